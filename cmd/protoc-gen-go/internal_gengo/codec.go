@@ -0,0 +1,727 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal_gengo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// GenerateCodec specifies whether to generate specialized size, marshal,
+// and unmarshal functions for messages, which are used in place of the
+// table-driven implementation in the runtime.
+var GenerateCodec = false
+
+// canGenerateCodec reports whether specialized codec functions can be
+// generated for the message. Messages with features that require the
+// assistance of the runtime (extensions, weak fields, and groups)
+// always use the table-driven implementation.
+func canGenerateCodec(m *messageInfo) bool {
+	if m.Desc.IsMapEntry() || m.Desc.ExtensionRanges().Len() > 0 || m.hasWeak {
+		return false
+	}
+	for _, field := range m.Fields {
+		if field.Desc.Kind() == protoreflect.GroupKind {
+			return false
+		}
+	}
+	return true
+}
+
+// hasCodec reports whether specialized codec functions are generated
+// for any message in the file.
+func (f *fileInfo) hasCodec() bool {
+	for _, m := range f.allMessages {
+		if m.genCodec {
+			return true
+		}
+	}
+	return false
+}
+
+func codecSizeFuncName(f *fileInfo, m *protogen.Message) string {
+	return fileVarName(f.File, m.GoIdent.GoName+"_size")
+}
+func codecMarshalFuncName(f *fileInfo, m *protogen.Message) string {
+	return fileVarName(f.File, m.GoIdent.GoName+"_marshal")
+}
+func codecUnmarshalFuncName(f *fileInfo, m *protogen.Message) string {
+	return fileVarName(f.File, m.GoIdent.GoName+"_unmarshal")
+}
+
+// hasCodec reports whether the message has specialized codec functions
+// generated in this file.
+func hasCodec(f *fileInfo, message *protogen.Message) bool {
+	for _, m := range f.allMessages {
+		if m.Message == message {
+			return m.genCodec
+		}
+	}
+	return false
+}
+
+// codecFields returns the fields of a message in the order that the
+// table-driven implementation marshals them: fields not within a oneof
+// sorted by field number, followed by the oneofs in declaration order.
+func codecFields(m *messageInfo) (fields []*protogen.Field, oneofs []*protogen.Oneof) {
+	for _, field := range m.Fields {
+		if oneof := field.Oneof; oneof != nil && !oneof.Desc.IsSynthetic() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Desc.Number() < fields[j].Desc.Number()
+	})
+	for _, oneof := range m.Oneofs {
+		if !oneof.Desc.IsSynthetic() {
+			oneofs = append(oneofs, oneof)
+		}
+	}
+	return fields, oneofs
+}
+
+func genMessageCodec(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	genMessageCodecSize(g, f, m)
+	genMessageCodecMarshal(g, f, m)
+	genMessageCodecUnmarshal(g, f, m)
+}
+
+func genMessageCodecSize(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	fields, oneofs := codecFields(m)
+	g.P("func ", codecSizeFuncName(f, m.Message), "(x *", m.GoIdent, ", flags ", protoifacePackage.Ident("MarshalInputFlags"), ") (n int) {")
+	g.P("if x == nil {")
+	g.P("return 0")
+	g.P("}")
+	g.P("if flags&", protoifacePackage.Ident("MarshalUseCachedSize"), " != 0 && flags&", protoifacePackage.Ident("MarshalReadOnly"), " == 0 {")
+	g.P("if size := ", atomicPackage.Ident("LoadInt32"), "(&x.sizeCache); size >= 0 {")
+	g.P("return int(size)")
+	g.P("}")
+	g.P("}")
+	for _, field := range fields {
+		genCodecSizeField(g, f, field, "x."+field.GoName)
+	}
+	for _, oneof := range oneofs {
+		genCodecOneofSwitch(g, oneof, func(field *protogen.Field) {
+			g.P("n += ", protowire.SizeTag(field.Desc.Number()), " + ", codecValueSize(g, f, field, "v."+field.GoName))
+		})
+	}
+	g.P("n += len(x.unknownFields)")
+	g.P("if flags&", protoifacePackage.Ident("MarshalReadOnly"), " == 0 {")
+	g.P("if n > ", mathPackage.Ident("MaxInt32"), " {")
+	g.P(atomicPackage.Ident("StoreInt32"), "(&x.sizeCache, -1)")
+	g.P("} else {")
+	g.P(atomicPackage.Ident("StoreInt32"), "(&x.sizeCache, int32(n))")
+	g.P("}")
+	g.P("}")
+	g.P("return n")
+	g.P("}")
+	g.P()
+}
+
+func genCodecSizeField(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, x string) {
+	fd := field.Desc
+	tagSize := protowire.SizeTag(fd.Number())
+	switch {
+	case fd.IsMap():
+		key, val := field.Message.Fields[0], field.Message.Fields[1]
+		k, v := "k", "v"
+		if !codecSizeUsesValue(key) {
+			k = "_"
+		}
+		if !codecSizeUsesValue(val) {
+			v = "_"
+		}
+		entrySize := fmt.Sprint(protowire.SizeTag(1), " + ", codecValueSize(g, f, key, "k"), " + ",
+			protowire.SizeTag(2), " + ", codecValueSize(g, f, val, "v"))
+		if k == "_" && v == "_" {
+			g.P("n += len(", x, ") * (", tagSize, " + ", protowirePackage.Ident("SizeBytes"), "(", entrySize, "))")
+			return
+		}
+		if v == "_" {
+			g.P("for ", k, " := range ", x, " {")
+		} else {
+			g.P("for ", k, ", ", v, " := range ", x, " {")
+		}
+		g.P("n += ", tagSize, " + ", protowirePackage.Ident("SizeBytes"), "(", entrySize, ")")
+		g.P("}")
+	case fd.IsList() && fd.IsPacked():
+		g.P("if len(", x, ") > 0 {")
+		genCodecPackedSize(g, f, field, x)
+		g.P("n += ", tagSize, " + ", protowirePackage.Ident("SizeBytes"), "(l)")
+		g.P("}")
+	case fd.IsList():
+		if !codecSizeUsesValue(field) {
+			g.P("n += len(", x, ") * (", tagSize, " + ", codecValueSize(g, f, field, ""), ")")
+			return
+		}
+		g.P("for _, v := range ", x, " {")
+		g.P("n += ", tagSize, " + ", codecValueSize(g, f, field, "v"))
+		g.P("}")
+	default:
+		v := x
+		if fd.HasPresence() && fd.Kind() != protoreflect.BytesKind && fd.Kind() != protoreflect.MessageKind {
+			v = "*" + x
+		}
+		g.P("if ", codecPresent(g, field, x), " {")
+		g.P("n += ", tagSize, " + ", codecValueSize(g, f, field, v))
+		g.P("}")
+	}
+}
+
+// genCodecPackedSize emits code that declares the variable l as the length
+// of the packed encoding of the list x, excluding the tag and length prefix.
+func genCodecPackedSize(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, x string) {
+	if !codecSizeUsesValue(field) {
+		g.P("l := len(", x, ") * ", codecValueSize(g, f, field, ""))
+		return
+	}
+	g.P("l := 0")
+	g.P("for _, v := range ", x, " {")
+	g.P("l += ", codecValueSize(g, f, field, "v"))
+	g.P("}")
+}
+
+func genMessageCodecMarshal(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	fields, oneofs := codecFields(m)
+	g.P("func ", codecMarshalFuncName(f, m.Message), "(b []byte, x *", m.GoIdent, ", flags ", protoifacePackage.Ident("MarshalInputFlags"), ") ([]byte, error) {")
+	g.P("if x == nil {")
+	g.P("return b, nil")
+	g.P("}")
+	var needErr bool
+	for _, field := range m.Fields {
+		needErr = needErr || codecMarshalNeedsErr(field)
+	}
+	if needErr {
+		g.P("var err error")
+	}
+	for _, field := range fields {
+		genCodecMarshalField(g, f, field, "x."+field.GoName)
+	}
+	for _, oneof := range oneofs {
+		genCodecOneofSwitch(g, oneof, func(field *protogen.Field) {
+			genCodecAppendTag(g, field.Desc.Number(), codecWireType(field.Desc))
+			genCodecAppendValue(g, f, field, "v."+field.GoName)
+		})
+	}
+	g.P("b = append(b, x.unknownFields...)")
+	g.P("return b, nil")
+	g.P("}")
+	g.P()
+}
+
+func genCodecMarshalField(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, x string) {
+	fd := field.Desc
+	wtyp := codecWireType(fd)
+	switch {
+	case fd.IsMap():
+		key, val := field.Message.Fields[0], field.Message.Fields[1]
+		keyType, _ := fieldGoType(g, f, key)
+		g.P("if len(", x, ") > 0 {")
+		g.P("if flags&", protoifacePackage.Ident("MarshalDeterministic"), " != 0 {")
+		g.P("keys := make([]", keyType, ", 0, len(", x, "))")
+		g.P("for k := range ", x, " {")
+		g.P("keys = append(keys, k)")
+		g.P("}")
+		if key.Desc.Kind() == protoreflect.BoolKind {
+			g.P(sortPackage.Ident("Slice"), "(keys, func(i, j int) bool { return !keys[i] && keys[j] })")
+		} else {
+			g.P(sortPackage.Ident("Slice"), "(keys, func(i, j int) bool { return keys[i] < keys[j] })")
+		}
+		g.P("for _, k := range keys {")
+		g.P("v := ", x, "[k]")
+		genCodecMarshalMapEntry(g, f, field, key, val)
+		g.P("}")
+		g.P("} else {")
+		g.P("for k, v := range ", x, " {")
+		genCodecMarshalMapEntry(g, f, field, key, val)
+		g.P("}")
+		g.P("}")
+		g.P("}")
+	case fd.IsList() && fd.IsPacked():
+		g.P("if len(", x, ") > 0 {")
+		genCodecAppendTag(g, fd.Number(), protowire.BytesType)
+		genCodecPackedSize(g, f, field, x)
+		g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, uint64(l))")
+		g.P("for _, v := range ", x, " {")
+		genCodecAppendValue(g, f, field, "v")
+		g.P("}")
+		g.P("}")
+	case fd.IsList():
+		g.P("for _, v := range ", x, " {")
+		genCodecAppendTag(g, fd.Number(), wtyp)
+		genCodecAppendValue(g, f, field, "v")
+		g.P("}")
+	default:
+		v := x
+		if fd.HasPresence() && fd.Kind() != protoreflect.BytesKind && fd.Kind() != protoreflect.MessageKind {
+			v = "*" + x
+		}
+		g.P("if ", codecPresent(g, field, x), " {")
+		genCodecAppendTag(g, fd.Number(), wtyp)
+		genCodecAppendValue(g, f, field, v)
+		g.P("}")
+	}
+}
+
+func genCodecMarshalMapEntry(g *protogen.GeneratedFile, f *fileInfo, field, key, val *protogen.Field) {
+	genCodecAppendTag(g, field.Desc.Number(), protowire.BytesType)
+	valSize := codecValueSize(g, f, val, "v")
+	if val.Desc.Kind() == protoreflect.MessageKind {
+		// Compute the size of the message value only once.
+		g.P("vn := ", codecMessageSize(g, f, val, "v"))
+		valSize = fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("SizeBytes")), "(vn)")
+	}
+	g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, uint64(",
+		protowire.SizeTag(1), " + ", codecValueSize(g, f, key, "k"), " + ",
+		protowire.SizeTag(2), " + ", valSize, "))")
+	genCodecAppendTag(g, 1, codecWireType(key.Desc))
+	genCodecAppendValue(g, f, key, "k")
+	genCodecAppendTag(g, 2, codecWireType(val.Desc))
+	if val.Desc.Kind() == protoreflect.MessageKind {
+		g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, uint64(vn))")
+		genCodecAppendMessage(g, f, val, "v")
+		return
+	}
+	genCodecAppendValue(g, f, val, "v")
+}
+
+func genMessageCodecUnmarshal(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	g.P("func ", codecUnmarshalFuncName(f, m.Message), "(b []byte, x *", m.GoIdent, ", opts ", protoPackage.Ident("UnmarshalOptions"), ") error {")
+	g.P("for len(b) > 0 {")
+	g.P("num, wtyp, n := ", protowirePackage.Ident("ConsumeTag"), "(b)")
+	g.P("if n < 0 || num > ", protowirePackage.Ident("MaxValidNumber"), " {")
+	g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(b)")
+	g.P("}")
+	if len(m.Fields) > 0 {
+		g.P("switch num {")
+		for _, field := range m.Fields {
+			g.P("case ", field.Desc.Number(), ":")
+			genCodecUnmarshalField(g, f, field)
+			g.P("b = b[n+m:]")
+			g.P("continue")
+		}
+		g.P("}")
+	}
+	g.P("m := ", protowirePackage.Ident("ConsumeFieldValue"), "(num, wtyp, b[n:])")
+	g.P("if m < 0 {")
+	g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(b[n:])")
+	g.P("}")
+	g.P("if !opts.DiscardUnknown {")
+	g.P("x.unknownFields = append(x.unknownFields, b[:n+m]...)")
+	g.P("}")
+	g.P("b = b[n+m:]")
+	g.P("}")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+}
+
+// genCodecUnmarshalField emits code that decodes the field value following
+// a tag of length n in b, declaring m as the length of the value.
+// The emitted code breaks out of the enclosing switch statement if
+// the wire type does not match the field.
+func genCodecUnmarshalField(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field) {
+	fd := field.Desc
+	x := "x." + field.GoName
+	if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+		x = "x." + field.Oneof.GoName
+	}
+	if fd.IsList() && codecWireType(fd) != protowire.BytesType {
+		// Repeated scalars are accepted in both packed and unpacked form.
+		g.P("if wtyp == ", protowirePackage.Ident("BytesType"), " {")
+		g.P("v, m := ", protowirePackage.Ident("ConsumeBytes"), "(b[n:])")
+		g.P("if m < 0 {")
+		g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(b[n:])")
+		g.P("}")
+		g.P("for len(v) > 0 {")
+		genCodecConsumeValue(g, field, "w", "l", "v")
+		g.P(x, " = append(", x, ", ", codecDecodeValue(g, field, "w"), ")")
+		g.P("v = v[l:]")
+		g.P("}")
+		g.P("b = b[n+m:]")
+		g.P("continue")
+		g.P("}")
+	}
+	g.P("if wtyp != ", protowirePackage.Ident(codecWireTypeName(codecWireType(fd))), " {")
+	g.P("break")
+	g.P("}")
+	switch {
+	case fd.IsMap():
+		genCodecUnmarshalMapEntry(g, f, field, x)
+	case fd.Kind() == protoreflect.MessageKind:
+		genCodecConsumeValue(g, field, "v", "m", "b[n:]")
+		goType := g.QualifiedGoIdent(field.Message.GoIdent)
+		switch {
+		case fd.IsList():
+			g.P("mv := new(", goType, ")")
+			genCodecUnmarshalMessage(g, f, field, "v", "mv")
+			g.P(x, " = append(", x, ", mv)")
+		case field.Oneof != nil && !field.Oneof.Desc.IsSynthetic():
+			g.P("ov, ok := ", x, ".(*", field.GoIdent, ")")
+			g.P("if !ok || ov == nil {")
+			g.P("ov = new(", field.GoIdent, ")")
+			g.P("}")
+			g.P("if ov.", field.GoName, " == nil {")
+			g.P("ov.", field.GoName, " = new(", goType, ")")
+			g.P("}")
+			genCodecUnmarshalMessage(g, f, field, "v", "ov."+field.GoName)
+			g.P(x, " = ov")
+		default:
+			g.P("if ", x, " == nil {")
+			g.P(x, " = new(", goType, ")")
+			g.P("}")
+			genCodecUnmarshalMessage(g, f, field, "v", x)
+		}
+	default:
+		genCodecConsumeValue(g, field, "v", "m", "b[n:]")
+		v := codecDecodeValue(g, field, "v")
+		if fd.Kind() == protoreflect.BytesKind {
+			if fd.HasPresence() || fd.IsList() || field.Oneof != nil {
+				v = "append([]byte{}, v...)"
+			} else {
+				v = "append([]byte(nil), v...)"
+			}
+		}
+		switch {
+		case fd.IsList():
+			g.P(x, " = append(", x, ", ", v, ")")
+		case field.Oneof != nil && !field.Oneof.Desc.IsSynthetic():
+			g.P(x, " = &", field.GoIdent, "{", field.GoName, ": ", v, "}")
+		case fd.HasPresence() && fd.Kind() != protoreflect.BytesKind:
+			goType, _ := fieldGoType(g, f, field)
+			g.P("if ", x, " == nil {")
+			g.P(x, " = new(", goType, ")")
+			g.P("}")
+			g.P("*", x, " = ", v)
+		default:
+			g.P(x, " = ", v)
+		}
+	}
+}
+
+func genCodecUnmarshalMapEntry(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, x string) {
+	key, val := field.Message.Fields[0], field.Message.Fields[1]
+	keyType, _ := fieldGoType(g, f, key)
+	valType, _ := fieldGoType(g, f, val)
+	g.P("v, m := ", protowirePackage.Ident("ConsumeBytes"), "(b[n:])")
+	g.P("if m < 0 {")
+	g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(b[n:])")
+	g.P("}")
+	g.P("var mk ", keyType)
+	if val.Desc.Kind() == protoreflect.MessageKind {
+		g.P("mv := new(", g.QualifiedGoIdent(val.Message.GoIdent), ")")
+	} else {
+		g.P("var mv ", valType)
+	}
+	g.P("for len(v) > 0 {")
+	g.P("en, et, k := ", protowirePackage.Ident("ConsumeTag"), "(v)")
+	g.P("if k < 0 || en > ", protowirePackage.Ident("MaxValidNumber"), " {")
+	g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(v)")
+	g.P("}")
+	g.P("switch {")
+	for _, entry := range []struct {
+		field *protogen.Field
+		name  string
+	}{{key, "mk"}, {val, "mv"}} {
+		g.P("case en == ", entry.field.Desc.Number(), " && et == ", protowirePackage.Ident(codecWireTypeName(codecWireType(entry.field.Desc))), ":")
+		genCodecConsumeValue(g, entry.field, "w", "l", "v[k:]")
+		switch entry.field.Desc.Kind() {
+		case protoreflect.MessageKind:
+			genCodecUnmarshalMessage(g, f, entry.field, "w", entry.name)
+		case protoreflect.BytesKind:
+			g.P(entry.name, " = append([]byte{}, w...)")
+		default:
+			g.P(entry.name, " = ", codecDecodeValue(g, entry.field, "w"))
+		}
+		g.P("v = v[k+l:]")
+		g.P("continue")
+	}
+	g.P("}")
+	g.P("l := ", protowirePackage.Ident("ConsumeFieldValue"), "(en, et, v[k:])")
+	g.P("if l < 0 {")
+	g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(v[k:])")
+	g.P("}")
+	g.P("v = v[k+l:]")
+	g.P("}")
+	g.P("if ", x, " == nil {")
+	g.P(x, " = make(map[", keyType, "]", valType, ")")
+	g.P("}")
+	g.P(x, "[mk] = mv")
+}
+
+// genCodecOneofSwitch emits a type switch over the set member of a oneof,
+// calling genCase to emit the code for each member with the wrapper value v.
+// A nil wrapper value is treated as if the oneof were unset.
+func genCodecOneofSwitch(g *protogen.GeneratedFile, oneof *protogen.Oneof, genCase func(*protogen.Field)) {
+	g.P("switch v := x.", oneof.GoName, ".(type) {")
+	for _, field := range oneof.Fields {
+		g.P("case *", field.GoIdent, ":")
+		g.P("if v == nil {")
+		g.P("break")
+		g.P("}")
+		genCase(field)
+	}
+	g.P("}")
+}
+
+// codecPresent returns a condition reporting whether the singular field x
+// is populated and must be marshaled.
+func codecPresent(g *protogen.GeneratedFile, field *protogen.Field, x string) string {
+	fd := field.Desc
+	switch {
+	case fd.HasPresence() || fd.Kind() == protoreflect.MessageKind:
+		return x + " != nil"
+	case fd.Kind() == protoreflect.BoolKind:
+		return x
+	case fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind:
+		return "len(" + x + ") > 0"
+	case fd.Kind() == protoreflect.FloatKind || fd.Kind() == protoreflect.DoubleKind:
+		// Negative zero is distinct from the zero value.
+		return fmt.Sprint(x, " != 0 || ", g.QualifiedGoIdent(mathPackage.Ident("Signbit")), "(float64(", x, "))")
+	default:
+		return x + " != 0"
+	}
+}
+
+// codecWireType returns the wire type of a single value of the field.
+func codecWireType(fd protoreflect.FieldDescriptor) protowire.Type {
+	switch fd.Kind() {
+	case protoreflect.BoolKind, protoreflect.EnumKind,
+		protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind:
+		return protowire.VarintType
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	default:
+		return protowire.BytesType
+	}
+}
+
+func codecWireTypeName(wtyp protowire.Type) string {
+	switch wtyp {
+	case protowire.VarintType:
+		return "VarintType"
+	case protowire.Fixed32Type:
+		return "Fixed32Type"
+	case protowire.Fixed64Type:
+		return "Fixed64Type"
+	default:
+		return "BytesType"
+	}
+}
+
+func genCodecAppendTag(g *protogen.GeneratedFile, num protoreflect.FieldNumber, wtyp protowire.Type) {
+	var s []string
+	for _, c := range protowire.AppendTag(nil, num, wtyp) {
+		s = append(s, fmt.Sprintf("0x%x", c))
+	}
+	g.P("b = append(b, ", strings.Join(s, ", "), ")")
+}
+
+// codecSizeUsesValue reports whether the size of a value of the field
+// depends on the value itself.
+func codecSizeUsesValue(field *protogen.Field) bool {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind,
+		protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind,
+		protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return false
+	}
+	return true
+}
+
+// codecValueSize returns an expression for the size of the value v of the
+// field, excluding the tag.
+func codecValueSize(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, v string) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "1"
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return "4"
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return "8"
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.EnumKind:
+		return fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("SizeVarint")), "(uint64(", v, "))")
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("SizeVarint")), "(",
+			g.QualifiedGoIdent(protowirePackage.Ident("EncodeZigZag")), "(int64(", v, ")))")
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("SizeBytes")), "(len(", v, "))")
+	default:
+		return fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("SizeBytes")), "(", codecMessageSize(g, f, field, v), ")")
+	}
+}
+
+// codecMessageSize returns an expression for the size of the message v.
+func codecMessageSize(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, v string) string {
+	if hasCodec(f, field.Message) {
+		return fmt.Sprint(codecSizeFuncName(f, field.Message), "(", v, ", flags)")
+	}
+	return fmt.Sprint(g.QualifiedGoIdent(protoPackage.Ident("Size")), "(", v, ")")
+}
+
+// codecMarshalNeedsErr reports whether marshaling the field may fail.
+func codecMarshalNeedsErr(field *protogen.Field) bool {
+	fd := field.Desc
+	if fd.IsMap() {
+		return codecMarshalNeedsErr(field.Message.Fields[0]) || codecMarshalNeedsErr(field.Message.Fields[1])
+	}
+	return fd.Kind() == protoreflect.MessageKind || codecEnforceUTF8(fd)
+}
+
+func codecEnforceUTF8(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.StringKind && fd.Syntax() == protoreflect.Proto3
+}
+
+// genCodecAppendValue emits code that appends the value v of the field
+// to b, excluding the tag.
+func genCodecAppendValue(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, v string) {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, ", protowirePackage.Ident("EncodeBool"), "(", v, "))")
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.EnumKind:
+		g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, uint64(", v, "))")
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, ", protowirePackage.Ident("EncodeZigZag"), "(int64(", v, ")))")
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+		g.P("b = ", protowirePackage.Ident("AppendFixed32"), "(b, uint32(", v, "))")
+	case protoreflect.FloatKind:
+		g.P("b = ", protowirePackage.Ident("AppendFixed32"), "(b, ", mathPackage.Ident("Float32bits"), "(", v, "))")
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		g.P("b = ", protowirePackage.Ident("AppendFixed64"), "(b, uint64(", v, "))")
+	case protoreflect.DoubleKind:
+		g.P("b = ", protowirePackage.Ident("AppendFixed64"), "(b, ", mathPackage.Ident("Float64bits"), "(", v, "))")
+	case protoreflect.StringKind:
+		if codecEnforceUTF8(field.Desc) {
+			g.P("if !", utf8Package.Ident("ValidString"), "(", v, ") {")
+			g.P("return b, ", protoimplPackage.Ident("X"), ".ErrInvalidUTF8()")
+			g.P("}")
+		}
+		g.P("b = ", protowirePackage.Ident("AppendString"), "(b, ", v, ")")
+	case protoreflect.BytesKind:
+		g.P("b = ", protowirePackage.Ident("AppendBytes"), "(b, ", v, ")")
+	case protoreflect.MessageKind:
+		g.P("b = ", protowirePackage.Ident("AppendVarint"), "(b, uint64(", codecMessageSize(g, f, field, v), "))")
+		genCodecAppendMessage(g, f, field, v)
+	}
+}
+
+// genCodecAppendMessage emits code that appends the contents of the
+// message v to b, excluding the tag and length prefix.
+func genCodecAppendMessage(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, v string) {
+	if hasCodec(f, field.Message) {
+		g.P("b, err = ", codecMarshalFuncName(f, field.Message), "(b, ", v, ", flags)")
+	} else {
+		g.P("b, err = ", protoPackage.Ident("MarshalOptions"), "{")
+		g.P("AllowPartial: true,")
+		g.P("Deterministic: flags&", protoifacePackage.Ident("MarshalDeterministic"), " != 0,")
+		g.P("UseCachedSize: true,")
+		g.P("}.MarshalAppend(b, ", v, ")")
+	}
+	g.P("if err != nil {")
+	g.P("return b, err")
+	g.P("}")
+}
+
+// genCodecConsumeValue emits code that declares v as the value of the field
+// consumed from buf, and n as the number of bytes consumed.
+func genCodecConsumeValue(g *protogen.GeneratedFile, field *protogen.Field, v, n, buf string) {
+	var consume string
+	switch codecWireType(field.Desc) {
+	case protowire.VarintType:
+		consume = "ConsumeVarint"
+	case protowire.Fixed32Type:
+		consume = "ConsumeFixed32"
+	case protowire.Fixed64Type:
+		consume = "ConsumeFixed64"
+	default:
+		consume = "ConsumeBytes"
+		if field.Desc.Kind() == protoreflect.StringKind {
+			consume = "ConsumeString"
+		}
+	}
+	g.P(v, ", ", n, " := ", protowirePackage.Ident(consume), "(", buf, ")")
+	g.P("if ", n, " < 0 {")
+	g.P("return ", protoimplPackage.Ident("X"), ".ErrDecode(", buf, ")")
+	g.P("}")
+	if codecEnforceUTF8(field.Desc) {
+		g.P("if !", utf8Package.Ident("ValidString"), "(", v, ") {")
+		g.P("return ", protoimplPackage.Ident("X"), ".ErrInvalidUTF8()")
+		g.P("}")
+	}
+}
+
+// codecDecodeValue returns an expression converting the raw value v
+// consumed by genCodecConsumeValue to the Go type of the field.
+func codecDecodeValue(g *protogen.GeneratedFile, field *protogen.Field, v string) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("DecodeBool")), "(", v, ")")
+	case protoreflect.EnumKind:
+		return fmt.Sprint(g.QualifiedGoIdent(field.Enum.GoIdent), "(", v, ")")
+	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind:
+		return "int32(" + v + ")"
+	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind:
+		return "int64(" + v + ")"
+	case protoreflect.Uint32Kind:
+		return "uint32(" + v + ")"
+	case protoreflect.Sint32Kind:
+		return fmt.Sprint("int32(", g.QualifiedGoIdent(protowirePackage.Ident("DecodeZigZag")), "(", v, " & ", g.QualifiedGoIdent(mathPackage.Ident("MaxUint32")), "))")
+	case protoreflect.Sint64Kind:
+		return fmt.Sprint(g.QualifiedGoIdent(protowirePackage.Ident("DecodeZigZag")), "(", v, ")")
+	case protoreflect.FloatKind:
+		return fmt.Sprint(g.QualifiedGoIdent(mathPackage.Ident("Float32frombits")), "(", v, ")")
+	case protoreflect.DoubleKind:
+		return fmt.Sprint(g.QualifiedGoIdent(mathPackage.Ident("Float64frombits")), "(", v, ")")
+	default:
+		return v
+	}
+}
+
+// genCodecUnmarshalMessage emits code that merges the wire-format bytes v
+// into the non-nil message x.
+func genCodecUnmarshalMessage(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field, v, x string) {
+	if hasCodec(f, field.Message) {
+		g.P("if err := ", codecUnmarshalFuncName(f, field.Message), "(", v, ", ", x, ", opts); err != nil {")
+	} else {
+		g.P("if err := opts.Unmarshal(", v, ", ", x, "); err != nil {")
+	}
+	g.P("return err")
+	g.P("}")
+}
+
+// genMessageCodecMethods emits code that installs the specialized codec
+// functions as the fast-path methods of the message.
+func genMessageCodecMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	idx := f.allMessagesByPtr[m]
+	typesVar := messageTypesVarName(f)
+	g.P(typesVar, "[", idx, "].Methods = ", protoifacePackage.Ident("Methods"), "{")
+	g.P("Flags: ", protoifacePackage.Ident("SupportMarshalDeterministic"), " | ", protoifacePackage.Ident("SupportUnmarshalDiscardUnknown"), ",")
+	g.P("Size: func(in ", protoifacePackage.Ident("SizeInput"), ") ", protoifacePackage.Ident("SizeOutput"), " {")
+	g.P("return ", protoifacePackage.Ident("SizeOutput"), "{Size: ", codecSizeFuncName(f, m.Message), "(in.Message.Interface().(*", m.GoIdent, "), in.Flags)}")
+	g.P("},")
+	g.P("Marshal: func(in ", protoifacePackage.Ident("MarshalInput"), ") (", protoifacePackage.Ident("MarshalOutput"), ", error) {")
+	g.P("b, err := ", codecMarshalFuncName(f, m.Message), "(in.Buf, in.Message.Interface().(*", m.GoIdent, "), in.Flags)")
+	g.P("return ", protoifacePackage.Ident("MarshalOutput"), "{Buf: b}, err")
+	g.P("},")
+	g.P("Unmarshal: func(in ", protoifacePackage.Ident("UnmarshalInput"), ") (", protoifacePackage.Ident("UnmarshalOutput"), ", error) {")
+	g.P("return ", protoifacePackage.Ident("UnmarshalOutput"), "{}, ", codecUnmarshalFuncName(f, m.Message), "(in.Buf, in.Message.Interface().(*", m.GoIdent, "), ", protoPackage.Ident("UnmarshalOptions"), "{")
+	g.P("Merge: true,")
+	g.P("AllowPartial: true,")
+	g.P("DiscardUnknown: in.Flags&", protoifacePackage.Ident("UnmarshalDiscardUnknown"), " != 0,")
+	g.P("AliasBuffer: in.Flags&", protoifacePackage.Ident("UnmarshalAliasBuffer"), " != 0,")
+	g.P("Resolver: in.Resolver,")
+	g.P("})")
+	g.P("},")
+	g.P("}")
+}
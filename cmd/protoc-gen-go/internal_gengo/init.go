@@ -125,6 +125,7 @@ type messageInfo struct {
 
 	isTracked bool
 	hasWeak   bool
+	genCodec  bool
 }
 
 func newMessageInfo(f *fileInfo, message *protogen.Message) *messageInfo {
@@ -135,6 +136,7 @@ func newMessageInfo(f *fileInfo, message *protogen.Message) *messageInfo {
 	for _, field := range m.Fields {
 		m.hasWeak = m.hasWeak || field.Desc.IsWeak()
 	}
+	m.genCodec = GenerateCodec && canGenerateCodec(m)
 	return m
 }
 
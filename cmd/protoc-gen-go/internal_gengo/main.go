@@ -35,10 +35,13 @@ var GenerateVersionMarkers = true
 
 // Standard library dependencies.
 const (
+	atomicPackage  = protogen.GoImportPath("sync/atomic")
 	base64Package  = protogen.GoImportPath("encoding/base64")
+	jsonPackage    = protogen.GoImportPath("encoding/json")
 	mathPackage    = protogen.GoImportPath("math")
 	reflectPackage = protogen.GoImportPath("reflect")
 	sortPackage    = protogen.GoImportPath("sort")
+	strconvPackage = protogen.GoImportPath("strconv")
 	stringsPackage = protogen.GoImportPath("strings")
 	syncPackage    = protogen.GoImportPath("sync")
 	timePackage    = protogen.GoImportPath("time")
@@ -54,9 +57,11 @@ var (
 	protoPackage         goImportPath = protogen.GoImportPath("google.golang.org/protobuf/proto")
 	protoifacePackage    goImportPath = protogen.GoImportPath("google.golang.org/protobuf/runtime/protoiface")
 	protoimplPackage     goImportPath = protogen.GoImportPath("google.golang.org/protobuf/runtime/protoimpl")
+	protowirePackage     goImportPath = protogen.GoImportPath("google.golang.org/protobuf/encoding/protowire")
 	protojsonPackage     goImportPath = protogen.GoImportPath("google.golang.org/protobuf/encoding/protojson")
 	protoreflectPackage  goImportPath = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoreflect")
 	protoregistryPackage goImportPath = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoregistry")
+	durationpbPackage    goImportPath = protogen.GoImportPath("google.golang.org/protobuf/types/known/durationpb")
 )
 
 type goImportPath interface {
@@ -85,6 +90,10 @@ func GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.Generated
 		g.P("_ = ", protoimplPackage.Ident("EnforceVersion"), "(", protoimpl.GenVersion, " - ", protoimplPackage.Ident("MinVersion"), ")")
 		g.P("// Verify that runtime/protoimpl is sufficiently up-to-date.")
 		g.P("_ = ", protoimplPackage.Ident("EnforceVersion"), "(", protoimplPackage.Ident("MaxVersion"), " - ", protoimpl.GenVersion, ")")
+		if f.hasCodec() {
+			g.P("// Verify that runtime/protoimpl supports the specialized codec functions.")
+			g.P("_ = ", protoimplPackage.Ident("EnforceVersion"), "(", protoimplPackage.Ident("MaxVersion"), " - ", protoimpl.CodecVersion, ")")
+		}
 		g.P(")")
 		g.P()
 	}
@@ -330,6 +339,9 @@ func genMessage(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
 	genMessageDefaultDecls(g, f, m)
 	genMessageMethods(g, f, m)
 	genMessageOneofWrapperTypes(g, f, m)
+	if m.genCodec {
+		genMessageCodec(g, f, m)
+	}
 }
 
 func genMessageFields(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
@@ -200,6 +200,13 @@ func genReflectFileDescriptor(gen *protogen.Plugin, g *protogen.GeneratedFile, f
 				g.P("}")
 			}
 		}
+
+		// Populate MessageInfo.Methods.
+		for _, message := range f.allMessages {
+			if message.genCodec {
+				genMessageCodecMethods(g, f, message)
+			}
+		}
 	}
 
 	g.P("type x struct{}")
@@ -36,6 +36,15 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
 	}
 	... // make use of any
 
+ By default, the type URL is the full name of the message type prefixed with
+ "type.googleapis.com/". A different prefix may be used with NewWithPrefix:
+
+	any, err := anypb.NewWithPrefix(m, "schemas.example.com/tenant")
+	if err != nil {
+		... // handle error
+	}
+	... // any.TypeUrl is "schemas.example.com/tenant/foopb.MyMessage"
+
 
  Unmarshaling an Any
 
@@ -83,6 +92,14 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
  listed in the case clauses are linked into the Go binary and therefore also
  registered in the global registry.
 
+ Message types that are not linked into the Go binary can be resolved from
+ descriptors by setting the Resolver in proto.UnmarshalOptions to a
+ dynamicpb.Types and calling the UnmarshalNew function:
+
+	m, err := anypb.UnmarshalNew(any, proto.UnmarshalOptions{
+		Resolver: dynamicpb.NewTypes(files),
+	})
+
 
  Type checking an Any
 
@@ -148,6 +165,29 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
 	ts := timestamppb.Now()
 	... // make use of ts as a *timestamppb.Timestamp
 
+
+ Parsing and formatting
+
+ The Parse function and Format method convert between a Timestamp and its
+ RFC 3339 representation, which is also used by the JSON format:
+
+	ts, err := timestamppb.Parse("1972-01-01T10:00:20.021Z")
+	if err != nil {
+		... // handle error
+	}
+	s := ts.Format() // "1972-01-01T10:00:20.021Z"
+
+
+ Arithmetic
+
+ The Add, Sub, and Compare methods operate exactly on the seconds and nanos
+ fields without converting to a time.Time, and never overflow:
+
+	later := ts.Add(durationpb.New(time.Hour))
+	elapsed := later.Sub(ts) // a *durationpb.Duration of one hour
+
+ The Normalize method carries nanos outside the range of 0 to 999,999,999
+ into the seconds.
 `
 	case genid.File_google_protobuf_duration_proto:
 		return ` Package durationpb contains generated types for ` + genid.File_google_protobuf_duration_proto + `.
@@ -193,6 +233,28 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
 	dur := durationpb.New(d)
 	... // make use of d as a *durationpb.Duration
 
+
+ Parsing and formatting
+
+ The Parse function and Format method convert between a Duration and its
+ JSON representation, which is a number of seconds with the suffix "s".
+ The ParseISO8601 function and FormatISO8601 method convert between a
+ Duration and its ISO 8601 representation:
+
+	dur, err := durationpb.ParseISO8601("PT1H30M")
+	if err != nil {
+		... // handle error
+	}
+	s := dur.Format() // "5400s"
+
+
+ Arithmetic
+
+ Since a Duration may represent spans of up to 10,000 years, which exceeds
+ the range of a time.Duration, the Add, Sub, and Compare methods operate
+ exactly on the seconds and nanos fields. The results saturate instead of
+ overflowing. The Normalize method carries excess nanos into the seconds
+ and gives them the same sign as the seconds.
 `
 	case genid.File_google_protobuf_struct_proto:
 		return ` Package structpb contains generated types for ` + genid.File_google_protobuf_struct_proto + `.
@@ -223,6 +285,39 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
  NewList, and NewValue constructor functions.
 
 
+ Conversion to and from arbitrary Go types
+
+ The ConvertValue and ConvertStruct functions construct messages from any Go
+ value that the "encoding/json" package can marshal, such as json.RawMessage,
+ json.Number, time.Time, and Go structs with json field tags.
+ The Value.ConvertTo, Struct.ConvertTo, and ListValue.ConvertTo methods are
+ their inverse and store the message in a Go value following the rules of
+ "encoding/json".Unmarshal:
+
+	type Person struct {
+		Name     string    ` + "`" + `json:"name"` + "`" + `
+		Birthday time.Time ` + "`" + `json:"birthday"` + "`" + `
+	}
+	s, err := structpb.ConvertStruct(Person{Name: "John Smith"})
+	if err != nil {
+		... // handle error
+	}
+	var p Person
+	if err := s.ConvertTo(&p); err != nil {
+		... // handle error
+	}
+
+
+ Accessing nested values
+
+ The Value.GetPath and Struct.GetPath methods retrieve a nested value by
+ a path of field names and list indexes (e.g., "phoneNumbers[0].type").
+ They return nil if the value does not exist, so the result can be used
+ directly with the getter methods of Value:
+
+	typ := m.GetPath("phoneNumbers[0].type").GetStringValue()
+
+
  Example usage
 
  Consider the following example JSON object:
@@ -338,6 +433,10 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
 func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
 	switch m.Desc.FullName() {
 	case genid.Any_message_fullname:
+		g.P("// DefaultTypeURLPrefix is the type URL prefix used by New and MarshalFrom.")
+		g.P("const DefaultTypeURLPrefix = \"type.googleapis.com/\"")
+		g.P()
+
 		g.P("// New marshals src into a new Any instance.")
 		g.P("func New(src ", protoPackage.Ident("Message"), ") (*Any, error) {")
 		g.P("	dst := new(Any)")
@@ -348,20 +447,45 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// NewWithPrefix marshals src into a new Any instance whose type URL")
+		g.P("// is the given prefix followed by the full name of src.")
+		g.P("// A trailing slash is added to the prefix if it does not already have one.")
+		g.P("func NewWithPrefix(src ", protoPackage.Ident("Message"), ", prefix string) (*Any, error) {")
+		g.P("	dst := new(Any)")
+		g.P("	if err := MarshalFromWithPrefix(dst, src, prefix, ", protoPackage.Ident("MarshalOptions"), "{}); err != nil {")
+		g.P("		return nil, err")
+		g.P("	}")
+		g.P("	return dst, nil")
+		g.P("}")
+		g.P()
+
 		g.P("// MarshalFrom marshals src into dst as the underlying message")
 		g.P("// using the provided marshal options.")
 		g.P("//")
 		g.P("// If no options are specified, call dst.MarshalFrom instead.")
 		g.P("func MarshalFrom(dst *Any, src ", protoPackage.Ident("Message"), ", opts ", protoPackage.Ident("MarshalOptions"), ") error {")
-		g.P("	const urlPrefix = \"type.googleapis.com/\"")
+		g.P("	return MarshalFromWithPrefix(dst, src, DefaultTypeURLPrefix, opts)")
+		g.P("}")
+		g.P()
+
+		g.P("// MarshalFromWithPrefix marshals src into dst as the underlying message")
+		g.P("// using the provided type URL prefix and marshal options.")
+		g.P("// A trailing slash is added to the prefix if it does not already have one.")
+		g.P("func MarshalFromWithPrefix(dst *Any, src ", protoPackage.Ident("Message"), ", prefix string, opts ", protoPackage.Ident("MarshalOptions"), ") error {")
 		g.P("	if src == nil {")
 		g.P("		return ", protoimplPackage.Ident("X"), ".NewError(\"invalid nil source message\")")
 		g.P("	}")
+		g.P("	if prefix == \"\" {")
+		g.P("		return ", protoimplPackage.Ident("X"), ".NewError(\"invalid empty type URL prefix\")")
+		g.P("	}")
+		g.P("	if !", stringsPackage.Ident("HasSuffix"), "(prefix, \"/\") {")
+		g.P("		prefix += \"/\"")
+		g.P("	}")
 		g.P("	b, err := opts.Marshal(src)")
 		g.P("	if err != nil {")
 		g.P("		return err")
 		g.P("	}")
-		g.P("	dst.TypeUrl = urlPrefix + string(src.ProtoReflect().Descriptor().FullName())")
+		g.P("	dst.TypeUrl = prefix + string(src.ProtoReflect().Descriptor().FullName())")
 		g.P("	dst.Value = b")
 		g.P("	return nil")
 		g.P("}")
@@ -429,6 +553,15 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// TypeURLPrefix reports the prefix of the type URL of the underlying message,")
+		g.P("// which is everything up to and including the last slash.")
+		g.P("// It returns an empty string if the type URL has no slash.")
+		g.P("func (x *Any) TypeURLPrefix() string {")
+		g.P("	url := x.GetTypeUrl()")
+		g.P("	return url[:", stringsPackage.Ident("LastIndexByte"), "(url, '/')+len(\"/\")]")
+		g.P("}")
+		g.P()
+
 		g.P("// MessageName reports the full name of the underlying message,")
 		g.P("// returning an empty string if invalid.")
 		g.P("func (x *Any) MessageName() ", protoreflectPackage.Ident("FullName"), " {")
@@ -541,6 +674,102 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// Parse parses a timestamp in the RFC 3339 format, which is the JSON format of")
+		g.P("// a Timestamp (e.g., \"1972-01-01T10:00:20.021Z\"). The year must have four")
+		g.P("// digits, the fractional seconds may have at most 9 digits, and the time zone")
+		g.P("// offset may be \"Z\" or of the form \"±hh:mm\".")
+		g.P("// It reports an error if the timestamp is invalid.")
+		g.P("func Parse(s string) (*Timestamp, error) {")
+		g.P("	secs, nanos, ok := ", protoimplPackage.Ident("X"), ".ParseTimestamp(s)")
+		g.P("	if !ok {")
+		g.P("		return nil, ", protoimplPackage.Ident("X"), ".NewError(\"invalid timestamp %q\", s)")
+		g.P("	}")
+		g.P("	x := &Timestamp{Seconds: secs, Nanos: nanos}")
+		g.P("	if err := x.CheckValid(); err != nil {")
+		g.P("		return nil, err")
+		g.P("	}")
+		g.P("	return x, nil")
+		g.P("}")
+		g.P()
+
+		g.P("// Format formats x in the RFC 3339 format in UTC, which is the JSON format of")
+		g.P("// a Timestamp (e.g., \"1972-01-01T10:00:20.021Z\"). The fractional seconds have")
+		g.P("// 0, 3, 6, or 9 digits depending on the required precision.")
+		g.P("// The timestamp is normalized before formatting. The result is only a valid")
+		g.P("// RFC 3339 timestamp if x is within the range reported by CheckValid.")
+		g.P("func (x *Timestamp) Format() string {")
+		g.P("	t := x.normalized()")
+		g.P("	return ", protoimplPackage.Ident("X"), ".FormatTimestamp(t.Seconds, t.Nanos)")
+		g.P("}")
+		g.P()
+
+		g.P("// Add returns x advanced by the exact duration d as a new normalized")
+		g.P("// Timestamp. A nil Timestamp or Duration is treated as zero.")
+		g.P("// The result may be outside the range of a valid timestamp,")
+		g.P("// but it saturates instead of overflowing the seconds.")
+		g.P("func (x *Timestamp) Add(d *", durationpbPackage.Ident("Duration"), ") *Timestamp {")
+		g.P("	return newTimestamp(x.asDuration().Add(d))")
+		g.P("}")
+		g.P()
+
+		g.P("// AddDate returns x advanced by the given number of years, months, and days")
+		g.P("// in UTC, normalizing dates in the same way as time.Time.AddDate")
+		g.P("// (e.g., adding one month to October 31 yields December 1).")
+		g.P("func (x *Timestamp) AddDate(years, months, days int) *Timestamp {")
+		g.P("	return New(x.AsTime().AddDate(years, months, days))")
+		g.P("}")
+		g.P()
+
+		g.P("// Sub returns the exact duration x-y as a new normalized Duration.")
+		g.P("// A nil Timestamp is treated as zero. The result may be outside the range of")
+		g.P("// a valid duration, but it saturates instead of overflowing the seconds.")
+		g.P("func (x *Timestamp) Sub(y *Timestamp) *", durationpbPackage.Ident("Duration"), " {")
+		g.P("	return x.asDuration().Sub(y.asDuration())")
+		g.P("}")
+		g.P()
+
+		g.P("// Compare returns -1, 0, or +1 depending on whether x is before,")
+		g.P("// equal to, or after y. A nil Timestamp is treated as zero.")
+		g.P("// Denormalized timestamps are compared by the instants they represent.")
+		g.P("func (x *Timestamp) Compare(y *Timestamp) int {")
+		g.P("	return x.asDuration().Compare(y.asDuration())")
+		g.P("}")
+		g.P()
+
+		g.P("// Normalize converts x to its canonical form where the nanos are within the")
+		g.P("// range of 0 to 999,999,999 inclusive. Excess nanos are carried into the")
+		g.P("// seconds.")
+		g.P("func (x *Timestamp) Normalize() {")
+		g.P("	t := x.normalized()")
+		g.P("	x.Seconds, x.Nanos = t.Seconds, t.Nanos")
+		g.P("}")
+		g.P()
+
+		g.P("func (x *Timestamp) normalized() *Timestamp {")
+		g.P("	return newTimestamp(x.asDuration().Add(nil))")
+		g.P("}")
+		g.P()
+
+		g.P("// asDuration returns x as a duration since the Unix epoch.")
+		g.P("func (x *Timestamp) asDuration() *", durationpbPackage.Ident("Duration"), " {")
+		g.P("	return &", durationpbPackage.Ident("Duration"), "{Seconds: x.GetSeconds(), Nanos: x.GetNanos()}")
+		g.P("}")
+		g.P()
+
+		g.P("// newTimestamp constructs a normalized Timestamp from a normalized duration")
+		g.P("// since the Unix epoch.")
+		g.P("func newTimestamp(d *", durationpbPackage.Ident("Duration"), ") *Timestamp {")
+		g.P("	secs, nanos := d.GetSeconds(), d.GetNanos()")
+		g.P("	if nanos < 0 {")
+		g.P("		if secs == ", mathPackage.Ident("MinInt64"), " {")
+		g.P("			return &Timestamp{Seconds: secs}")
+		g.P("		}")
+		g.P("		secs, nanos = secs-1, nanos+1e9")
+		g.P("	}")
+		g.P("	return &Timestamp{Seconds: secs, Nanos: nanos}")
+		g.P("}")
+		g.P()
+
 	case genid.Duration_message_fullname:
 		g.P("// New constructs a new Duration from the provided time.Duration.")
 		g.P("func New(d ", timePackage.Ident("Duration"), ") *Duration {")
@@ -633,6 +862,163 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// Parse parses a duration in the JSON format of a Duration, which is a decimal")
+		g.P("// number of seconds with the suffix \"s\" (e.g., \"1.5s\"). The fractional part")
+		g.P("// may have at most 9 digits. It reports an error if the duration is invalid.")
+		g.P("func Parse(s string) (*Duration, error) {")
+		g.P("	secs, nanos, ok := ", protoimplPackage.Ident("X"), ".ParseDuration(s)")
+		g.P("	if !ok {")
+		g.P("		return nil, ", protoimplPackage.Ident("X"), ".NewError(\"invalid duration %q\", s)")
+		g.P("	}")
+		g.P("	x := &Duration{Seconds: secs, Nanos: nanos}")
+		g.P("	if err := x.CheckValid(); err != nil {")
+		g.P("		return nil, err")
+		g.P("	}")
+		g.P("	return x, nil")
+		g.P("}")
+		g.P()
+
+		g.P("// ParseISO8601 parses a duration in the ISO 8601 format \"PnWnDTnHnMnS\"")
+		g.P("// with an optional leading sign (e.g., \"PT1H30M\" or \"-P1DT0.5S\").")
+		g.P("// Weeks are 7 days and days are 24 hours long. Years and months are not")
+		g.P("// accepted since they do not have a fixed length. Only the seconds may have")
+		g.P("// a fractional part of at most 9 digits.")
+		g.P("// It reports an error if the duration is invalid.")
+		g.P("func ParseISO8601(s string) (*Duration, error) {")
+		g.P("	secs, nanos, ok := ", protoimplPackage.Ident("X"), ".ParseISO8601Duration(s)")
+		g.P("	if !ok {")
+		g.P("		return nil, ", protoimplPackage.Ident("X"), ".NewError(\"invalid ISO 8601 duration %q\", s)")
+		g.P("	}")
+		g.P("	x := newDuration(secs, int64(nanos), true)")
+		g.P("	if err := x.CheckValid(); err != nil {")
+		g.P("		return nil, err")
+		g.P("	}")
+		g.P("	return x, nil")
+		g.P("}")
+		g.P()
+
+		g.P("// Format formats x in the JSON format of a Duration, which is a decimal number")
+		g.P("// of seconds with the suffix \"s\" (e.g., \"1.5s\"). The fractional part has")
+		g.P("// 0, 3, 6, or 9 digits depending on the required precision.")
+		g.P("// The duration is normalized before formatting.")
+		g.P("func (x *Duration) Format() string {")
+		g.P("	d := x.normalized()")
+		g.P("	return ", protoimplPackage.Ident("X"), ".FormatDuration(d.Seconds, d.Nanos)")
+		g.P("}")
+		g.P()
+
+		g.P("// FormatISO8601 formats x in the ISO 8601 format using hours, minutes, and")
+		g.P("// seconds (e.g., \"PT1H30M0.5S\"). A negative duration has a leading minus sign.")
+		g.P("// The duration is normalized before formatting.")
+		g.P("func (x *Duration) FormatISO8601() string {")
+		g.P("	d := x.normalized()")
+		g.P("	return ", protoimplPackage.Ident("X"), ".FormatISO8601Duration(d.Seconds, d.Nanos)")
+		g.P("}")
+		g.P()
+
+		g.P("// Add returns the exact sum of x and y as a new normalized Duration.")
+		g.P("// A nil Duration is treated as zero. The result may be outside the range of")
+		g.P("// a valid duration, but it saturates instead of overflowing the seconds.")
+		g.P("func (x *Duration) Add(y *Duration) *Duration {")
+		g.P("	secs, ok := addSeconds(x.GetSeconds(), y.GetSeconds())")
+		g.P("	return newDuration(secs, int64(x.GetNanos())+int64(y.GetNanos()), ok)")
+		g.P("}")
+		g.P()
+
+		g.P("// Sub returns the exact difference of x and y as a new normalized Duration.")
+		g.P("// A nil Duration is treated as zero. The result may be outside the range of")
+		g.P("// a valid duration, but it saturates instead of overflowing the seconds.")
+		g.P("func (x *Duration) Sub(y *Duration) *Duration {")
+		g.P("	secs, ok := subSeconds(x.GetSeconds(), y.GetSeconds())")
+		g.P("	return newDuration(secs, int64(x.GetNanos())-int64(y.GetNanos()), ok)")
+		g.P("}")
+		g.P()
+
+		g.P("// Compare returns -1, 0, or +1 depending on whether x is shorter than,")
+		g.P("// equal to, or longer than y. A nil Duration is treated as zero.")
+		g.P("// Denormalized durations are compared by the values they represent.")
+		g.P("func (x *Duration) Compare(y *Duration) int {")
+		g.P("	dx, dy := x.normalized(), y.normalized()")
+		g.P("	switch {")
+		g.P("	case dx.Seconds < dy.Seconds:")
+		g.P("		return -1")
+		g.P("	case dx.Seconds > dy.Seconds:")
+		g.P("		return +1")
+		g.P("	case dx.Nanos < dy.Nanos:")
+		g.P("		return -1")
+		g.P("	case dx.Nanos > dy.Nanos:")
+		g.P("		return +1")
+		g.P("	default:")
+		g.P("		return 0")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// Normalize converts x to its canonical form where the nanos are within the")
+		g.P("// range of -999,999,999 to +999,999,999 inclusive and have the same sign as")
+		g.P("// the seconds. Excess nanos are carried into the seconds.")
+		g.P("func (x *Duration) Normalize() {")
+		g.P("	d := x.normalized()")
+		g.P("	x.Seconds, x.Nanos = d.Seconds, d.Nanos")
+		g.P("}")
+		g.P()
+
+		g.P("func (x *Duration) normalized() *Duration {")
+		g.P("	return newDuration(x.GetSeconds(), int64(x.GetNanos()), true)")
+		g.P("}")
+		g.P()
+
+		g.P("// newDuration constructs a normalized Duration from secs and nanos,")
+		g.P("// where nanos may be of any magnitude and sign. If ok is false,")
+		g.P("// secs is the saturated result of an overflowed computation.")
+		g.P("func newDuration(secs, nanos int64, ok bool) *Duration {")
+		g.P("	if ok {")
+		g.P("		secs, ok = addSeconds(secs, nanos/1e9)")
+		g.P("		nanos %= 1e9")
+		g.P("	}")
+		g.P("	if !ok {")
+		g.P("		// Saturate to the largest value with the same sign.")
+		g.P("		if secs < 0 {")
+		g.P("			return &Duration{Seconds: secs, Nanos: -(1e9 - 1)}")
+		g.P("		}")
+		g.P("		return &Duration{Seconds: secs, Nanos: +(1e9 - 1)}")
+		g.P("	}")
+		g.P("	switch {")
+		g.P("	case secs > 0 && nanos < 0:")
+		g.P("		secs, nanos = secs-1, nanos+1e9")
+		g.P("	case secs < 0 && nanos > 0:")
+		g.P("		secs, nanos = secs+1, nanos-1e9")
+		g.P("	}")
+		g.P("	return &Duration{Seconds: secs, Nanos: int32(nanos)}")
+		g.P("}")
+		g.P()
+
+		g.P("// addSeconds returns x+y, or the saturated sum and false on overflow.")
+		g.P("func addSeconds(x, y int64) (int64, bool) {")
+		g.P("	switch {")
+		g.P("	case y > 0 && x > ", mathPackage.Ident("MaxInt64"), "-y:")
+		g.P("		return ", mathPackage.Ident("MaxInt64"), ", false")
+		g.P("	case y < 0 && x < ", mathPackage.Ident("MinInt64"), "-y:")
+		g.P("		return ", mathPackage.Ident("MinInt64"), ", false")
+		g.P("	default:")
+		g.P("		return x + y, true")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// subSeconds returns x-y, or the saturated difference and false on overflow.")
+		g.P("func subSeconds(x, y int64) (int64, bool) {")
+		g.P("	switch {")
+		g.P("	case y < 0 && x > ", mathPackage.Ident("MaxInt64"), "+y:")
+		g.P("		return ", mathPackage.Ident("MaxInt64"), ", false")
+		g.P("	case y > 0 && x < ", mathPackage.Ident("MinInt64"), "+y:")
+		g.P("		return ", mathPackage.Ident("MinInt64"), ", false")
+		g.P("	default:")
+		g.P("		return x - y, true")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
 	case genid.Struct_message_fullname:
 		g.P("// NewStruct constructs a Struct from a general-purpose Go map.")
 		g.P("// The map keys must be valid UTF-8.")
@@ -674,6 +1060,44 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// ConvertStruct constructs a Struct from any Go value that the standard")
+		g.P("// \"encoding/json\" package marshals as a JSON object, such as a Go struct")
+		g.P("// with json field tags or a map with string keys.")
+		g.P("// The value is converted using ConvertValue.")
+		g.P("func ConvertStruct(v interface{}) (*Struct, error) {")
+		g.P("	x, err := ConvertValue(v)")
+		g.P("	if err != nil {")
+		g.P("		return nil, err")
+		g.P("	}")
+		g.P("	s, ok := x.GetKind().(*Value_StructValue)")
+		g.P("	if !ok {")
+		g.P("		return nil, ", protoimplPackage.Ident("X"), ".NewError(\"invalid type: %T is not a JSON object\", v)")
+		g.P("	}")
+		g.P("	return s.StructValue, nil")
+		g.P("}")
+		g.P()
+
+		g.P("// ConvertTo stores x in the Go value pointed to by v")
+		g.P("// following the rules of \"encoding/json\".Unmarshal.")
+		g.P("func (x *Struct) ConvertTo(v interface{}) error {")
+		g.P("	b, err := ", protojsonPackage.Ident("Marshal"), "(x)")
+		g.P("	if err != nil {")
+		g.P("		return err")
+		g.P("	}")
+		g.P("	return ", jsonPackage.Ident("Unmarshal"), "(b, v)")
+		g.P("}")
+		g.P()
+
+		g.P("// GetPath returns the value at the given path relative to x.")
+		g.P("// See Value.GetPath for the path syntax.")
+		g.P("func (x *Struct) GetPath(path string) *Value {")
+		g.P("	if x == nil {")
+		g.P("		return nil")
+		g.P("	}")
+		g.P("	return NewStructValue(x).GetPath(path)")
+		g.P("}")
+		g.P()
+
 	case genid.ListValue_message_fullname:
 		g.P("// NewList constructs a ListValue from a general-purpose Go slice.")
 		g.P("// The slice elements are converted using NewValue.")
@@ -711,6 +1135,17 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// ConvertTo stores x in the Go value pointed to by v")
+		g.P("// following the rules of \"encoding/json\".Unmarshal.")
+		g.P("func (x *ListValue) ConvertTo(v interface{}) error {")
+		g.P("	b, err := ", protojsonPackage.Ident("Marshal"), "(x)")
+		g.P("	if err != nil {")
+		g.P("		return err")
+		g.P("	}")
+		g.P("	return ", jsonPackage.Ident("Unmarshal"), "(b, v)")
+		g.P("}")
+		g.P()
+
 	case genid.Value_message_fullname:
 		g.P("// NewValue constructs a Value from a general-purpose Go interface.")
 		g.P("//")
@@ -867,6 +1302,121 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// ConvertValue constructs a Value from any Go value that can be marshaled by")
+		g.P("// the standard \"encoding/json\" package, including json.RawMessage, json.Number,")
+		g.P("// time.Time, and Go structs with json field tags.")
+		g.P("//")
+		g.P("// The Go types accepted by NewValue are converted as NewValue does.")
+		g.P("// All other values are marshaled as JSON by \"encoding/json\".Marshal and")
+		g.P("// the result is parsed as a Value. Consequently, types implementing")
+		g.P("// json.Marshaler or encoding.TextMarshaler use their custom representation")
+		g.P("// (e.g., a time.Time is stored as an RFC 3339 string).")
+		g.P("func ConvertValue(v interface{}) (*Value, error) {")
+		g.P("	switch v.(type) {")
+		g.P("	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64, string, []byte:")
+		g.P("		return NewValue(v)")
+		g.P("	}")
+		g.P("	b, err := ", jsonPackage.Ident("Marshal"), "(v)")
+		g.P("	if err != nil {")
+		g.P("		return nil, ", protoimplPackage.Ident("X"), ".NewError(\"invalid type %T: %v\", v, err)")
+		g.P("	}")
+		g.P("	x := new(Value)")
+		g.P("	if err := ", protojsonPackage.Ident("Unmarshal"), "(b, x); err != nil {")
+		g.P("		return nil, err")
+		g.P("	}")
+		g.P("	return x, nil")
+		g.P("}")
+		g.P()
+
+		g.P("// ConvertTo stores x in the Go value pointed to by v")
+		g.P("// following the rules of \"encoding/json\".Unmarshal.")
+		g.P("// It is the inverse of ConvertValue.")
+		g.P("func (x *Value) ConvertTo(v interface{}) error {")
+		g.P("	b, err := ", protojsonPackage.Ident("Marshal"), "(x)")
+		g.P("	if err != nil {")
+		g.P("		return err")
+		g.P("	}")
+		g.P("	return ", jsonPackage.Ident("Unmarshal"), "(b, v)")
+		g.P("}")
+		g.P()
+
+		g.P("// GetPath returns the value at the given path relative to x,")
+		g.P("// or nil if there is no such value or the path is malformed.")
+		g.P("//")
+		g.P("// A path is a sequence of Struct field names separated by dots,")
+		g.P("// where each name may be followed by ListValue indexes in brackets")
+		g.P("// (e.g., \"a.b[0].c\"). A field name that contains a dot or bracket")
+		g.P("// is written as a quoted string in brackets (e.g., `a[\"b.c\"]`).")
+		g.P("// The empty path refers to x itself.")
+		g.P("//")
+		g.P("// Since the getter methods of a nil Value return the zero value,")
+		g.P("// the result may be used directly (e.g., x.GetPath(\"a.b\").GetStringValue()).")
+		g.P("func (x *Value) GetPath(path string) *Value {")
+		g.P("	if path != \"\" && path[0] != '[' {")
+		g.P("		path = \".\" + path")
+		g.P("	}")
+		g.P("	for x != nil && path != \"\" {")
+		g.P("		switch path[0] {")
+		g.P("		case '.':")
+		g.P("			path = path[len(\".\"):]")
+		g.P("			i := ", stringsPackage.Ident("IndexAny"), "(path, \".[\")")
+		g.P("			if i < 0 {")
+		g.P("				i = len(path)")
+		g.P("			}")
+		g.P("			if i == 0 {")
+		g.P("				return nil")
+		g.P("			}")
+		g.P("			x = x.GetStructValue().GetFields()[path[:i]]")
+		g.P("			path = path[i:]")
+		g.P("		case '[':")
+		g.P("			path = path[len(\"[\"):]")
+		g.P("			if path != \"\" && path[0] == '\"' {")
+		g.P("				n := quotedLen(path)")
+		g.P("				if n < 0 || n >= len(path) || path[n] != ']' {")
+		g.P("					return nil")
+		g.P("				}")
+		g.P("				k, err := ", strconvPackage.Ident("Unquote"), "(path[:n])")
+		g.P("				if err != nil {")
+		g.P("					return nil")
+		g.P("				}")
+		g.P("				x = x.GetStructValue().GetFields()[k]")
+		g.P("				path = path[n+len(\"]\"):]")
+		g.P("			} else {")
+		g.P("				i := ", stringsPackage.Ident("IndexByte"), "(path, ']')")
+		g.P("				if i < 0 {")
+		g.P("					return nil")
+		g.P("				}")
+		g.P("				n, err := ", strconvPackage.Ident("Atoi"), "(path[:i])")
+		g.P("				vs := x.GetListValue().GetValues()")
+		g.P("				if err != nil || n < 0 || n >= len(vs) {")
+		g.P("					return nil")
+		g.P("				}")
+		g.P("				x = vs[n]")
+		g.P("				path = path[i+len(\"]\"):]")
+		g.P("			}")
+		g.P("		default:")
+		g.P("			return nil")
+		g.P("		}")
+		g.P("	}")
+		g.P("	return x")
+		g.P("}")
+		g.P()
+
+		g.P("// quotedLen returns the length of the quoted string at the start of s,")
+		g.P("// or -1 if it is not terminated.")
+		g.P("func quotedLen(s string) int {")
+		g.P("	for i := len(`\"`); i < len(s); i++ {")
+		g.P("		switch s[i] {")
+		g.P("		case '\\\\':")
+		g.P("			i++")
+		g.P("		case '\"':")
+		g.P("			return i + len(`\"`)")
+		g.P("		}")
+		g.P("	}")
+		g.P("	return -1")
+		g.P("}")
+		g.P()
+
 	case genid.FieldMask_message_fullname:
 		g.P("// New constructs a field mask from a list of paths and verifies that")
 		g.P("// each one is valid according to the specified message type.")
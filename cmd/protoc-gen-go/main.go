@@ -31,6 +31,7 @@ func main() {
 		flags        flag.FlagSet
 		plugins      = flags.String("plugins", "", "deprecated option")
 		importPrefix = flags.String("import_prefix", "", "deprecated option")
+		genCodec     = flags.Bool("generate_codec", false, "generate specialized marshal and unmarshal functions")
 	)
 	protogen.Options{
 		ParamFunc: flags.Set,
@@ -41,6 +42,7 @@ func main() {
 		if *importPrefix != "" {
 			return errors.New("protoc-gen-go: import_prefix is not supported")
 		}
+		gengo.GenerateCodec = *genCodec
 		for _, f := range gen.Files {
 			if f.Generate {
 				gengo.GenerateFile(gen, f)
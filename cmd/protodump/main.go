@@ -2,11 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// pbdump is a tool for decoding the wire format for protocol buffer messages.
+// The protodump binary decodes the wire format for protocol buffer messages.
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -17,14 +19,19 @@ import (
 	"strconv"
 	"strings"
 
+	"google.golang.org/protobuf/encoding/protodump"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/internal/errors"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/testing/protopack"
 
 	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func main() {
@@ -41,6 +48,10 @@ func main() {
 		flagUsages = append(flagUsages, fmt.Sprintf("  -%-16v  %v", name, usage))
 		return flag.Bool(name, false, usage)
 	}
+	flagString := func(name, value, usage string) *string {
+		flagUsages = append(flagUsages, fmt.Sprintf("  -%-16v  %v", name+" "+value, usage))
+		return flag.String(name, "", usage)
+	}
 	flagVar(fieldsFlag{&fs, protoreflect.BoolKind}, "bools", "List of bool fields")
 	flagVar(fieldsFlag{&fs, protoreflect.Int64Kind}, "ints", "List of int32 or int64 fields")
 	flagVar(fieldsFlag{&fs, protoreflect.Sint64Kind}, "sints", "List of sint32 or sint64 fields")
@@ -55,13 +66,29 @@ func main() {
 	flagVar(fieldsFlag{&fs, protoreflect.BytesKind}, "bytes", "List of bytes fields")
 	flagVar(fieldsFlag{&fs, protoreflect.MessageKind}, "messages", "List of message fields")
 	flagVar(fieldsFlag{&fs, protoreflect.GroupKind}, "groups", "List of group fields")
+	descSet := flagString("descriptor_set", "FILE", "Binary FileDescriptorSet to load the message type from")
+	msgName := flagString("message", "NAME", "Full name of the message type in the descriptor set")
+	inFormat := flagString("input_format", "FORMAT", "Format of the input: binary (default), json, or text")
+	outFormat := flagString("output_format", "FORMAT", "Format of the output: dump (default), binary, json, or text")
+	delimited := flagBool("delimited", "Binary input and output are streams of length-prefixed messages")
+	printJSON := flagBool("json", "Print the dump output as JSON")
 	printDesc := flagBool("print_descriptor", "Print the message descriptor")
-	printSource := flagBool("print_source", "Print the output in valid Go syntax")
+	printSource := flagBool("print_source", "Print the output as a protopack.Message in valid Go syntax")
 	flag.Usage = func() {
 		fmt.Printf("Usage: %s [OPTIONS]... [INPUTS]...\n\n%s\n", filepath.Base(os.Args[0]), strings.Join(append([]string{
 			"Print structured representations of encoded protocol buffer messages.",
-			"Since the protobuf wire format is not fully self-describing, type information",
-			"about the proto message can be provided using flags (e.g., -messages).",
+			"Since the protobuf wire format is not fully self-describing, the structure",
+			"of the data is reconstructed using heuristics, where each field that could",
+			"be interpreted in more than one way is annotated with the confidence",
+			"in the chosen interpretation.",
+			"",
+			"Type information about the proto message can be provided in one of two ways.",
+			"The -descriptor_set flag specifies a file containing a serialized",
+			"google.protobuf.FileDescriptorSet (e.g., as produced by protoc using the",
+			"--descriptor_set_out and --include_imports flags), and the -message flag",
+			"specifies the full name of the message type in that set.",
+			"",
+			"Alternatively, field types can be provided using flags (e.g., -messages).",
 			"Each field list is a comma-separated list of field identifiers,",
 			"where each field identifier is a dot-separated list of field numbers,",
 			"identifying each field relative to the root message.",
@@ -83,8 +110,18 @@ func main() {
 			"    }",
 			"",
 			"Arbitrarily complex message schemas can be represented using these flags.",
-			"Scalar field types are marked as repeated so that pbdump can decode",
+			"Scalar field types are marked as repeated so that protodump can decode",
 			"the packed representations of such field types.",
+			"Fields that are not specified are decoded using heuristics.",
+			"",
+			"When a message type is provided, the input may also be in the JSON or text",
+			"format, and the message may be re-encoded in the binary, JSON, or text format",
+			"instead of being dumped. Messages in google.protobuf.Any fields are resolved",
+			"using the same descriptor set.",
+			"",
+			"With -delimited, binary input is a stream of messages each preceded by its",
+			"length as a varint, such as a file of records, and binary output is written",
+			"in the same form.",
 			"",
 			"If no inputs are specified, the wire data is read in from stdin, otherwise",
 			"the contents of each specified input file is concatenated and",
@@ -96,17 +133,66 @@ func main() {
 	flag.Parse()
 
 	// Create message types.
-	var desc protoreflect.MessageDescriptor
-	if len(fs) > 0 {
+	var files *protoregistry.Files
+	var name protoreflect.FullName
+	switch {
+	case *descSet != "" || *msgName != "":
+		if len(fs) > 0 {
+			log.Fatalf("-descriptor_set cannot be used with field type flags")
+		}
+		if *descSet == "" || *msgName == "" {
+			log.Fatalf("both -descriptor_set and -message must be specified")
+		}
 		var err error
-		desc, err = fs.Descriptor()
+		files, err = loadFiles(*descSet)
+		if err != nil {
+			log.Fatalf("Descriptor set error: %v", err)
+		}
+		name = protoreflect.FullName(*msgName)
+	case len(fs) > 0:
+		desc, err := fs.Descriptor()
 		if err != nil {
 			log.Fatalf("Descriptor error: %v", err)
 		}
+		files = new(protoregistry.Files)
+		if err := files.RegisterFile(desc.ParentFile()); err != nil {
+			log.Fatalf("Descriptor error: %v", err)
+		}
+		name = desc.FullName()
+	}
+	var types *dynamicpb.Types
+	var mt protoreflect.MessageType
+	var desc protoreflect.MessageDescriptor
+	if files != nil {
+		types = dynamicpb.NewTypes(files)
+		var err error
+		mt, err = types.FindMessageByName(name)
+		if err != nil {
+			log.Fatalf("Message %v error: %v", name, err)
+		}
+		desc = mt.Descriptor()
 		if *printDesc {
 			fmt.Printf("%#v\n", desc)
 		}
 	}
+	switch *inFormat {
+	case "", "binary":
+	case "json", "text":
+		if *delimited {
+			log.Fatalf("-delimited cannot be used with %v input", *inFormat)
+		}
+	default:
+		log.Fatalf("invalid -input_format: %q", *inFormat)
+	}
+	switch *outFormat {
+	case "", "dump":
+	case "binary", "json", "text":
+	default:
+		log.Fatalf("invalid -output_format: %q", *outFormat)
+	}
+	if mt == nil && (*inFormat != "" && *inFormat != "binary" || *outFormat != "" && *outFormat != "dump") {
+		log.Fatalf("converting formats requires a message type from -descriptor_set and -message or field type flags")
+	}
 
 	// Read message input.
 	var buf []byte
@@ -124,20 +210,170 @@ func main() {
 		}
 		buf = append(buf, b...)
 	}
+	inputs := [][]byte{buf}
+	if *delimited {
+		var err error
+		inputs, err = splitDelimited(buf)
+		if err != nil {
+			log.Fatalf("Delimited input error: %v", err)
+		}
+	}
 
 	// Parse and print message structure.
-	defer log.Printf("fatal input: %q", buf) // debug printout if panic occurs
-	var m protopack.Message
-	m.UnmarshalDescriptor(buf, desc)
 	if *printSource {
-		fmt.Printf("%#v\n", m)
-	} else {
-		fmt.Printf("%+v\n", m)
+		for _, buf := range inputs {
+			printSourceOrDie(buf, desc)
+		}
+		os.Exit(0)
 	}
+	c := converter{
+		types:     types,
+		mt:        mt,
+		inFormat:  *inFormat,
+		outFormat: *outFormat,
+		delimited: *delimited,
+		json:      *printJSON,
+	}
+	w := bufio.NewWriter(os.Stdout)
+	for i, b := range inputs {
+		out, err := c.convert(b)
+		if err != nil {
+			if len(inputs) > 1 {
+				log.Fatalf("Message %d: %v", i, err)
+			}
+			log.Fatal(err)
+		}
+		w.Write(out)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("Write error: %v", err)
+	}
+}
+
+// printSourceOrDie prints the input as a protopack.Message in Go syntax,
+// and verifies that it round-trips.
+func printSourceOrDie(buf []byte, desc protoreflect.MessageDescriptor) {
+	done := false
+	defer func() {
+		if !done {
+			log.Printf("fatal input: %q", buf) // debug printout if panic occurs
+		}
+	}()
+	var m protopack.Message
+	m.UnmarshalDescriptor(buf, desc)
+	fmt.Printf("%#v\n", m)
 	if !bytes.Equal(buf, m.Marshal()) || len(buf) != m.Size() {
 		log.Fatalf("roundtrip mismatch:\n\tgot:  %d %x\n\twant: %d %x", m.Size(), m, len(buf), buf)
 	}
-	os.Exit(0) // exit cleanly, avoid debug printout
+	done = true
+}
+
+// converter converts a message from one format to another.
+type converter struct {
+	types     *dynamicpb.Types
+	mt        protoreflect.MessageType // nil if no message type is known
+	inFormat  string
+	outFormat string
+	delimited bool
+	json      bool
+}
+
+// convert converts a single input message to its output form.
+func (c converter) convert(b []byte) ([]byte, error) {
+	var m proto.Message
+	switch c.inFormat {
+	case "json":
+		m = c.mt.New().Interface()
+		if err := (protojson.UnmarshalOptions{Resolver: c.types}).Unmarshal(b, m); err != nil {
+			return nil, err
+		}
+	case "text":
+		m = c.mt.New().Interface()
+		if err := (prototext.UnmarshalOptions{Resolver: c.types}).Unmarshal(b, m); err != nil {
+			return nil, err
+		}
+	default:
+		if c.outFormat == "" || c.outFormat == "dump" {
+			// Dump the input as is, rather than as re-encoded by the
+			// message type, so that malformed data can be inspected.
+			return c.dump(b)
+		}
+		m = c.mt.New().Interface()
+		if err := (proto.UnmarshalOptions{AllowPartial: true, Resolver: c.types}).Unmarshal(b, m); err != nil {
+			return nil, err
+		}
+	}
+
+	switch c.outFormat {
+	case "binary":
+		out, err := proto.MarshalOptions{AllowPartial: true}.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		if c.delimited {
+			out = append(protowire.AppendVarint(nil, uint64(len(out))), out...)
+		}
+		return out, nil
+	case "json":
+		out, err := protojson.MarshalOptions{Multiline: true, Resolver: c.types}.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	case "text":
+		return prototext.MarshalOptions{Multiline: true, Resolver: c.types}.Marshal(m)
+	default:
+		b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return c.dump(b)
+	}
+}
+
+// dump formats the binary message b as a best-effort tree.
+func (c converter) dump(b []byte) ([]byte, error) {
+	var desc protoreflect.MessageDescriptor
+	if c.mt != nil {
+		desc = c.mt.Descriptor()
+	}
+	m := protodump.DecodeOptions{Message: desc}.Decode(b)
+	if c.json {
+		out, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	}
+	return []byte(m.String() + "\n"), nil
+}
+
+// splitDelimited splits b into a sequence of messages,
+// each of which is preceded by its length as a varint.
+func splitDelimited(b []byte) ([][]byte, error) {
+	var bs [][]byte
+	for len(b) > 0 {
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, errors.New("message %d: %v", len(bs), protowire.ParseError(n))
+		}
+		bs = append(bs, v)
+		b = b[n:]
+	}
+	return bs, nil
+}
+
+// loadFiles loads the serialized FileDescriptorSet in the given file.
+func loadFiles(file string) (*protoregistry.Files, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	fds := new(descriptorpb.FileDescriptorSet)
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(fds)
 }
 
 // fields is a tree of fields, keyed by a field number.
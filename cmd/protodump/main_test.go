@@ -5,15 +5,22 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
 
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
 	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
 )
 
 func mustMakeMessage(s string) *descriptorpb.DescriptorProto {
@@ -113,3 +120,46 @@ func TestFields(t *testing.T) {
 		})
 	}
 }
+
+func TestConvert(t *testing.T) {
+	types := dynamicpb.NewTypes(protoregistry.GlobalFiles)
+	mt, err := types.FindMessageByName("google.protobuf.Any")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const textIn = `[type.googleapis.com/google.protobuf.Duration]: {seconds: 3}`
+
+	// Convert from text to delimited binary and back.
+	toBinary := converter{types: types, mt: mt, inFormat: "text", outFormat: "binary", delimited: true}
+	b, err := toBinary.convert([]byte(textIn))
+	if err != nil {
+		t.Fatalf("convert() to binary error: %v", err)
+	}
+	stream := append(append([]byte(nil), b...), b...)
+	inputs, err := splitDelimited(stream)
+	if err != nil {
+		t.Fatalf("splitDelimited() error: %v", err)
+	}
+	if len(inputs) != 2 {
+		t.Fatalf("splitDelimited() returned %d messages, want 2", len(inputs))
+	}
+	toJSON := converter{types: types, mt: mt, inFormat: "binary", outFormat: "json"}
+	for _, in := range inputs {
+		got, err := toJSON.convert(in)
+		if err != nil {
+			t.Fatalf("convert() to JSON error: %v", err)
+		}
+		// The Any is resolved, so its content is shown as a Duration.
+		if !bytes.Contains(got, []byte(`"3s"`)) {
+			t.Errorf("convert() to JSON = %s, want resolved Duration", got)
+		}
+	}
+
+	// A truncated stream is an error.
+	if _, err := splitDelimited(stream[:len(stream)-1]); err == nil {
+		t.Errorf("splitDelimited() of truncated stream succeeded, want error")
+	}
+	if _, err := splitDelimited(protowire.AppendVarint(nil, 1<<40)); err == nil {
+		t.Errorf("splitDelimited() of oversized length succeeded, want error")
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protodump decodes protocol buffer wire data without a schema.
+//
+// The wire format is not fully self-describing: a length-delimited field may
+// hold a nested message, a string, a packed list of scalars, or opaque bytes.
+// This package reconstructs a best-effort tree of the data by trying each
+// interpretation and choosing the most plausible one, along with a confidence
+// in that choice. When a message descriptor is available, it is used to
+// decode the fields it declares, and heuristics are used for the rest.
+//
+// The heuristics may change over time, so the output of this package is
+// intended for human consumption and debugging, not for programmatic use.
+package protodump
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxDepth is the maximum nesting depth of messages and groups.
+// Deeper data is treated as opaque bytes or as malformed.
+const maxDepth = 100
+
+// Kind is the interpretation of a field value.
+type Kind int8
+
+const (
+	// InvalidKind is malformed wire data, which extends to the end of the
+	// enclosing message.
+	InvalidKind Kind = iota
+	VarintKind
+	Fixed32Kind
+	Fixed64Kind
+	BytesKind
+	StringKind
+	MessageKind
+	GroupKind
+	PackedVarintKind
+	PackedFixed32Kind
+	PackedFixed64Kind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case InvalidKind:
+		return "invalid"
+	case VarintKind:
+		return "varint"
+	case Fixed32Kind:
+		return "fixed32"
+	case Fixed64Kind:
+		return "fixed64"
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case MessageKind:
+		return "message"
+	case GroupKind:
+		return "group"
+	case PackedVarintKind:
+		return "packed varint"
+	case PackedFixed32Kind:
+		return "packed fixed32"
+	case PackedFixed64Kind:
+		return "packed fixed64"
+	default:
+		return fmt.Sprintf("<unknown:%d>", k)
+	}
+}
+
+// Message is a decoded message, which is a sequence of fields
+// in the order they appear in the wire data.
+type Message struct {
+	Fields []*Field
+}
+
+// Field is a decoded field.
+type Field struct {
+	// Number and Type are the field number and wire type of the field tag.
+	// They are zero for an InvalidKind field with a malformed tag.
+	Number protowire.Number
+	Type   protowire.Type
+
+	// Kind is the interpretation of the field value.
+	Kind Kind
+
+	// Confidence is the estimated probability in the range [0, 1]
+	// that Kind is the correct interpretation of the field value.
+	// It is 1 for values where the wire type allows only one interpretation
+	// or where the interpretation is determined by the descriptor.
+	Confidence float64
+
+	// Desc is the descriptor for the field, if known.
+	Desc protoreflect.FieldDescriptor
+
+	// Raw is the wire data of the field value, excluding the tag.
+	// For length-delimited fields, it excludes the length prefix, and
+	// for groups, it excludes the end group marker.
+	// For InvalidKind fields, it is the remainder of the malformed data.
+	Raw []byte
+
+	// Scalar is the value of a VarintKind, Fixed32Kind, or Fixed64Kind field.
+	Scalar uint64
+
+	// Scalars are the values of a PackedVarintKind, PackedFixed32Kind,
+	// or PackedFixed64Kind field.
+	Scalars []uint64
+
+	// Message is the content of a MessageKind or GroupKind field.
+	Message *Message
+}
+
+// Decode decodes b using heuristics for all fields.
+// It is equivalent to DecodeOptions{}.Decode(b).
+func Decode(b []byte) *Message {
+	return DecodeOptions{}.Decode(b)
+}
+
+// DecodeOptions configures the decoder.
+type DecodeOptions struct {
+	// Message is the descriptor of the top-level message.
+	// If nil, no fields are known.
+	Message protoreflect.MessageDescriptor
+}
+
+// Decode decodes b as a message. It never fails; malformed wire data is
+// reported as an InvalidKind field at the end of the enclosing message.
+func (o DecodeOptions) Decode(b []byte) *Message {
+	m, _, _ := decodeFields(b, o.Message, 0, 0)
+	return m
+}
+
+// decodeFields decodes fields from b until the end of input or, if group is
+// non-zero, until the end group marker for that field number. It returns the
+// decoded fields, the number of bytes consumed excluding any end group marker,
+// and whether b was well-formed.
+func decodeFields(b []byte, md protoreflect.MessageDescriptor, depth int, group protowire.Number) (m *Message, n int, ok bool) {
+	m = new(Message)
+	for n < len(b) {
+		num, typ, tagLen := protowire.ConsumeTag(b[n:])
+		if tagLen < 0 {
+			m.Fields = append(m.Fields, &Field{Kind: InvalidKind, Raw: b[n:]})
+			return m, len(b), false
+		}
+		if typ == protowire.EndGroupType {
+			if num == group {
+				return m, n, true
+			}
+			m.Fields = append(m.Fields, &Field{Number: num, Type: typ, Kind: InvalidKind, Raw: b[n+tagLen:]})
+			return m, len(b), false
+		}
+		var fd protoreflect.FieldDescriptor
+		if md != nil {
+			fd = md.Fields().ByNumber(num)
+		}
+		f, valLen := decodeValue(num, typ, b[n+tagLen:], fd, depth)
+		if valLen < 0 {
+			m.Fields = append(m.Fields, &Field{Number: num, Type: typ, Kind: InvalidKind, Desc: fd, Raw: b[n+tagLen:]})
+			return m, len(b), false
+		}
+		m.Fields = append(m.Fields, f)
+		n += tagLen + valLen
+	}
+	return m, n, group == 0
+}
+
+// decodeValue decodes a field value from b. It returns the field and the
+// number of bytes consumed, or a negative length if b is malformed.
+func decodeValue(num protowire.Number, typ protowire.Type, b []byte, fd protoreflect.FieldDescriptor, depth int) (*Field, int) {
+	f := &Field{Number: num, Type: typ, Desc: fd, Confidence: 1}
+	var n int
+	switch typ {
+	case protowire.VarintType:
+		f.Kind = VarintKind
+		f.Scalar, n = protowire.ConsumeVarint(b)
+	case protowire.Fixed32Type:
+		var v uint32
+		f.Kind = Fixed32Kind
+		v, n = protowire.ConsumeFixed32(b)
+		f.Scalar = uint64(v)
+	case protowire.Fixed64Type:
+		f.Kind = Fixed64Kind
+		f.Scalar, n = protowire.ConsumeFixed64(b)
+	case protowire.BytesType:
+		f.Raw, n = protowire.ConsumeBytes(b)
+		if n >= 0 {
+			decodeBytes(f, depth)
+		}
+		return f, n
+	case protowire.StartGroupType:
+		if depth >= maxDepth {
+			return nil, -1
+		}
+		var md protoreflect.MessageDescriptor
+		if fd != nil && fd.Kind() == protoreflect.GroupKind {
+			md = fd.Message()
+		}
+		m, n, ok := decodeFields(b, md, depth+1, num)
+		if !ok {
+			return nil, -1
+		}
+		_, _, tagLen := protowire.ConsumeTag(b[n:])
+		f.Kind = GroupKind
+		f.Message = m
+		f.Raw = b[:n]
+		return f, n + tagLen
+	default:
+		return nil, -1
+	}
+	if n < 0 {
+		return nil, -1
+	}
+	f.Raw = b[:n]
+	return f, n
+}
+
+// decodeBytes interprets the value of a length-delimited field,
+// using the field descriptor if it is known and consistent with the data.
+func decodeBytes(f *Field, depth int) {
+	if fd := f.Desc; fd != nil {
+		switch k := fd.Kind(); {
+		case k == protoreflect.StringKind:
+			f.Kind = StringKind
+			return
+		case k == protoreflect.BytesKind:
+			f.Kind = BytesKind
+			return
+		case k == protoreflect.MessageKind:
+			if depth < maxDepth {
+				if m, _, ok := decodeFields(f.Raw, fd.Message(), depth+1, 0); ok {
+					f.Kind, f.Message = MessageKind, m
+					return
+				}
+			}
+		case fd.IsList() && k != protoreflect.GroupKind:
+			kind := packedKinds[wireTypes[k]]
+			if vs, ok := decodePacked(f.Raw, kind); ok {
+				f.Kind, f.Scalars = kind, vs
+				return
+			}
+		}
+	}
+	f.Kind, f.Confidence = guessBytes(f, depth)
+}
+
+// guessBytes guesses the interpretation of a length-delimited value,
+// populating the Message or Scalars of f as appropriate.
+func guessBytes(f *Field, depth int) (Kind, float64) {
+	b := f.Raw
+	if len(b) == 0 {
+		// An empty value may be any kind, so there is no evidence for any.
+		return BytesKind, 0
+	}
+	isText := isText(b)
+	if depth < maxDepth {
+		if m, _, ok := decodeFields(b, nil, depth+1, 0); ok {
+			if isText {
+				// Printable text is often also a syntactically valid message
+				// since tags for field numbers above 3 are printable ASCII.
+				return StringKind, 0.6
+			}
+			f.Message = m
+			return MessageKind, messageConfidence(m)
+		}
+	}
+	if isText {
+		return StringKind, 0.9
+	}
+	for _, k := range []Kind{PackedVarintKind, PackedFixed64Kind, PackedFixed32Kind} {
+		if vs, ok := decodePacked(b, k); ok {
+			f.Scalars = vs
+			return k, 0.3
+		}
+	}
+	return BytesKind, 0.8
+}
+
+// messageConfidence estimates the probability that a syntactically valid
+// message was encoded as a message, based on how plausible its fields are.
+func messageConfidence(m *Message) float64 {
+	c := 0.9
+	for _, f := range m.Fields {
+		// Field numbers are usually small, while random data
+		// that happens to parse often has large field numbers.
+		if f.Number > 1000 {
+			c = 0.5
+		}
+		if f.Message != nil {
+			c = minFloat(c, messageConfidence(f.Message))
+		}
+	}
+	return c
+}
+
+// decodePacked decodes b as a packed list of the given kind.
+// It reports false if b is not an exact sequence of minimally-encoded values.
+func decodePacked(b []byte, k Kind) ([]uint64, bool) {
+	var vs []uint64
+	for len(b) > 0 {
+		var v uint64
+		var n int
+		switch k {
+		case PackedVarintKind:
+			v, n = protowire.ConsumeVarint(b)
+			if n > 0 && n != protowire.SizeVarint(v) {
+				return nil, false
+			}
+		case PackedFixed32Kind:
+			var v32 uint32
+			v32, n = protowire.ConsumeFixed32(b)
+			v = uint64(v32)
+		case PackedFixed64Kind:
+			v, n = protowire.ConsumeFixed64(b)
+		default:
+			return nil, false
+		}
+		if n < 0 {
+			return nil, false
+		}
+		vs = append(vs, v)
+		b = b[n:]
+	}
+	return vs, len(vs) > 0
+}
+
+// isText reports whether b is valid UTF-8 consisting of printable characters.
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+func minFloat(x, y float64) float64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// wireTypes maps each scalar kind to the wire type of its elements.
+var wireTypes = map[protoreflect.Kind]protowire.Type{
+	protoreflect.BoolKind:     protowire.VarintType,
+	protoreflect.EnumKind:     protowire.VarintType,
+	protoreflect.Int32Kind:    protowire.VarintType,
+	protoreflect.Sint32Kind:   protowire.VarintType,
+	protoreflect.Uint32Kind:   protowire.VarintType,
+	protoreflect.Int64Kind:    protowire.VarintType,
+	protoreflect.Sint64Kind:   protowire.VarintType,
+	protoreflect.Uint64Kind:   protowire.VarintType,
+	protoreflect.Sfixed32Kind: protowire.Fixed32Type,
+	protoreflect.Fixed32Kind:  protowire.Fixed32Type,
+	protoreflect.FloatKind:    protowire.Fixed32Type,
+	protoreflect.Sfixed64Kind: protowire.Fixed64Type,
+	protoreflect.Fixed64Kind:  protowire.Fixed64Type,
+	protoreflect.DoubleKind:   protowire.Fixed64Type,
+	protoreflect.StringKind:   protowire.BytesType,
+	protoreflect.BytesKind:    protowire.BytesType,
+	protoreflect.MessageKind:  protowire.BytesType,
+	protoreflect.GroupKind:    protowire.StartGroupType,
+}
+
+// packedKinds maps the wire type of scalar elements to the packed kind.
+var packedKinds = map[protowire.Type]Kind{
+	protowire.VarintType:  PackedVarintKind,
+	protowire.Fixed32Type: PackedFixed32Kind,
+	protowire.Fixed64Type: PackedFixed64Kind,
+}
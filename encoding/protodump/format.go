@@ -0,0 +1,239 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// String formats the message as multi-line text, with one field per line
+// and nested messages and groups indented. Fields known from the descriptor
+// are annotated with their names, and interpretations that are not certain
+// are annotated with their confidence.
+//
+// Example output:
+//
+//	1 (id): varint 150
+//	2: string "hello"  # 90%
+//	3: message {  # 90%
+//	  1: fixed32 1065353216  # float32 1
+//	}
+//	4: packed varint [1 2 3]  # 30%
+func (m *Message) String() string {
+	var b bytes.Buffer
+	m.format(&b, "")
+	return b.String()
+}
+
+func (m *Message) format(b *bytes.Buffer, indent string) {
+	for _, f := range m.Fields {
+		b.WriteString(indent)
+		if f.Kind == InvalidKind && f.Number == 0 {
+			b.WriteString("?")
+		} else {
+			b.WriteString(strconv.Itoa(int(f.Number)))
+		}
+		if f.Desc != nil {
+			fmt.Fprintf(b, " (%s)", f.Desc.Name())
+		}
+		fmt.Fprintf(b, ": %v", f.Kind)
+
+		var comments []string
+		if f.Confidence < 1 && f.Kind != InvalidKind {
+			comments = append(comments, fmt.Sprintf("%.0f%%", 100*f.Confidence))
+		}
+		switch f.Kind {
+		case VarintKind, Fixed32Kind, Fixed64Kind:
+			v, alt := f.scalar(f.Scalar)
+			fmt.Fprintf(b, " %v", formatValue(v))
+			if alt != "" {
+				comments = append(comments, alt)
+			}
+		case PackedVarintKind, PackedFixed32Kind, PackedFixed64Kind:
+			var ss []string
+			for _, x := range f.Scalars {
+				v, _ := f.scalar(x)
+				ss = append(ss, formatValue(v))
+			}
+			fmt.Fprintf(b, " [%s]", strings.Join(ss, " "))
+		case StringKind, BytesKind, InvalidKind:
+			fmt.Fprintf(b, " %q", f.Raw)
+		case MessageKind, GroupKind:
+			b.WriteString(" {")
+			writeComments(b, comments)
+			b.WriteString("\n")
+			f.Message.format(b, indent+"  ")
+			b.WriteString(indent + "}\n")
+			continue
+		}
+		writeComments(b, comments)
+		b.WriteString("\n")
+	}
+}
+
+func writeComments(b *bytes.Buffer, comments []string) {
+	if len(comments) > 0 {
+		b.WriteString("  # " + strings.Join(comments, ", "))
+	}
+}
+
+// scalar returns the scalar value x of the field, as interpreted by the
+// field descriptor if it is consistent with the wire type. Otherwise, it
+// returns x as an unsigned integer along with an alternate interpretation
+// of x that may be more meaningful, if any.
+func (f *Field) scalar(x uint64) (v interface{}, alt string) {
+	typ := f.Type
+	switch f.Kind {
+	case PackedVarintKind:
+		typ = protowire.VarintType
+	case PackedFixed32Kind:
+		typ = protowire.Fixed32Type
+	case PackedFixed64Kind:
+		typ = protowire.Fixed64Type
+	}
+	if fd := f.Desc; fd != nil && wireTypes[fd.Kind()] == typ {
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			return protowire.DecodeBool(x), ""
+		case protoreflect.EnumKind:
+			if ev := fd.Enum().Values().ByNumber(protoreflect.EnumNumber(x)); ev != nil {
+				return string(ev.Name()), ""
+			}
+			return int32(x), ""
+		case protoreflect.Int32Kind:
+			return int32(x), ""
+		case protoreflect.Int64Kind:
+			return int64(x), ""
+		case protoreflect.Sint32Kind:
+			return int32(protowire.DecodeZigZag(x & math.MaxUint32)), ""
+		case protoreflect.Sint64Kind:
+			return protowire.DecodeZigZag(x), ""
+		case protoreflect.Uint32Kind:
+			return uint32(x), ""
+		case protoreflect.Sfixed32Kind:
+			return int32(x), ""
+		case protoreflect.FloatKind:
+			return math.Float32frombits(uint32(x)), ""
+		case protoreflect.Sfixed64Kind:
+			return int64(x), ""
+		case protoreflect.DoubleKind:
+			return math.Float64frombits(x), ""
+		default:
+			return x, ""
+		}
+	}
+	switch typ {
+	case protowire.VarintType:
+		if int64(x) < 0 {
+			alt = fmt.Sprintf("int64 %d", int64(x))
+		}
+	case protowire.Fixed32Type:
+		alt = "float32 " + formatValue(math.Float32frombits(uint32(x)))
+	case protowire.Fixed64Type:
+		alt = "float64 " + formatValue(math.Float64frombits(x))
+	}
+	return x, alt
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// MarshalJSON formats the message as a JSON array of field objects.
+// Each object has the field "number", "wireType", "kind", and "value",
+// and may have the fields "name" for fields known from the descriptor and
+// "confidence" for interpretations that are not certain.
+// The value of a message or group is a nested array of field objects,
+// and the value of a bytes or invalid field is base64-encoded.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.jsonFields())
+}
+
+type jsonField struct {
+	Number     protowire.Number `json:"number"`
+	Name       string           `json:"name,omitempty"`
+	WireType   string           `json:"wireType"`
+	Kind       string           `json:"kind"`
+	Confidence *float64         `json:"confidence,omitempty"`
+	Value      interface{}      `json:"value"`
+}
+
+func (m *Message) jsonFields() []jsonField {
+	jfs := make([]jsonField, 0, len(m.Fields))
+	for _, f := range m.Fields {
+		jf := jsonField{
+			Number:   f.Number,
+			WireType: wireTypeNames[f.Type],
+			Kind:     f.Kind.String(),
+		}
+		if f.Desc != nil {
+			jf.Name = string(f.Desc.Name())
+		}
+		if f.Confidence < 1 && f.Kind != InvalidKind {
+			c := f.Confidence
+			jf.Confidence = &c
+		}
+		switch f.Kind {
+		case VarintKind, Fixed32Kind, Fixed64Kind:
+			v, _ := f.scalar(f.Scalar)
+			jf.Value = jsonValue(v)
+		case PackedVarintKind, PackedFixed32Kind, PackedFixed64Kind:
+			vs := make([]interface{}, len(f.Scalars))
+			for i, x := range f.Scalars {
+				v, _ := f.scalar(x)
+				vs[i] = jsonValue(v)
+			}
+			jf.Value = vs
+		case StringKind:
+			jf.Value = string(f.Raw)
+		case MessageKind, GroupKind:
+			jf.Value = f.Message.jsonFields()
+		default:
+			jf.Value = f.Raw
+		}
+		jfs = append(jfs, jf)
+	}
+	return jfs
+}
+
+// jsonValue returns v in a form that can be represented in JSON,
+// where non-finite floating-point numbers are formatted as strings.
+func jsonValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case float32:
+		if math.IsInf(float64(x), 0) || math.IsNaN(float64(x)) {
+			return formatValue(v)
+		}
+	case float64:
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return formatValue(v)
+		}
+	}
+	return v
+}
+
+var wireTypeNames = map[protowire.Type]string{
+	protowire.VarintType:     "varint",
+	protowire.Fixed32Type:    "fixed32",
+	protowire.Fixed64Type:    "fixed64",
+	protowire.BytesType:      "bytes",
+	protowire.StartGroupType: "group",
+	protowire.EndGroupType:   "endgroup",
+}
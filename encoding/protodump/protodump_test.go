@@ -0,0 +1,233 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodump_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/encoding/protodump"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protopack"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		desc string
+		md   protoreflect.MessageDescriptor
+		in   protopack.Message
+		want string
+	}{{
+		desc: "scalars",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(150),
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(-1),
+			protopack.Tag{3, protopack.Fixed32Type}, protopack.Float32(1),
+			protopack.Tag{4, protopack.Fixed64Type}, protopack.Float64(-2.5),
+		},
+		want: `
+1: varint 150
+2: varint 18446744073709551615  # int64 -1
+3: fixed32 1065353216  # float32 1
+4: fixed64 13836183955189006336  # float64 -2.5
+`,
+	}, {
+		desc: "string",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.BytesType}, protopack.String("Hello, world!\n"),
+		},
+		want: `
+1: string "Hello, world!\n"  # 90%
+`,
+	}, {
+		desc: "printable message",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.BytesType}, protopack.String("hi"),
+		},
+		want: `
+1: string "hi"  # 60%
+`,
+	}, {
+		desc: "nested message",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{3, protopack.BytesType}, protopack.String("abc"),
+				},
+			},
+		},
+		want: `
+1: message {  # 90%
+  1: varint 1
+  2: message {  # 90%
+    3: string "abc"  # 90%
+  }
+}
+`,
+	}, {
+		desc: "large field numbers",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{123456, protopack.VarintType}, protopack.Varint(1),
+			},
+		},
+		want: `
+1: message {  # 50%
+  123456: varint 1
+}
+`,
+	}, {
+		desc: "packed varints",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Varint(1), protopack.Varint(2), protopack.Varint(300),
+			},
+		},
+		want: `
+1: packed varint [1 2 300]  # 30%
+`,
+	}, {
+		desc: "bytes",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.BytesType}, protopack.Bytes("\x00\xff\x80"),
+			protopack.Tag{2, protopack.BytesType}, protopack.Bytes(""),
+		},
+		want: `
+1: bytes "\x00\xff\x80"  # 80%
+2: bytes ""  # 0%
+`,
+	}, {
+		desc: "group",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.StartGroupType},
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(5),
+			protopack.Tag{1, protopack.EndGroupType},
+		},
+		want: `
+1: group {
+  2: varint 5
+}
+`,
+	}, {
+		desc: "invalid",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{2, protopack.BytesType}, protopack.Uvarint(10), protopack.Raw("abc"),
+		},
+		want: `
+1: varint 1
+2: invalid "\nabc"
+`,
+	}, {
+		desc: "invalid tag",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Raw("\xff"),
+		},
+		want: `
+1: varint 1
+?: invalid "\xff"
+`,
+	}, {
+		desc: "unterminated group",
+		in: protopack.Message{
+			protopack.Tag{1, protopack.StartGroupType},
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(5),
+		},
+		want: `
+1: invalid "\x10\x05"
+`,
+	}, {
+		desc: "descriptor",
+		md:   (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(),
+		in: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(-5),
+			protopack.Tag{6, protopack.VarintType}, protopack.Svarint(-5),
+			protopack.Tag{11, protopack.Fixed32Type}, protopack.Float32(1.5),
+			protopack.Tag{14, protopack.BytesType}, protopack.String("hi"),
+			protopack.Tag{16, protopack.StartGroupType},
+			protopack.Tag{17, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{16, protopack.EndGroupType},
+			protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+			},
+			protopack.Tag{21, protopack.VarintType}, protopack.Varint(-1),
+			protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Varint(1), protopack.Varint(-1),
+			},
+			protopack.Tag{1, protopack.BytesType}, protopack.String("mismatch"),
+			protopack.Tag{10000, protopack.VarintType}, protopack.Varint(3),
+		},
+		want: `
+1 (optional_int32): varint -5
+6 (optional_sint64): varint -5
+11 (optional_float): fixed32 1.5
+14 (optional_string): string "hi"
+16 (optionalgroup): group {
+  17 (a): varint 1
+}
+18 (optional_nested_message): message {
+  1 (a): varint 2
+}
+21 (optional_nested_enum): varint NEG
+31 (repeated_int32): packed varint [1 -1]
+1 (optional_int32): string "mismatch"  # 90%
+10000: varint 3
+`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := protodump.DecodeOptions{Message: tt.md}.Decode(tt.in.Marshal())
+			got := m.String()
+			want := strings.TrimPrefix(tt.want, "\n")
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("String() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	in := protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(150),
+		protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.Fixed64Type}, protopack.Float64(1),
+			protopack.Tag{2, protopack.BytesType}, protopack.Bytes("\x00\xff"),
+		},
+		protopack.Tag{3, protopack.BytesType}, protopack.String("Hello"),
+	}
+	m := protodump.DecodeOptions{
+		Message: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(),
+	}.Decode(in.Marshal())
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	var got interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	var want interface{}
+	if err := json.Unmarshal([]byte(`[
+		{"number": 1, "name": "optional_int32", "wireType": "varint", "kind": "varint", "value": 150},
+		{"number": 2, "name": "optional_int64", "wireType": "bytes", "kind": "message", "confidence": 0.9, "value": [
+			{"number": 1, "wireType": "fixed64", "kind": "fixed64", "value": 4607182418800017408},
+			{"number": 2, "wireType": "bytes", "kind": "bytes", "confidence": 0.8, "value": "AP8="}
+		]},
+		{"number": 3, "name": "optional_uint32", "wireType": "bytes", "kind": "string", "confidence": 0.9, "value": "Hello"}
+	]`), &want); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MarshalJSON() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -68,13 +68,13 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 
 	dec := decoder{json.NewDecoder(b), o}
 	if err := dec.unmarshalMessage(m.ProtoReflect(), false); err != nil {
-		return err
+		return errors.AsTextDecodeError(err, json.ErrUnexpectedEOF, len(b), dec.Position)
 	}
 
 	// Check for EOF.
 	tok, err := dec.Read()
 	if err != nil {
-		return err
+		return errors.AsTextDecodeError(err, json.ErrUnexpectedEOF, len(b), dec.Position)
 	}
 	if tok.Kind() != json.EOF {
 		return dec.unexpectedTokenError(tok)
@@ -83,7 +83,7 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 	if o.AllowPartial {
 		return nil
 	}
-	return proto.CheckInitialized(m)
+	return errors.AsDecodeError(errors.DecodeErrorRequiredNotSet, proto.CheckInitialized(m))
 }
 
 type decoder struct {
@@ -91,11 +91,11 @@ type decoder struct {
 	opts UnmarshalOptions
 }
 
-// newError returns an error object with position info.
-func (d decoder) newError(pos int, f string, x ...interface{}) error {
+// newError returns an error object of the given kind with position info.
+func (d decoder) newError(kind errors.DecodeErrorKind, pos int, f string, x ...interface{}) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("(line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return errors.TextDecodeError(kind, pos, line, column, errors.New(head+f, x...))
 }
 
 // unexpectedTokenError returns a syntax error for the given unexpected token.
@@ -107,7 +107,7 @@ func (d decoder) unexpectedTokenError(tok json.Token) error {
 func (d decoder) syntaxError(pos int, f string, x ...interface{}) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("syntax error (line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return errors.TextDecodeError(errors.DecodeErrorSyntax, pos, line, column, errors.New(head+f, x...))
 }
 
 // unmarshalMessage unmarshals a message into the given protoreflect.Message.
@@ -163,12 +163,12 @@ func (d decoder) unmarshalMessage(m pref.Message, skipTypeURL bool) error {
 			extName := pref.FullName(name[1 : len(name)-1])
 			extType, err := d.opts.Resolver.FindExtensionByName(extName)
 			if err != nil && err != protoregistry.NotFound {
-				return d.newError(tok.Pos(), "unable to resolve %s: %v", tok.RawString(), err)
+				return d.newError(errors.DecodeErrorUnresolvedType, tok.Pos(), "unable to resolve %s: %v", tok.RawString(), err)
 			}
 			if extType != nil {
 				fd = extType.TypeDescriptor()
 				if !messageDesc.ExtensionRanges().Has(fd.Number()) || fd.ContainingMessage().FullName() != messageDesc.FullName() {
-					return d.newError(tok.Pos(), "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
+					return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
 				}
 			}
 		} else {
@@ -192,13 +192,13 @@ func (d decoder) unmarshalMessage(m pref.Message, skipTypeURL bool) error {
 				}
 				continue
 			}
-			return d.newError(tok.Pos(), "unknown field %v", tok.RawString())
+			return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "unknown field %v", tok.RawString())
 		}
 
 		// Do not allow duplicate fields.
 		num := uint64(fd.Number())
 		if seenNums.Has(num) {
-			return d.newError(tok.Pos(), "duplicate field %v", tok.RawString())
+			return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "duplicate field %v", tok.RawString())
 		}
 		seenNums.Set(num)
 
@@ -213,26 +213,26 @@ func (d decoder) unmarshalMessage(m pref.Message, skipTypeURL bool) error {
 		case fd.IsList():
 			list := m.Mutable(fd).List()
 			if err := d.unmarshalList(list, fd); err != nil {
-				return err
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 		case fd.IsMap():
 			mmap := m.Mutable(fd).Map()
 			if err := d.unmarshalMap(mmap, fd); err != nil {
-				return err
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 		default:
 			// If field is a oneof, check if it has already been set.
 			if od := fd.ContainingOneof(); od != nil {
 				idx := uint64(od.Index())
 				if seenOneofs.Has(idx) {
-					return d.newError(tok.Pos(), "error parsing %s, oneof %v is already set", tok.RawString(), od.FullName())
+					return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "error parsing %s, oneof %v is already set", tok.RawString(), od.FullName())
 				}
 				seenOneofs.Set(idx)
 			}
 
 			// Required or optional fields.
 			if err := d.unmarshalSingular(m, fd); err != nil {
-				return err
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 		}
 	}
@@ -335,7 +335,7 @@ func (d decoder) unmarshalScalar(fd pref.FieldDescriptor) (pref.Value, error) {
 		panic(fmt.Sprintf("unmarshalScalar: invalid scalar kind %v", kind))
 	}
 
-	return pref.Value{}, d.newError(tok.Pos(), "invalid value for %v type: %v", kind, tok.RawString())
+	return pref.Value{}, d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid value for %v type: %v", kind, tok.RawString())
 }
 
 func unmarshalInt(tok json.Token, bitSize int) (pref.Value, bool) {
@@ -520,7 +520,7 @@ func (d decoder) unmarshalList(list pref.List, fd pref.FieldDescriptor) error {
 
 			val := list.NewElement()
 			if err := d.unmarshalMessage(val.Message(), false); err != nil {
-				return err
+				return errors.AddIndexPath(err, list.Len())
 			}
 			list.Append(val)
 		}
@@ -538,7 +538,7 @@ func (d decoder) unmarshalList(list pref.List, fd pref.FieldDescriptor) error {
 
 			val, err := d.unmarshalScalar(fd)
 			if err != nil {
-				return err
+				return errors.AddIndexPath(err, list.Len())
 			}
 			list.Append(val)
 		}
@@ -599,13 +599,13 @@ Loop:
 
 		// Check for duplicate field name.
 		if mmap.Has(pkey) {
-			return d.newError(tok.Pos(), "duplicate map key %v", tok.RawString())
+			return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "duplicate map key %v", tok.RawString())
 		}
 
 		// Read and unmarshal field value.
 		pval, err := unmarshalMapValue()
 		if err != nil {
-			return err
+			return errors.AddMapKeyPath(err, pkey.Interface())
 		}
 
 		mmap.Set(pkey, pval)
@@ -659,5 +659,5 @@ func (d decoder) unmarshalMapKey(tok json.Token, fd pref.FieldDescriptor) (pref.
 		panic(fmt.Sprintf("invalid kind for map key: %v", kind))
 	}
 
-	return pref.MapKey{}, d.newError(tok.Pos(), "invalid value for %v key: %s", kind, tok.RawString())
+	return pref.MapKey{}, d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid value for %v key: %s", kind, tok.RawString())
 }
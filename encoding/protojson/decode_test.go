@@ -1895,6 +1895,11 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &timestamppb.Timestamp{},
 		inputText:    `"1970-01-01T00:00:00.0000000001Z"`,
 		wantErr:      `invalid google.protobuf.Timestamp value`,
+	}, {
+		desc:         "Timestamp with comma as fraction separator",
+		inputMessage: &timestamppb.Timestamp{},
+		inputText:    `"1970-01-01T00:00:00,5Z"`,
+		wantErr:      `invalid google.protobuf.Timestamp value`,
 	}, {
 		desc:         "FieldMask empty",
 		inputMessage: &fieldmaskpb.FieldMask{},
@@ -2478,3 +2483,78 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalErrorPath(t *testing.T) {
+	tests := []struct {
+		desc         string
+		inputMessage proto.Message
+		inputText    string
+		wantKind     proto.DecodeErrorKind
+		wantPath     string
+		wantLine     int
+		wantColumn   int
+	}{{
+		desc:         "invalid value in nested message",
+		inputMessage: &pb3.Nests{},
+		inputText: `{
+  "sNested": {
+    "sNested": {"sString": 1}
+  }
+}`,
+		wantKind:   proto.DecodeErrorInvalidValue,
+		wantPath:   "s_nested.s_nested.s_string",
+		wantLine:   3,
+		wantColumn: 28,
+	}, {
+		desc:         "invalid list element",
+		inputMessage: &pb3.Repeats{},
+		inputText:    `{"rptInt32": [1, 2, "x"]}`,
+		wantKind:     proto.DecodeErrorInvalidValue,
+		wantPath:     "rpt_int32[2]",
+		wantLine:     1,
+		wantColumn:   21,
+	}, {
+		desc:         "unknown field in map value",
+		inputMessage: &pb3.Maps{},
+		inputText:    `{"strToNested": {"k": {"unknown": 1}}}`,
+		wantKind:     proto.DecodeErrorUnknownField,
+		wantPath:     `str_to_nested["k"]`,
+		wantLine:     1,
+		wantColumn:   24,
+	}, {
+		desc:         "duplicate field",
+		inputMessage: &pb3.Scalars{},
+		inputText:    `{"sInt32": 1, "sInt32": 2}`,
+		wantKind:     proto.DecodeErrorDuplicateField,
+		wantLine:     1,
+		wantColumn:   15,
+	}, {
+		desc:         "unexpected EOF",
+		inputMessage: &pb3.Nests{},
+		inputText:    `{"sNested": {`,
+		wantKind:     proto.DecodeErrorSyntax,
+		wantPath:     "s_nested",
+		wantLine:     1,
+		wantColumn:   14,
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			err := protojson.Unmarshal([]byte(tt.inputText), tt.inputMessage)
+			derr, ok := err.(*proto.DecodeError)
+			if !ok {
+				t.Fatalf("Unmarshal() error = %v (%T), want *proto.DecodeError", err, err)
+			}
+			if derr.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", derr.Kind, tt.wantKind)
+			}
+			if derr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", derr.Path, tt.wantPath)
+			}
+			if derr.Line != tt.wantLine || derr.Column != tt.wantColumn {
+				t.Errorf("position = %d:%d, want %d:%d", derr.Line, derr.Column, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
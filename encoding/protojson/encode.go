@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/order"
 	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
@@ -82,6 +83,17 @@ type MarshalOptions struct {
 	//  ╚═══════╧════════════════════════════╝
 	EmitUnpopulated bool
 
+	// Redact specifies whether to replace the values of sensitive fields
+	// with the JSON string "[REDACTED]", in which case the unmarshaler is
+	// unable to parse the output. A field is sensitive if it has the
+	// debug_redact field option set or if RedactField reports true for it.
+	// Format always redacts sensitive fields.
+	Redact bool
+
+	// RedactField reports whether a field is sensitive in addition to
+	// fields with the debug_redact option. It is only used if Redact is set.
+	RedactField func(protoreflect.FieldDescriptor) bool
+
 	// Resolver is used for looking up types when expanding google.protobuf.Any
 	// messages. If nil, this defaults to using protoregistry.GlobalTypes.
 	Resolver interface {
@@ -99,6 +111,7 @@ func (o MarshalOptions) Format(m proto.Message) string {
 		return "<nil>" // invalid syntax, but okay since this is for debugging
 	}
 	o.AllowPartial = true
+	o.Redact = true
 	b, _ := o.Marshal(m)
 	return string(b)
 }
@@ -229,6 +242,10 @@ func (e encoder) marshalMessage(m pref.Message, typeURL string) error {
 		if err = e.WriteName(name); err != nil {
 			return false
 		}
+		if e.opts.Redact && redact.IsRedacted(fd, e.opts.RedactField) {
+			err = e.WriteString(redact.Placeholder)
+			return err == nil
+		}
 		if err = e.marshalValue(v, fd); err != nil {
 			return false
 		}
@@ -5,14 +5,12 @@
 package protojson
 
 import (
-	"bytes"
 	"fmt"
 	"math"
-	"strconv"
 	"strings"
-	"time"
 
 	"google.golang.org/protobuf/internal/encoding/json"
+	"google.golang.org/protobuf/internal/encoding/timefmt"
 	"google.golang.org/protobuf/internal/errors"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/strs"
@@ -191,7 +189,7 @@ func (d decoder) unmarshalAny(m pref.Message) error {
 			return d.skipJSONValue()
 		}
 		// Use start.Pos() for line position.
-		return d.newError(start.Pos(), err.Error())
+		return d.newError(errors.DecodeErrorInvalidValue, start.Pos(), err.Error())
 
 	default:
 		if err != nil {
@@ -202,7 +200,7 @@ func (d decoder) unmarshalAny(m pref.Message) error {
 	typeURL := tok.ParsedString()
 	emt, err := d.opts.Resolver.FindMessageByURL(typeURL)
 	if err != nil {
-		return d.newError(tok.Pos(), "unable to resolve %v: %q", tok.RawString(), err)
+		return d.newError(errors.DecodeErrorUnresolvedType, tok.Pos(), "unable to resolve %v: %q", tok.RawString(), err)
 	}
 
 	// Create new message for the embedded message type and unmarshal into it.
@@ -226,7 +224,7 @@ func (d decoder) unmarshalAny(m pref.Message) error {
 		Deterministic: true,
 	}.Marshal(em.Interface())
 	if err != nil {
-		return d.newError(start.Pos(), "error in marshaling Any.value field: %v", err)
+		return d.newError(errors.DecodeErrorOther, start.Pos(), "error in marshaling Any.value field: %v", err)
 	}
 
 	fds := m.Descriptor().Fields()
@@ -283,7 +281,7 @@ Loop:
 
 			// Return error if this was previously set already.
 			if typeURL != "" {
-				return json.Token{}, d.newError(tok.Pos(), `duplicate "@type" field`)
+				return json.Token{}, d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), `duplicate "@type" field`)
 			}
 			// Read field value.
 			tok, err := d.Read()
@@ -291,11 +289,11 @@ Loop:
 				return json.Token{}, err
 			}
 			if tok.Kind() != json.String {
-				return json.Token{}, d.newError(tok.Pos(), `@type field value is not a string: %v`, tok.RawString())
+				return json.Token{}, d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), `@type field value is not a string: %v`, tok.RawString())
 			}
 			typeURL = tok.ParsedString()
 			if typeURL == "" {
-				return json.Token{}, d.newError(tok.Pos(), `@type field contains empty value`)
+				return json.Token{}, d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), `@type field contains empty value`)
 			}
 			typeTok = tok
 		}
@@ -367,7 +365,7 @@ func (d decoder) unmarshalAnyValue(unmarshal unmarshalFunc, m pref.Message) erro
 		switch tok.Kind() {
 		case json.ObjectClose:
 			if !found {
-				return d.newError(tok.Pos(), `missing "value" field`)
+				return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), `missing "value" field`)
 			}
 			return nil
 
@@ -379,7 +377,7 @@ func (d decoder) unmarshalAnyValue(unmarshal unmarshalFunc, m pref.Message) erro
 
 			case "value":
 				if found {
-					return d.newError(tok.Pos(), `duplicate "value" field`)
+					return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), `duplicate "value" field`)
 				}
 				// Unmarshal the field value into the given message.
 				if err := unmarshal(d, m); err != nil {
@@ -394,7 +392,7 @@ func (d decoder) unmarshalAnyValue(unmarshal unmarshalFunc, m pref.Message) erro
 					}
 					continue
 				}
-				return d.newError(tok.Pos(), "unknown field %v", tok.RawString())
+				return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "unknown field %v", tok.RawString())
 			}
 		}
 	}
@@ -451,7 +449,7 @@ func (d decoder) unmarshalEmpty(pref.Message) error {
 				}
 				continue
 			}
-			return d.newError(tok.Pos(), "unknown field %v", tok.RawString())
+			return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "unknown field %v", tok.RawString())
 
 		default:
 			return d.unexpectedTokenError(tok)
@@ -535,7 +533,7 @@ func (d decoder) unmarshalKnownValue(m pref.Message) error {
 		var ok bool
 		val, ok = unmarshalFloat(tok, 64)
 		if !ok {
-			return d.newError(tok.Pos(), "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
+			return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
 		}
 
 	case json.String:
@@ -567,7 +565,7 @@ func (d decoder) unmarshalKnownValue(m pref.Message) error {
 		}
 
 	default:
-		return d.newError(tok.Pos(), "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
+		return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
 	}
 
 	m.Set(fd, val)
@@ -588,7 +586,7 @@ func (d decoder) unmarshalKnownValue(m pref.Message) error {
 
 const (
 	secondsInNanos       = 999999999
-	maxSecondsInDuration = 315576000000
+	maxSecondsInDuration = timefmt.MaxDurationSeconds
 )
 
 func (e encoder) marshalDuration(m pref.Message) error {
@@ -611,15 +609,7 @@ func (e encoder) marshalDuration(m pref.Message) error {
 	}
 	// Generated output always contains 0, 3, 6, or 9 fractional digits,
 	// depending on required precision, followed by the suffix "s".
-	var sign string
-	if secs < 0 || nanos < 0 {
-		sign, secs, nanos = "-", -1*secs, -1*nanos
-	}
-	x := fmt.Sprintf("%s%d.%09d", sign, secs, nanos)
-	x = strings.TrimSuffix(x, "000")
-	x = strings.TrimSuffix(x, "000")
-	x = strings.TrimSuffix(x, ".000")
-	e.WriteString(x + "s")
+	e.WriteString(timefmt.FormatDuration(secs, int32(nanos)))
 	return nil
 }
 
@@ -632,14 +622,14 @@ func (d decoder) unmarshalDuration(m pref.Message) error {
 		return d.unexpectedTokenError(tok)
 	}
 
-	secs, nanos, ok := parseDuration(tok.ParsedString())
+	secs, nanos, ok := timefmt.ParseDuration(tok.ParsedString())
 	if !ok {
-		return d.newError(tok.Pos(), "invalid %v value %v", genid.Duration_message_fullname, tok.RawString())
+		return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid %v value %v", genid.Duration_message_fullname, tok.RawString())
 	}
 	// Validate seconds. No need to validate nanos because parseDuration would
 	// have covered that already.
 	if secs < -maxSecondsInDuration || secs > maxSecondsInDuration {
-		return d.newError(tok.Pos(), "%v value out of range: %v", genid.Duration_message_fullname, tok.RawString())
+		return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "%v value out of range: %v", genid.Duration_message_fullname, tok.RawString())
 	}
 
 	fds := m.Descriptor().Fields()
@@ -651,116 +641,6 @@ func (d decoder) unmarshalDuration(m pref.Message) error {
 	return nil
 }
 
-// parseDuration parses the given input string for seconds and nanoseconds value
-// for the Duration JSON format. The format is a decimal number with a suffix
-// 's'. It can have optional plus/minus sign. There needs to be at least an
-// integer or fractional part. Fractional part is limited to 9 digits only for
-// nanoseconds precision, regardless of whether there are trailing zero digits.
-// Example values are 1s, 0.1s, 1.s, .1s, +1s, -1s, -.1s.
-func parseDuration(input string) (int64, int32, bool) {
-	b := []byte(input)
-	size := len(b)
-	if size < 2 {
-		return 0, 0, false
-	}
-	if b[size-1] != 's' {
-		return 0, 0, false
-	}
-	b = b[:size-1]
-
-	// Read optional plus/minus symbol.
-	var neg bool
-	switch b[0] {
-	case '-':
-		neg = true
-		b = b[1:]
-	case '+':
-		b = b[1:]
-	}
-	if len(b) == 0 {
-		return 0, 0, false
-	}
-
-	// Read the integer part.
-	var intp []byte
-	switch {
-	case b[0] == '0':
-		b = b[1:]
-
-	case '1' <= b[0] && b[0] <= '9':
-		intp = b[0:]
-		b = b[1:]
-		n := 1
-		for len(b) > 0 && '0' <= b[0] && b[0] <= '9' {
-			n++
-			b = b[1:]
-		}
-		intp = intp[:n]
-
-	case b[0] == '.':
-		// Continue below.
-
-	default:
-		return 0, 0, false
-	}
-
-	hasFrac := false
-	var frac [9]byte
-	if len(b) > 0 {
-		if b[0] != '.' {
-			return 0, 0, false
-		}
-		// Read the fractional part.
-		b = b[1:]
-		n := 0
-		for len(b) > 0 && n < 9 && '0' <= b[0] && b[0] <= '9' {
-			frac[n] = b[0]
-			n++
-			b = b[1:]
-		}
-		// It is not valid if there are more bytes left.
-		if len(b) > 0 {
-			return 0, 0, false
-		}
-		// Pad fractional part with 0s.
-		for i := n; i < 9; i++ {
-			frac[i] = '0'
-		}
-		hasFrac = true
-	}
-
-	var secs int64
-	if len(intp) > 0 {
-		var err error
-		secs, err = strconv.ParseInt(string(intp), 10, 64)
-		if err != nil {
-			return 0, 0, false
-		}
-	}
-
-	var nanos int64
-	if hasFrac {
-		nanob := bytes.TrimLeft(frac[:], "0")
-		if len(nanob) > 0 {
-			var err error
-			nanos, err = strconv.ParseInt(string(nanob), 10, 32)
-			if err != nil {
-				return 0, 0, false
-			}
-		}
-	}
-
-	if neg {
-		if secs > 0 {
-			secs = -secs
-		}
-		if nanos > 0 {
-			nanos = -nanos
-		}
-	}
-	return secs, int32(nanos), true
-}
-
 // The JSON representation for a Timestamp is a JSON string in the RFC 3339
 // format, i.e. "{year}-{month}-{day}T{hour}:{min}:{sec}[.{frac_sec}]Z" where
 // {year} is always expressed using four digits while {month}, {day}, {hour},
@@ -775,8 +655,8 @@ func parseDuration(input string) (int64, int32, bool) {
 // Timestamp.nanos must be from 0 to 999,999,999 inclusive.
 
 const (
-	maxTimestampSeconds = 253402300799
-	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = timefmt.MaxTimestampSeconds
+	minTimestampSeconds = timefmt.MinTimestampSeconds
 )
 
 func (e encoder) marshalTimestamp(m pref.Message) error {
@@ -796,12 +676,7 @@ func (e encoder) marshalTimestamp(m pref.Message) error {
 	}
 	// Uses RFC 3339, where generated output will be Z-normalized and uses 0, 3,
 	// 6 or 9 fractional digits.
-	t := time.Unix(secs, nanos).UTC()
-	x := t.Format("2006-01-02T15:04:05.000000000")
-	x = strings.TrimSuffix(x, "000")
-	x = strings.TrimSuffix(x, "000")
-	x = strings.TrimSuffix(x, ".000")
-	e.WriteString(x + "Z")
+	e.WriteString(timefmt.FormatTimestamp(secs, int32(nanos)))
 	return nil
 }
 
@@ -814,15 +689,14 @@ func (d decoder) unmarshalTimestamp(m pref.Message) error {
 		return d.unexpectedTokenError(tok)
 	}
 
-	t, err := time.Parse(time.RFC3339Nano, tok.ParsedString())
-	if err != nil {
-		return d.newError(tok.Pos(), "invalid %v value %v", genid.Timestamp_message_fullname, tok.RawString())
+	secs, nanos, ok := timefmt.ParseTimestamp(tok.ParsedString())
+	if !ok {
+		return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid %v value %v", genid.Timestamp_message_fullname, tok.RawString())
 	}
-	// Validate seconds. No need to validate nanos because time.Parse would have
-	// covered that already.
-	secs := t.Unix()
+	// Validate seconds. No need to validate nanos because ParseTimestamp would
+	// have covered that already.
 	if secs < minTimestampSeconds || secs > maxTimestampSeconds {
-		return d.newError(tok.Pos(), "%v value out of range: %v", genid.Timestamp_message_fullname, tok.RawString())
+		return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "%v value out of range: %v", genid.Timestamp_message_fullname, tok.RawString())
 	}
 
 	fds := m.Descriptor().Fields()
@@ -830,7 +704,7 @@ func (d decoder) unmarshalTimestamp(m pref.Message) error {
 	fdNanos := fds.ByNumber(genid.Timestamp_Nanos_field_number)
 
 	m.Set(fdSeconds, pref.ValueOfInt64(secs))
-	m.Set(fdNanos, pref.ValueOfInt32(int32(t.Nanosecond())))
+	m.Set(fdNanos, pref.ValueOfInt32(nanos))
 	return nil
 }
 
@@ -881,7 +755,7 @@ func (d decoder) unmarshalFieldMask(m pref.Message) error {
 	for _, s0 := range paths {
 		s := strs.JSONSnakeCase(s0)
 		if strings.Contains(s0, "_") || !pref.FullName(s).IsValid() {
-			return d.newError(tok.Pos(), "%v contains invalid path: %q", genid.FieldMask_Paths_field_fullname, s0)
+			return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "%v contains invalid path: %q", genid.FieldMask_Paths_field_fullname, s0)
 		}
 		list.Append(pref.ValueOfString(s))
 	}
@@ -0,0 +1,225 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoscan
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Set returns the wire-format message b with the field named by p set to v.
+// The result unmarshals to the same message as unmarshaling b, setting the
+// field, and marshaling the message again. Messages along the path are
+// created as needed, and setting a member of a oneof clears the other members.
+// It returns an error if p contains a repeated field.
+//
+// The value is written in place of the last occurrence of the field, or
+// appended to the message if the field is absent. Other occurrences of the
+// field are removed, and multiple occurrences of an enclosing message field
+// are merged into one. Unrelated fields are copied unchanged.
+// The input buffer is not modified.
+func (p *Path) Set(b []byte, v protoreflect.Value) ([]byte, error) {
+	if p.repeated {
+		return nil, errors.New("field path has multiple values")
+	}
+	out, err := p.edit(nil, b, 0, v)
+	return out, errors.SetWireOffset(err, b)
+}
+
+// Clear returns the wire-format message b with the field named by p cleared.
+// If p contains a repeated message field, the field is cleared in every
+// element of the list. Messages along the path are not created if absent.
+// The input buffer is not modified.
+func (p *Path) Clear(b []byte) ([]byte, error) {
+	out, err := p.edit(nil, b, 0, protoreflect.Value{})
+	return out, errors.SetWireOffset(err, b)
+}
+
+// occurrence is the location of a field within an encoded message.
+type occurrence struct {
+	start, end int    // bounds of the tag and value
+	value      []byte // encoded message value of a message or group field
+	sibling    bool   // whether the field is another member of the same oneof
+}
+
+// edit appends to out the message b with fields[i:] set to v,
+// or cleared if v is invalid.
+func (p *Path) edit(out, b []byte, i int, v protoreflect.Value) ([]byte, error) {
+	fd := p.fields[i]
+	num := fd.Number()
+	var oneof protoreflect.OneofDescriptor
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		oneof = od
+	}
+	last := i == len(p.fields)-1
+	set := v.IsValid()
+
+	var occs []occurrence
+	live := 0 // index of the first occurrence not cleared by a oneof sibling
+	for pos := 0; pos < len(b); {
+		n, t, tagLen := protowire.ConsumeTag(b[pos:])
+		if tagLen < 0 {
+			return nil, errDecode(b[pos:])
+		}
+		valLen := protowire.ConsumeFieldValue(n, t, b[pos+tagLen:])
+		if valLen < 0 {
+			return nil, errDecode(b[pos:])
+		}
+		o := occurrence{start: pos, end: pos + tagLen + valLen}
+		pos = o.end
+		switch {
+		case n == num && last:
+			wt := wireType(fd.Kind())
+			if t != wt && !(fd.IsList() && t == protowire.BytesType && wt != protowire.BytesType) {
+				continue
+			}
+		case n == num:
+			var ok bool
+			if o.value, ok = messageValue(fd, n, t, b[o.start+tagLen:o.end]); !ok {
+				continue
+			}
+		case oneof != nil:
+			od := oneof.Fields().ByNumber(n)
+			if od == nil || t != wireType(od.Kind()) {
+				continue
+			}
+			o.sibling = true
+			live = len(occs) + 1
+		default:
+			continue
+		}
+		occs = append(occs, o)
+	}
+
+	// Determine the encoding of the field that replaces the last occurrence.
+	var (
+		repl    []byte
+		hasRepl bool
+	)
+	switch {
+	case last && set:
+		repl = protowire.AppendTag(nil, num, wireType(fd.Kind()))
+		var err error
+		if repl, err = appendValue(repl, fd, v); err != nil {
+			return nil, err
+		}
+		hasRepl = true
+	case !last && !fd.IsList():
+		var merged []byte
+		var found bool
+		for _, o := range occs[live:] {
+			merged = append(merged, o.value...)
+			found = true
+		}
+		if !found && !set {
+			// Clearing a field of an absent message does not create it.
+			return append(out, b...), nil
+		}
+		inner, err := p.edit(nil, merged, i+1, v)
+		if err != nil {
+			return nil, err
+		}
+		repl = appendMessage(nil, fd, inner)
+		hasRepl = true
+	}
+
+	// Setting a member of a oneof clears the other members, as does clearing
+	// the member that is set, since earlier members would otherwise reappear.
+	dropSiblings := set || (last && len(occs) > 0 && !occs[len(occs)-1].sibling)
+
+	anchor := -1
+	if hasRepl {
+		for j, o := range occs {
+			if !o.sibling {
+				anchor = j
+			}
+		}
+	}
+	prev := 0
+	for j, o := range occs {
+		if o.sibling && !dropSiblings {
+			continue
+		}
+		out = append(out, b[prev:o.start]...)
+		prev = o.end
+		switch {
+		case j == anchor:
+			out = append(out, repl...)
+		case !last && fd.IsList() && !o.sibling:
+			// Each element of a repeated field is edited separately.
+			inner, err := p.edit(nil, o.value, i+1, v)
+			if err != nil {
+				return nil, err
+			}
+			out = appendMessage(out, fd, inner)
+		}
+	}
+	out = append(out, b[prev:]...)
+	if hasRepl && anchor < 0 {
+		out = append(out, repl...)
+	}
+	return out, nil
+}
+
+// appendMessage appends an occurrence of the message or group field fd
+// with the encoded message value b.
+func appendMessage(out []byte, fd protoreflect.FieldDescriptor, b []byte) []byte {
+	if fd.Kind() == protoreflect.GroupKind {
+		out = protowire.AppendTag(out, fd.Number(), protowire.StartGroupType)
+		out = append(out, b...)
+		return protowire.AppendTag(out, fd.Number(), protowire.EndGroupType)
+	}
+	out = protowire.AppendTag(out, fd.Number(), protowire.BytesType)
+	return protowire.AppendBytes(out, b)
+}
+
+// appendValue appends the encoding of the scalar value v of field fd.
+func appendValue(b []byte, fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v.Bool()))
+	case protoreflect.EnumKind:
+		b = protowire.AppendVarint(b, uint64(v.Enum()))
+	case protoreflect.Int32Kind:
+		b = protowire.AppendVarint(b, uint64(int32(v.Int())))
+	case protoreflect.Sint32Kind:
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(int32(v.Int()))))
+	case protoreflect.Uint32Kind:
+		b = protowire.AppendVarint(b, uint64(uint32(v.Uint())))
+	case protoreflect.Int64Kind:
+		b = protowire.AppendVarint(b, uint64(v.Int()))
+	case protoreflect.Sint64Kind:
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int()))
+	case protoreflect.Uint64Kind:
+		b = protowire.AppendVarint(b, v.Uint())
+	case protoreflect.Sfixed32Kind:
+		b = protowire.AppendFixed32(b, uint32(v.Int()))
+	case protoreflect.Fixed32Kind:
+		b = protowire.AppendFixed32(b, uint32(v.Uint()))
+	case protoreflect.FloatKind:
+		b = protowire.AppendFixed32(b, math.Float32bits(float32(v.Float())))
+	case protoreflect.Sfixed64Kind:
+		b = protowire.AppendFixed64(b, uint64(v.Int()))
+	case protoreflect.Fixed64Kind:
+		b = protowire.AppendFixed64(b, v.Uint())
+	case protoreflect.DoubleKind:
+		b = protowire.AppendFixed64(b, math.Float64bits(v.Float()))
+	case protoreflect.StringKind:
+		if strs.EnforceUTF8(fd) && !utf8.ValidString(v.String()) {
+			return b, errors.InvalidUTF8(string(fd.FullName()))
+		}
+		b = protowire.AppendString(b, v.String())
+	case protoreflect.BytesKind:
+		b = protowire.AppendBytes(b, v.Bytes())
+	default:
+		return b, errors.New("invalid kind %v", fd.Kind())
+	}
+	return b, nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoscan_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protoscan"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protopack"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+var editInputs = []protopack.Message{
+	{},
+	{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		protopack.Tag{14, protopack.BytesType}, protopack.String("string"),
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+		protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(3),
+		protopack.Tag{31, protopack.VarintType}, protopack.Varint(4),
+		protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Varint(5), protopack.Varint(6),
+		},
+	},
+	{
+		protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+			},
+		},
+		protopack.Tag{2, protopack.VarintType}, protopack.Varint(3),
+		protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{14, protopack.BytesType}, protopack.String("x"),
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(4),
+			},
+		},
+		protopack.Tag{18, protopack.VarintType}, protopack.Varint(5),
+	},
+	{
+		protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		},
+		protopack.Tag{113, protopack.BytesType}, protopack.String("string"),
+		protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{},
+		},
+		protopack.Tag{111, protopack.BytesType}, protopack.String("wrong wire type"),
+	},
+	{
+		protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		},
+		protopack.Tag{111, protopack.VarintType}, protopack.Varint(2),
+	},
+	{
+		protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{31, protopack.VarintType}, protopack.Varint(2),
+			},
+		},
+		protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{},
+		protopack.Tag{16, protopack.StartGroupType},
+		protopack.Tag{17, protopack.VarintType}, protopack.Varint(3),
+		protopack.Tag{16, protopack.EndGroupType},
+		protopack.Tag{46, protopack.StartGroupType},
+		protopack.Tag{47, protopack.VarintType}, protopack.Varint(4),
+		protopack.Tag{46, protopack.EndGroupType},
+	},
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		path string
+		v    protoreflect.Value
+	}{
+		{"optional_int32", protoreflect.ValueOfInt32(-1)},
+		{"optional_sint64", protoreflect.ValueOfInt64(-2)},
+		{"optional_fixed32", protoreflect.ValueOfUint32(3)},
+		{"optional_double", protoreflect.ValueOfFloat64(4.5)},
+		{"optional_bool", protoreflect.ValueOfBool(true)},
+		{"optional_string", protoreflect.ValueOfString("set")},
+		{"optional_bytes", protoreflect.ValueOfBytes([]byte("set"))},
+		{"optional_nested_enum", protoreflect.ValueOfEnum(2)},
+		{"optional_nested_message.a", protoreflect.ValueOfInt32(100)},
+		{"optional_nested_message.corecursive.optional_int32", protoreflect.ValueOfInt32(100)},
+		{"optional_nested_message.corecursive.optional_nested_message.a", protoreflect.ValueOfInt32(100)},
+		{"oneof_uint32", protoreflect.ValueOfUint32(100)},
+		{"oneof_string", protoreflect.ValueOfString("set")},
+		{"oneof_nested_message.a", protoreflect.ValueOfInt32(100)},
+		{"oneof_nested_message.corecursive.optional_int32", protoreflect.ValueOfInt32(100)},
+		{"optionalgroup.a", protoreflect.ValueOfInt32(100)},
+	}
+	for _, test := range tests {
+		for _, in := range editInputs {
+			b := in.Marshal()
+			t.Run(test.path, func(t *testing.T) {
+				p, err := protoscan.Compile((&testpb.TestAllTypes{}).ProtoReflect().Descriptor(), test.path)
+				if err != nil {
+					t.Fatalf("Compile(%q) error: %v", test.path, err)
+				}
+				orig := append([]byte(nil), b...)
+				got, err := p.Set(b, test.v)
+				if err != nil {
+					t.Fatalf("Set error: %v", err)
+				}
+				if !bytes.Equal(b, orig) {
+					t.Errorf("Set modified its input")
+				}
+
+				want := &testpb.TestAllTypes{}
+				if err := proto.Unmarshal(b, want); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				setPath(want.ProtoReflect(), strings.Split(test.path, "."), test.v)
+				checkEdit(t, in, got, want)
+
+				v, ok, err := p.Get(got)
+				if err != nil || !ok || !equalValues([]protoreflect.Value{v}, []protoreflect.Value{test.v}) {
+					t.Errorf("Get after Set = %v, %v, %v; want %v, true, nil", v, ok, err, test.v)
+				}
+			})
+		}
+	}
+}
+
+func TestClear(t *testing.T) {
+	for _, path := range []string{
+		"optional_int32",
+		"optional_string",
+		"repeated_int32",
+		"optional_nested_message.a",
+		"optional_nested_message.corecursive.optional_int32",
+		"optional_nested_message.corecursive.optional_string",
+		"oneof_uint32",
+		"oneof_nested_message.a",
+		"oneof_nested_message.corecursive.optional_int32",
+		"repeated_nested_message.a",
+		"repeated_nested_message.corecursive.repeated_int32",
+		"optionalgroup.a",
+		"repeatedgroup.a",
+	} {
+		for _, in := range editInputs {
+			b := in.Marshal()
+			t.Run(path, func(t *testing.T) {
+				p, err := protoscan.Compile((&testpb.TestAllTypes{}).ProtoReflect().Descriptor(), path)
+				if err != nil {
+					t.Fatalf("Compile(%q) error: %v", path, err)
+				}
+				got, err := p.Clear(b)
+				if err != nil {
+					t.Fatalf("Clear error: %v", err)
+				}
+
+				want := &testpb.TestAllTypes{}
+				if err := proto.Unmarshal(b, want); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				clearPath(want.ProtoReflect(), strings.Split(path, "."))
+				checkEdit(t, in, got, want)
+			})
+		}
+	}
+}
+
+// checkEdit checks that the edited encoding got unmarshals to want.
+func checkEdit(t *testing.T, in protopack.Message, got []byte, want proto.Message) {
+	t.Helper()
+	m := &testpb.TestAllTypes{}
+	if err := proto.Unmarshal(got, m); err != nil {
+		t.Fatalf("Unmarshal of edited message error: %v", err)
+	}
+	if !proto.Equal(m, want) {
+		var out protopack.Message
+		out.UnmarshalDescriptor(got, m.ProtoReflect().Descriptor())
+		t.Errorf("edited message mismatch:\ninput:  %v\noutput: %v\ngot:  %v\nwant: %v",
+			in, out, prototext.Format(m), prototext.Format(want))
+	}
+}
+
+// setPath sets the field named by path in m to v, creating messages as needed.
+func setPath(m protoreflect.Message, path []string, v protoreflect.Value) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if len(path) == 1 {
+		m.Set(fd, v)
+		return
+	}
+	setPath(m.Mutable(fd).Message(), path[1:], v)
+}
+
+// clearPath clears the field named by path in m, if present.
+func clearPath(m protoreflect.Message, path []string) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	switch {
+	case len(path) == 1:
+		m.Clear(fd)
+	case !m.Has(fd):
+	case fd.IsList():
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			clearPath(list.Get(i).Message(), path[1:])
+		}
+	default:
+		clearPath(m.Get(fd).Message(), path[1:])
+	}
+}
+
+func TestEditErrors(t *testing.T) {
+	md := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor()
+	p, err := protoscan.Compile(md, "repeated_int32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Set(nil, protoreflect.ValueOfInt32(1)); err == nil {
+		t.Errorf("Set on a repeated path succeeded, want error")
+	}
+
+	p, err = protoscan.Compile(md, "optional_nested_message.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := protopack.Message{
+		protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.VarintType}, protopack.Raw{0x80},
+		},
+	}.Marshal()
+	if _, err := p.Set(b, protoreflect.ValueOfInt32(1)); err == nil {
+		t.Errorf("Set on invalid input succeeded, want error")
+	}
+	if _, err := p.Clear(b); err == nil {
+		t.Errorf("Clear on invalid input succeeded, want error")
+	}
+
+	p, err = protoscan.Compile((&test3pb.TestAllTypes{}).ProtoReflect().Descriptor(), "singular_string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Set(nil, protoreflect.ValueOfString("\xff")); err == nil {
+		t.Errorf("Set with invalid UTF-8 succeeded, want error")
+	}
+}
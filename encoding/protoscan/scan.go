@@ -0,0 +1,316 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoscan reads and edits field values directly in wire-format
+// messages.
+//
+// Scanning reads only the fields along a path and never allocates a message,
+// which makes it suitable for routing and sharding decisions that depend on
+// a few fields of a large payload. Editing similarly rewrites a single field
+// without unmarshaling and marshaling the rest of the message:
+//
+//	p, err := protoscan.Compile(md, "header.tenant_id")
+//	...
+//	v, ok, err := p.Get(b)
+//	...
+//	b, err = p.Set(b, protoreflect.ValueOfString("default"))
+//
+// The values found are those that proto.Unmarshal would produce:
+// multiple occurrences of a singular message field are merged,
+// the last occurrence of a singular scalar field wins, setting a member
+// of a oneof clears the other members, and repeated scalar fields are
+// accepted in both packed and unpacked form.
+// Values of bytes fields alias the input buffer.
+package protoscan
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Path is a sequence of fields leading from a message to a scalar field.
+// A Path is safe for concurrent use.
+type Path struct {
+	fields   []protoreflect.FieldDescriptor
+	repeated bool // whether any field in the path is repeated
+}
+
+// Compile returns the path named by s in messages of type md.
+// The path is a sequence of field names separated by dots.
+// Every field except the last must be a message or group field,
+// which may be repeated but not a map. The last field must be a scalar
+// (neither a message nor a map) field, which may be repeated.
+// Extension fields cannot be named.
+func Compile(md protoreflect.MessageDescriptor, s string) (*Path, error) {
+	p := &Path{}
+	names := strings.Split(s, ".")
+	for i, name := range names {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, errors.New("invalid field path %q: %v has no field named %q", s, md.FullName(), name)
+		}
+		if fd.IsMap() {
+			return nil, errors.New("invalid field path %q: field %v is a map", s, fd.FullName())
+		}
+		isMessage := fd.Message() != nil
+		if last := i == len(names)-1; last && isMessage {
+			return nil, errors.New("invalid field path %q: field %v is a message", s, fd.FullName())
+		} else if !last && !isMessage {
+			return nil, errors.New("invalid field path %q: field %v is not a message", s, fd.FullName())
+		}
+		p.fields = append(p.fields, fd)
+		p.repeated = p.repeated || fd.IsList()
+		md = fd.Message()
+	}
+	return p, nil
+}
+
+// Field returns the last field in the path.
+func (p *Path) Field() protoreflect.FieldDescriptor {
+	return p.fields[len(p.fields)-1]
+}
+
+// Get returns the value of the field named by p in the wire-format message b.
+// It reports false if the field is not populated, in which case the value
+// is the default value of the field.
+// It returns an error if p contains a repeated field; use Range instead.
+func (p *Path) Get(b []byte) (v protoreflect.Value, ok bool, err error) {
+	if p.repeated {
+		return v, false, errors.New("field path has multiple values")
+	}
+	err = p.Range(b, func(x protoreflect.Value) bool {
+		v, ok = x, true
+		return true
+	})
+	if err != nil || !ok {
+		return p.Field().Default(), false, err
+	}
+	return v, true, nil
+}
+
+// Range calls f for each value of the field named by p in the wire-format
+// message b, in the order in which unmarshaling would place them in the
+// message. If p contains no repeated fields, f is called at most once.
+// If f returns false, Range stops scanning.
+//
+// Range returns an error if the fields it scans are not valid wire-format data.
+// Other parts of b are not checked.
+func (p *Path) Range(b []byte, f func(protoreflect.Value) bool) error {
+	var segs [1][]byte
+	segs[0] = b
+	_, err := p.scan(segs[:], 0, f)
+	return errors.SetWireOffset(err, b)
+}
+
+// scan calls f for the values of fields[i:] in the message encoded as the
+// concatenation of segs. It reports false if f requested that scanning stop.
+func (p *Path) scan(segs [][]byte, i int, f func(protoreflect.Value) bool) (bool, error) {
+	fd := p.fields[i]
+	num := fd.Number()
+	var oneof protoreflect.OneofDescriptor
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		oneof = od
+	}
+	last := i == len(p.fields)-1
+
+	var (
+		found     []byte // encoding of the last value of a singular scalar field
+		foundType protowire.Type
+		hasFound  bool
+		inner     [4][]byte // occurrences of a singular message field
+		merged    = inner[:0]
+	)
+	for _, b := range segs {
+		for len(b) > 0 {
+			n, t, tagLen := protowire.ConsumeTag(b)
+			if tagLen < 0 {
+				return false, errDecode(b)
+			}
+			valLen := protowire.ConsumeFieldValue(n, t, b[tagLen:])
+			if valLen < 0 {
+				return false, errDecode(b)
+			}
+			v := b[tagLen : tagLen+valLen]
+			start := b
+			b = b[tagLen+valLen:]
+
+			if n != num {
+				if oneof != nil {
+					// Setting another member of the oneof clears this one.
+					if od := oneof.Fields().ByNumber(n); od != nil && t == wireType(od.Kind()) {
+						hasFound, merged = false, merged[:0]
+					}
+				}
+				continue
+			}
+			switch {
+			case last && fd.IsList():
+				ok, err := scanList(fd, t, v, start, f)
+				if !ok || err != nil {
+					return ok, err
+				}
+			case last:
+				if t == wireType(fd.Kind()) {
+					found, foundType, hasFound = v, t, true
+				}
+			default:
+				if v, ok := messageValue(fd, n, t, v); ok {
+					if fd.IsList() {
+						// Each element of a repeated field is a separate message.
+						var elem [1][]byte
+						elem[0] = v
+						if ok, err := p.scan(elem[:], i+1, f); !ok || err != nil {
+							return ok, err
+						}
+					} else {
+						merged = append(merged, v)
+					}
+				}
+			}
+		}
+	}
+	switch {
+	case hasFound:
+		v, _, err := decodeValue(fd, foundType, found)
+		if err != nil {
+			return false, err
+		}
+		return f(v), nil
+	case len(merged) > 0:
+		return p.scan(merged, i+1, f)
+	}
+	return true, nil
+}
+
+// messageValue returns the encoding of the message in the value v of a
+// message or group field. It reports false for a mismatched wire type,
+// which unmarshaling treats as an unknown field.
+func messageValue(fd protoreflect.FieldDescriptor, num protowire.Number, t protowire.Type, v []byte) ([]byte, bool) {
+	switch {
+	case fd.Kind() == protoreflect.GroupKind && t == protowire.StartGroupType:
+		v, _ = protowire.ConsumeGroup(num, v)
+		return v, true
+	case fd.Kind() == protoreflect.MessageKind && t == protowire.BytesType:
+		v, _ = protowire.ConsumeBytes(v)
+		return v, true
+	}
+	return nil, false
+}
+
+// scanList calls f for each value in the encoding v of one occurrence of a
+// repeated scalar field, which may be packed.
+func scanList(fd protoreflect.FieldDescriptor, t protowire.Type, v, start []byte, f func(protoreflect.Value) bool) (bool, error) {
+	wt := wireType(fd.Kind())
+	if t == wt {
+		x, _, err := decodeValue(fd, t, v)
+		if err != nil {
+			return false, err
+		}
+		return f(x), nil
+	}
+	if t != protowire.BytesType || wt == protowire.BytesType {
+		return true, nil
+	}
+	v, _ = protowire.ConsumeBytes(v)
+	for len(v) > 0 {
+		x, n, err := decodeValue(fd, wt, v)
+		if err != nil {
+			return false, err
+		}
+		if n < 0 {
+			return false, errDecode(start)
+		}
+		if !f(x) {
+			return false, nil
+		}
+		v = v[n:]
+	}
+	return true, nil
+}
+
+func wireType(k protoreflect.Kind) protowire.Type {
+	switch k {
+	case protoreflect.BoolKind, protoreflect.EnumKind,
+		protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind:
+		return protowire.VarintType
+	case protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.GroupKind:
+		return protowire.StartGroupType
+	default:
+		return protowire.BytesType
+	}
+}
+
+// decodeValue decodes a single value of wire type t from the start of b.
+// It returns the number of bytes consumed, or a negative number if b is
+// not a valid encoding of the value.
+func decodeValue(fd protoreflect.FieldDescriptor, t protowire.Type, b []byte) (protoreflect.Value, int, error) {
+	var v uint64
+	var n int
+	switch t {
+	case protowire.VarintType:
+		v, n = protowire.ConsumeVarint(b)
+	case protowire.Fixed32Type:
+		var v32 uint32
+		v32, n = protowire.ConsumeFixed32(b)
+		v = uint64(v32)
+	case protowire.Fixed64Type:
+		v, n = protowire.ConsumeFixed64(b)
+	case protowire.BytesType:
+		var s []byte
+		s, n = protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protoreflect.Value{}, n, nil
+		}
+		if fd.Kind() == protoreflect.StringKind {
+			if strs.EnforceUTF8(fd) && !utf8.Valid(s) {
+				return protoreflect.Value{}, n, errors.WireDecodeError(errors.DecodeErrorOther, errors.InvalidUTF8(string(fd.FullName())), cap(b))
+			}
+			return protoreflect.ValueOfString(string(s)), n, nil
+		}
+		return protoreflect.ValueOfBytes(s), n, nil
+	}
+	if n < 0 {
+		return protoreflect.Value{}, n, nil
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(protowire.DecodeBool(v)), n, nil
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), n, nil
+	case protoreflect.Int32Kind:
+		return protoreflect.ValueOfInt32(int32(v)), n, nil
+	case protoreflect.Sint32Kind:
+		return protoreflect.ValueOfInt32(int32(protowire.DecodeZigZag(v & math.MaxUint32))), n, nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(v)), n, nil
+	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(v)), n, nil
+	case protoreflect.Sint64Kind:
+		return protoreflect.ValueOfInt64(protowire.DecodeZigZag(v)), n, nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(v), n, nil
+	case protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(v)), n, nil
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(math.Float32frombits(uint32(v))), n, nil
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(math.Float64frombits(v)), n, nil
+	}
+	return protoreflect.Value{}, -1, nil
+}
+
+func errDecode(b []byte) error {
+	return errors.WireDecodeError(errors.DecodeErrorWireFormat, errors.New("cannot parse invalid wire-format data"), cap(b))
+}
@@ -0,0 +1,367 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoscan_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protoscan"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		desc string
+		path string
+		b    protopack.Message
+	}{{
+		desc: "absent",
+		path: "optional_int32",
+		b:    protopack.Message{},
+	}, {
+		desc: "last occurrence wins",
+		path: "optional_int32",
+		b: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(2),
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+		},
+	}, {
+		desc: "mismatched wire type",
+		path: "optional_int32",
+		b: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(2),
+		},
+	}, {
+		desc: "string",
+		path: "optional_string",
+		b: protopack.Message{
+			protopack.Tag{14, protopack.BytesType}, protopack.String("first"),
+			protopack.Tag{14, protopack.BytesType}, protopack.String("second"),
+		},
+	}, {
+		desc: "bytes",
+		path: "optional_bytes",
+		b: protopack.Message{
+			protopack.Tag{15, protopack.BytesType}, protopack.Bytes([]byte("first")),
+			protopack.Tag{15, protopack.BytesType}, protopack.Bytes([]byte("second")),
+		},
+	}, {
+		desc: "repeated bytes",
+		path: "repeated_bytes",
+		b: protopack.Message{
+			protopack.Tag{45, protopack.BytesType}, protopack.Bytes([]byte("first")),
+			protopack.Tag{45, protopack.BytesType}, protopack.Bytes(nil),
+			protopack.Tag{45, protopack.BytesType}, protopack.Bytes([]byte("second")),
+		},
+	}, {
+		desc: "merged messages",
+		path: "optional_nested_message.corecursive.optional_int32",
+		b: protopack.Message{
+			protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+				},
+			},
+			protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+			},
+			protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+				},
+			},
+			protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{14, protopack.BytesType}, protopack.String("x"),
+				},
+			},
+		},
+	}, {
+		desc: "oneof member cleared",
+		path: "oneof_nested_message.a",
+		b: protopack.Message{
+			protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			},
+			protopack.Tag{113, protopack.BytesType}, protopack.String("string"),
+			protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{},
+			},
+		},
+	}, {
+		desc: "oneof member set",
+		path: "oneof_nested_message.a",
+		b: protopack.Message{
+			protopack.Tag{113, protopack.BytesType}, protopack.String("string"),
+			protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			},
+			protopack.Tag{111, protopack.BytesType}, protopack.String("wrong wire type"),
+		},
+	}, {
+		desc: "packed and unpacked",
+		path: "repeated_int32",
+		b: protopack.Message{
+			protopack.Tag{31, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Varint(2), protopack.Varint(3),
+			},
+			protopack.Tag{31, protopack.VarintType}, protopack.Varint(4),
+			protopack.Tag{31, protopack.Fixed64Type}, protopack.Uint64(5),
+		},
+	}, {
+		desc: "repeated messages",
+		path: "repeated_nested_message.a",
+		b: protopack.Message{
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			},
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{},
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+			},
+		},
+	}, {
+		desc: "repeated messages and repeated scalars",
+		path: "repeated_nested_message.corecursive.repeated_int32",
+		b: protopack.Message{
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{31, protopack.VarintType}, protopack.Varint(1),
+				},
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{31, protopack.VarintType}, protopack.Varint(2),
+				},
+			},
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+					protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{
+						protopack.Varint(3), protopack.Varint(4),
+					},
+				},
+			},
+		},
+	}, {
+		desc: "group",
+		path: "optionalgroup.a",
+		b: protopack.Message{
+			protopack.Tag{16, protopack.StartGroupType},
+			protopack.Tag{17, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{16, protopack.EndGroupType},
+			protopack.Tag{16, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{17, protopack.VarintType}, protopack.Varint(2),
+			},
+		},
+	}, {
+		desc: "repeated group",
+		path: "repeatedgroup.a",
+		b: protopack.Message{
+			protopack.Tag{46, protopack.StartGroupType},
+			protopack.Tag{47, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{46, protopack.EndGroupType},
+			protopack.Tag{46, protopack.StartGroupType},
+			protopack.Tag{47, protopack.VarintType}, protopack.Varint(2),
+			protopack.Tag{46, protopack.EndGroupType},
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			b := test.b.Marshal()
+			m := &testpb.TestAllTypes{}
+			if err := proto.Unmarshal(b, m); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			want := walk(m.ProtoReflect(), strings.Split(test.path, "."))
+
+			p, err := protoscan.Compile(m.ProtoReflect().Descriptor(), test.path)
+			if err != nil {
+				t.Fatalf("Compile(%q) error: %v", test.path, err)
+			}
+			var got []protoreflect.Value
+			if err := p.Range(b, func(v protoreflect.Value) bool {
+				got = append(got, v)
+				return true
+			}); err != nil {
+				t.Fatalf("Range error: %v", err)
+			}
+			if !equalValues(got, want) {
+				t.Errorf("Range(%q) = %v, want %v", test.path, got, want)
+			}
+
+			if len(want) > 1 {
+				var n int
+				if err := p.Range(b, func(v protoreflect.Value) bool {
+					n++
+					return false
+				}); err != nil {
+					t.Fatalf("Range error: %v", err)
+				}
+				if n != 1 {
+					t.Errorf("Range stopped after %v values, want 1", n)
+				}
+			}
+		})
+	}
+}
+
+// walk returns the values of the field named by path in m, as found by
+// unmarshaling and walking the message.
+func walk(m protoreflect.Message, path []string) []protoreflect.Value {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if !m.Has(fd) {
+		return nil
+	}
+	v := m.Get(fd)
+	if len(path) == 1 {
+		if !fd.IsList() {
+			return []protoreflect.Value{v}
+		}
+		var vs []protoreflect.Value
+		for i := 0; i < v.List().Len(); i++ {
+			vs = append(vs, v.List().Get(i))
+		}
+		return vs
+	}
+	if !fd.IsList() {
+		return walk(v.Message(), path[1:])
+	}
+	var vs []protoreflect.Value
+	for i := 0; i < v.List().Len(); i++ {
+		vs = append(vs, walk(v.List().Get(i).Message(), path[1:])...)
+	}
+	return vs
+}
+
+func equalValues(x, y []protoreflect.Value) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if xb, ok := x[i].Interface().([]byte); ok {
+			if yb, ok := y[i].Interface().([]byte); !ok || !bytes.Equal(xb, yb) {
+				return false
+			}
+		} else if x[i].Interface() != y[i].Interface() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGet(t *testing.T) {
+	md := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor()
+	p, err := protoscan.Compile(md, "default_int32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ok, err := p.Get(nil)
+	if err != nil || ok || v.Int() != 81 {
+		t.Errorf("Get(nil) = %v, %v, %v; want 81, false, nil", v, ok, err)
+	}
+	b := protopack.Message{protopack.Tag{81, protopack.VarintType}, protopack.Varint(1)}.Marshal()
+	v, ok, err = p.Get(b)
+	if err != nil || !ok || v.Int() != 1 {
+		t.Errorf("Get(%x) = %v, %v, %v; want 1, true, nil", b, v, ok, err)
+	}
+	if n := testing.AllocsPerRun(100, func() { p.Get(b) }); n > 0 {
+		t.Errorf("Get allocated %v times, want 0", n)
+	}
+
+	p, err = protoscan.Compile(md, "repeated_nested_message.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := p.Get(nil); err == nil {
+		t.Errorf("Get on a repeated path succeeded, want error")
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		md   protoreflect.MessageDescriptor
+		path string
+		b    []byte
+	}{{
+		desc: "truncated tag",
+		md:   (&testpb.TestAllTypes{}).ProtoReflect().Descriptor(),
+		path: "optional_int32",
+		b:    protopack.Message{protopack.Raw{0x80}}.Marshal(),
+	}, {
+		desc: "truncated value",
+		md:   (&testpb.TestAllTypes{}).ProtoReflect().Descriptor(),
+		path: "optional_int32",
+		b: protopack.Message{
+			protopack.Tag{14, protopack.BytesType}, protopack.Raw{0x05, 'a'},
+		}.Marshal(),
+	}, {
+		desc: "truncated nested value",
+		md:   (&testpb.TestAllTypes{}).ProtoReflect().Descriptor(),
+		path: "optional_nested_message.a",
+		b: protopack.Message{
+			protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Raw{0x80},
+			},
+		}.Marshal(),
+	}, {
+		desc: "truncated packed value",
+		md:   (&testpb.TestAllTypes{}).ProtoReflect().Descriptor(),
+		path: "repeated_int32",
+		b: protopack.Message{
+			protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Varint(1), protopack.Raw{0x80},
+			},
+		}.Marshal(),
+	}, {
+		desc: "invalid UTF-8",
+		md:   (&test3pb.TestAllTypes{}).ProtoReflect().Descriptor(),
+		path: "singular_string",
+		b: protopack.Message{
+			protopack.Tag{94, protopack.BytesType}, protopack.String("\xff"),
+		}.Marshal(),
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			p, err := protoscan.Compile(test.md, test.path)
+			if err != nil {
+				t.Fatalf("Compile(%q) error: %v", test.path, err)
+			}
+			if err := p.Range(test.b, func(protoreflect.Value) bool { return true }); err == nil {
+				t.Errorf("Range(%x) succeeded, want error", test.b)
+			}
+			if err := proto.Unmarshal(test.b, dynamicpb.NewMessage(test.md)); err == nil {
+				t.Errorf("Unmarshal(%x) succeeded, want error", test.b)
+			}
+		})
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	md := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor()
+	for _, path := range []string{
+		"",
+		"no_such_field",
+		"optional_nested_message",
+		"optional_int32.a",
+		"map_string_nested_message.a",
+		"map_int32_int32",
+		"optional_nested_message.no_such_field",
+	} {
+		if _, err := protoscan.Compile(md, path); err == nil {
+			t.Errorf("Compile(%q) succeeded, want error", path)
+		}
+	}
+}
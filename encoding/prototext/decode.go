@@ -68,12 +68,12 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 
 	dec := decoder{text.NewDecoder(b), o}
 	if err := dec.unmarshalMessage(m.ProtoReflect(), false); err != nil {
-		return err
+		return errors.AsTextDecodeError(err, text.ErrUnexpectedEOF, len(b), dec.Position)
 	}
 	if o.AllowPartial {
 		return nil
 	}
-	return proto.CheckInitialized(m)
+	return errors.AsDecodeError(errors.DecodeErrorRequiredNotSet, proto.CheckInitialized(m))
 }
 
 type decoder struct {
@@ -81,11 +81,11 @@ type decoder struct {
 	opts UnmarshalOptions
 }
 
-// newError returns an error object with position info.
-func (d decoder) newError(pos int, f string, x ...interface{}) error {
+// newError returns an error object of the given kind with position info.
+func (d decoder) newError(kind errors.DecodeErrorKind, pos int, f string, x ...interface{}) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("(line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return errors.TextDecodeError(kind, pos, line, column, errors.New(head+f, x...))
 }
 
 // unexpectedTokenError returns a syntax error for the given unexpected token.
@@ -97,7 +97,7 @@ func (d decoder) unexpectedTokenError(tok text.Token) error {
 func (d decoder) syntaxError(pos int, f string, x ...interface{}) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("syntax error (line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return errors.TextDecodeError(errors.DecodeErrorSyntax, pos, line, column, errors.New(head+f, x...))
 }
 
 // unmarshalMessage unmarshals into the given protoreflect.Message.
@@ -167,7 +167,7 @@ func (d decoder) unmarshalMessage(m pref.Message, checkDelims bool) error {
 			isFieldNumberName = true
 			num := pref.FieldNumber(tok.FieldNumber())
 			if !num.IsValid() {
-				return d.newError(tok.Pos(), "invalid field number: %d", num)
+				return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid field number: %d", num)
 			}
 			fd = fieldDescs.ByNumber(num)
 			if fd == nil {
@@ -178,10 +178,10 @@ func (d decoder) unmarshalMessage(m pref.Message, checkDelims bool) error {
 		if xt != nil {
 			fd = xt.TypeDescriptor()
 			if !messageDesc.ExtensionRanges().Has(fd.Number()) || fd.ContainingMessage().FullName() != messageDesc.FullName() {
-				return d.newError(tok.Pos(), "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
+				return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
 			}
 		} else if xtErr != nil && xtErr != protoregistry.NotFound {
-			return d.newError(tok.Pos(), "unable to resolve [%s]: %v", tok.RawString(), xtErr)
+			return d.newError(errors.DecodeErrorUnresolvedType, tok.Pos(), "unable to resolve [%s]: %v", tok.RawString(), xtErr)
 		}
 		if flags.ProtoLegacy {
 			if fd != nil && fd.IsWeak() && fd.Message().IsPlaceholder() {
@@ -195,7 +195,7 @@ func (d decoder) unmarshalMessage(m pref.Message, checkDelims bool) error {
 				d.skipValue()
 				continue
 			}
-			return d.newError(tok.Pos(), "unknown field: %v", tok.RawString())
+			return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "unknown field: %v", tok.RawString())
 		}
 
 		// Handle fields identified by field number.
@@ -207,7 +207,7 @@ func (d decoder) unmarshalMessage(m pref.Message, checkDelims bool) error {
 			// best-effort textual representation of the field value.  In that case,
 			// it may not be possible to unmarshal the value from a parser that does
 			// have information about the unknown field.
-			return d.newError(tok.Pos(), "cannot specify field by number: %v", tok.RawString())
+			return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "cannot specify field by number: %v", tok.RawString())
 		}
 
 		switch {
@@ -219,13 +219,13 @@ func (d decoder) unmarshalMessage(m pref.Message, checkDelims bool) error {
 
 			list := m.Mutable(fd).List()
 			if err := d.unmarshalList(fd, list); err != nil {
-				return err
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 
 		case fd.IsMap():
 			mmap := m.Mutable(fd).Map()
 			if err := d.unmarshalMap(fd, mmap); err != nil {
-				return err
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 
 		default:
@@ -238,18 +238,18 @@ func (d decoder) unmarshalMessage(m pref.Message, checkDelims bool) error {
 			if od := fd.ContainingOneof(); od != nil {
 				idx := uint64(od.Index())
 				if seenOneofs.Has(idx) {
-					return d.newError(tok.Pos(), "error parsing %q, oneof %v is already set", tok.RawString(), od.FullName())
+					return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "error parsing %q, oneof %v is already set", tok.RawString(), od.FullName())
 				}
 				seenOneofs.Set(idx)
 			}
 
 			num := uint64(fd.Number())
 			if seenNums.Has(num) {
-				return d.newError(tok.Pos(), "non-repeated field %q is repeated", tok.RawString())
+				return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "non-repeated field %q is repeated", tok.RawString())
 			}
 
 			if err := d.unmarshalSingular(fd, m); err != nil {
-				return err
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 			seenNums.Set(num)
 		}
@@ -328,7 +328,7 @@ func (d decoder) unmarshalScalar(fd pref.FieldDescriptor) (pref.Value, error) {
 	case pref.StringKind:
 		if s, ok := tok.String(); ok {
 			if strs.EnforceUTF8(fd) && !utf8.ValidString(s) {
-				return pref.Value{}, d.newError(tok.Pos(), "contains invalid UTF-8")
+				return pref.Value{}, d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "contains invalid UTF-8")
 			}
 			return pref.ValueOfString(s), nil
 		}
@@ -353,7 +353,7 @@ func (d decoder) unmarshalScalar(fd pref.FieldDescriptor) (pref.Value, error) {
 		panic(fmt.Sprintf("invalid scalar kind %v", kind))
 	}
 
-	return pref.Value{}, d.newError(tok.Pos(), "invalid value for %v type: %v", kind, tok.RawString())
+	return pref.Value{}, d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid value for %v type: %v", kind, tok.RawString())
 }
 
 // unmarshalList unmarshals into given protoreflect.List. A list value can
@@ -382,7 +382,7 @@ func (d decoder) unmarshalList(fd pref.FieldDescriptor, list pref.List) error {
 				case text.MessageOpen:
 					pval := list.NewElement()
 					if err := d.unmarshalMessage(pval.Message(), true); err != nil {
-						return err
+						return errors.AddIndexPath(err, list.Len())
 					}
 					list.Append(pval)
 				default:
@@ -393,7 +393,7 @@ func (d decoder) unmarshalList(fd pref.FieldDescriptor, list pref.List) error {
 		case text.MessageOpen:
 			pval := list.NewElement()
 			if err := d.unmarshalMessage(pval.Message(), true); err != nil {
-				return err
+				return errors.AddIndexPath(err, list.Len())
 			}
 			list.Append(pval)
 			return nil
@@ -416,7 +416,7 @@ func (d decoder) unmarshalList(fd pref.FieldDescriptor, list pref.List) error {
 				case text.Scalar:
 					pval, err := d.unmarshalScalar(fd)
 					if err != nil {
-						return err
+						return errors.AddIndexPath(err, list.Len())
 					}
 					list.Append(pval)
 				default:
@@ -427,7 +427,7 @@ func (d decoder) unmarshalList(fd pref.FieldDescriptor, list pref.List) error {
 		case text.Scalar:
 			pval, err := d.unmarshalScalar(fd)
 			if err != nil {
-				return err
+				return errors.AddIndexPath(err, list.Len())
 			}
 			list.Append(pval)
 			return nil
@@ -506,7 +506,7 @@ Loop:
 		case text.Name:
 			if tok.NameKind() != text.IdentName {
 				if !d.opts.DiscardUnknown {
-					return d.newError(tok.Pos(), "unknown map entry field %q", tok.RawString())
+					return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "unknown map entry field %q", tok.RawString())
 				}
 				d.skipValue()
 				continue Loop
@@ -524,7 +524,7 @@ Loop:
 				return d.syntaxError(tok.Pos(), "missing field separator :")
 			}
 			if key.IsValid() {
-				return d.newError(tok.Pos(), "map entry %q cannot be repeated", name)
+				return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "map entry %q cannot be repeated", name)
 			}
 			val, err := d.unmarshalScalar(fd.MapKey())
 			if err != nil {
@@ -539,16 +539,19 @@ Loop:
 				}
 			}
 			if pval.IsValid() {
-				return d.newError(tok.Pos(), "map entry %q cannot be repeated", name)
+				return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "map entry %q cannot be repeated", name)
 			}
 			pval, err = unmarshalMapValue()
 			if err != nil {
+				if key.IsValid() {
+					return errors.AddMapKeyPath(err, key.Interface())
+				}
 				return err
 			}
 
 		default:
 			if !d.opts.DiscardUnknown {
-				return d.newError(tok.Pos(), "unknown map entry field %q", name)
+				return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "unknown map entry field %q", name)
 			}
 			d.skipValue()
 		}
@@ -620,10 +623,10 @@ Loop:
 			switch name := pref.Name(tok.IdentName()); name {
 			case genid.Any_TypeUrl_field_name:
 				if seenTypeUrl {
-					return d.newError(tok.Pos(), "duplicate %v field", genid.Any_TypeUrl_field_fullname)
+					return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "duplicate %v field", genid.Any_TypeUrl_field_fullname)
 				}
 				if isExpanded {
-					return d.newError(tok.Pos(), "conflict with [%s] field", typeURL)
+					return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "conflict with [%s] field", typeURL)
 				}
 				tok, err := d.Read()
 				if err != nil {
@@ -632,16 +635,16 @@ Loop:
 				var ok bool
 				typeURL, ok = tok.String()
 				if !ok {
-					return d.newError(tok.Pos(), "invalid %v field value: %v", genid.Any_TypeUrl_field_fullname, tok.RawString())
+					return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid %v field value: %v", genid.Any_TypeUrl_field_fullname, tok.RawString())
 				}
 				seenTypeUrl = true
 
 			case genid.Any_Value_field_name:
 				if seenValue {
-					return d.newError(tok.Pos(), "duplicate %v field", genid.Any_Value_field_fullname)
+					return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "duplicate %v field", genid.Any_Value_field_fullname)
 				}
 				if isExpanded {
-					return d.newError(tok.Pos(), "conflict with [%s] field", typeURL)
+					return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "conflict with [%s] field", typeURL)
 				}
 				tok, err := d.Read()
 				if err != nil {
@@ -649,23 +652,23 @@ Loop:
 				}
 				s, ok := tok.String()
 				if !ok {
-					return d.newError(tok.Pos(), "invalid %v field value: %v", genid.Any_Value_field_fullname, tok.RawString())
+					return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "invalid %v field value: %v", genid.Any_Value_field_fullname, tok.RawString())
 				}
 				bValue = []byte(s)
 				seenValue = true
 
 			default:
 				if !d.opts.DiscardUnknown {
-					return d.newError(tok.Pos(), "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
+					return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
 				}
 			}
 
 		case text.TypeName:
 			if isExpanded {
-				return d.newError(tok.Pos(), "cannot have more than one type")
+				return d.newError(errors.DecodeErrorDuplicateField, tok.Pos(), "cannot have more than one type")
 			}
 			if seenTypeUrl {
-				return d.newError(tok.Pos(), "conflict with type_url field")
+				return d.newError(errors.DecodeErrorInvalidValue, tok.Pos(), "conflict with type_url field")
 			}
 			typeURL = tok.TypeName()
 			var err error
@@ -677,7 +680,7 @@ Loop:
 
 		default:
 			if !d.opts.DiscardUnknown {
-				return d.newError(tok.Pos(), "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
+				return d.newError(errors.DecodeErrorUnknownField, tok.Pos(), "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
 			}
 		}
 	}
@@ -695,7 +698,7 @@ Loop:
 func (d decoder) unmarshalExpandedAny(typeURL string, pos int) ([]byte, error) {
 	mt, err := d.opts.Resolver.FindMessageByURL(typeURL)
 	if err != nil {
-		return nil, d.newError(pos, "unable to resolve message [%v]: %v", typeURL, err)
+		return nil, d.newError(errors.DecodeErrorUnresolvedType, pos, "unable to resolve message [%v]: %v", typeURL, err)
 	}
 	// Create new message for the embedded message type and unmarshal the value
 	// field into it.
@@ -709,7 +712,7 @@ func (d decoder) unmarshalExpandedAny(typeURL string, pos int) ([]byte, error) {
 		Deterministic: true,
 	}.Marshal(m.Interface())
 	if err != nil {
-		return nil, d.newError(pos, "error in marshaling message into Any.value: %v", err)
+		return nil, d.newError(errors.DecodeErrorOther, pos, "error in marshaling message into Any.value: %v", err)
 	}
 	return b, nil
 }
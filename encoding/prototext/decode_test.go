@@ -1687,3 +1687,76 @@ type_url: "pb2.Nested"
 		})
 	}
 }
+
+func TestUnmarshalErrorPath(t *testing.T) {
+	tests := []struct {
+		desc         string
+		inputMessage proto.Message
+		inputText    string
+		wantKind     proto.DecodeErrorKind
+		wantPath     string
+		wantLine     int
+		wantColumn   int
+	}{{
+		desc:         "invalid value in nested message",
+		inputMessage: &pb3.Nests{},
+		inputText: `s_nested {
+  s_nested { s_string: 1 }
+}`,
+		wantKind:   proto.DecodeErrorInvalidValue,
+		wantPath:   "s_nested.s_nested.s_string",
+		wantLine:   2,
+		wantColumn: 24,
+	}, {
+		desc:         "invalid list element",
+		inputMessage: &pb3.Repeats{},
+		inputText:    `rpt_int32: [1, 2, "x"]`,
+		wantKind:     proto.DecodeErrorInvalidValue,
+		wantPath:     "rpt_int32[2]",
+		wantLine:     1,
+		wantColumn:   19,
+	}, {
+		desc:         "unknown field in map value",
+		inputMessage: &pb3.Maps{},
+		inputText:    `str_to_nested { key: "k" value { unknown: 1 } }`,
+		wantKind:     proto.DecodeErrorUnknownField,
+		wantPath:     `str_to_nested["k"]`,
+		wantLine:     1,
+		wantColumn:   34,
+	}, {
+		desc:         "repeated non-repeated field",
+		inputMessage: &pb3.Scalars{},
+		inputText:    `s_int32: 1 s_int32: 2`,
+		wantKind:     proto.DecodeErrorDuplicateField,
+		wantLine:     1,
+		wantColumn:   12,
+	}, {
+		desc:         "unexpected EOF",
+		inputMessage: &pb3.Nests{},
+		inputText:    `s_nested {`,
+		wantKind:     proto.DecodeErrorSyntax,
+		wantPath:     "s_nested",
+		wantLine:     1,
+		wantColumn:   11,
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			err := prototext.Unmarshal([]byte(tt.inputText), tt.inputMessage)
+			derr, ok := err.(*proto.DecodeError)
+			if !ok {
+				t.Fatalf("Unmarshal() error = %v (%T), want *proto.DecodeError", err, err)
+			}
+			if derr.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", derr.Kind, tt.wantKind)
+			}
+			if derr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", derr.Path, tt.wantPath)
+			}
+			if derr.Line != tt.wantLine || derr.Column != tt.wantColumn {
+				t.Errorf("position = %d:%d, want %d:%d", derr.Line, derr.Column, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
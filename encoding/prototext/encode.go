@@ -17,6 +17,7 @@ import (
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/order"
 	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/internal/strs"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -75,6 +76,17 @@ type MarshalOptions struct {
 	// The default is to exclude unknown fields.
 	EmitUnknown bool
 
+	// Redact specifies whether to replace the values of sensitive fields
+	// with the placeholder "[REDACTED]", in which case the unmarshaler is
+	// unable to parse the output. A field is sensitive if it has the
+	// debug_redact field option set or if RedactField reports true for it.
+	// Format always redacts sensitive fields.
+	Redact bool
+
+	// RedactField reports whether a field is sensitive in addition to
+	// fields with the debug_redact option. It is only used if Redact is set.
+	RedactField func(protoreflect.FieldDescriptor) bool
+
 	// Resolver is used for looking up types when expanding google.protobuf.Any
 	// messages. If nil, this defaults to using protoregistry.GlobalTypes.
 	Resolver interface {
@@ -94,6 +106,7 @@ func (o MarshalOptions) Format(m proto.Message) string {
 	o.allowInvalidUTF8 = true
 	o.AllowPartial = true
 	o.EmitUnknown = true
+	o.Redact = true
 	b, _ := o.Marshal(m)
 	return string(b)
 }
@@ -192,6 +205,10 @@ func (e encoder) marshalMessage(m pref.Message, inclDelims bool) error {
 // marshalField marshals the given field with protoreflect.Value.
 func (e encoder) marshalField(name string, val pref.Value, fd pref.FieldDescriptor) error {
 	switch {
+	case e.opts.Redact && redact.IsRedacted(fd, e.opts.RedactField):
+		e.WriteName(name)
+		e.WriteLiteral(redact.Placeholder)
+		return nil
 	case fd.IsList():
 		return e.marshalList(name, val.List(), fd)
 	case fd.IsMap():
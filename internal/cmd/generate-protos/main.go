@@ -87,10 +87,15 @@ func init() {
 		// This is reasonable since we fully control the output.
 		detrand.Disable()
 
-		protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		var flags flag.FlagSet
+		genCodec := flags.Bool("generate_codec", false, "")
+		protogen.Options{
+			ParamFunc: flags.Set,
+		}.Run(func(gen *protogen.Plugin) error {
 			for _, file := range gen.Files {
 				if file.Generate {
 					gengo.GenerateVersionMarkers = false
+					gengo.GenerateCodec = *genCodec
 					gengo.GenerateFile(gen, file)
 					generateIdentifiers(gen, file)
 					generateSouceContextStringer(gen, file)
@@ -140,12 +145,16 @@ func generateLocalProtos() {
 	dirs := []struct {
 		path        string
 		annotateFor map[string]bool
+		codecFor    map[string]bool
 		exclude     map[string]bool
 	}{
 		{path: "cmd/protoc-gen-go/testdata", annotateFor: map[string]bool{
 			"cmd/protoc-gen-go/testdata/annotations/annotations.proto": true},
 		},
-		{path: "internal/testprotos", exclude: map[string]bool{
+		{path: "internal/testprotos", codecFor: map[string]bool{
+			"internal/testprotos/codec/test.proto":  true,
+			"internal/testprotos/codec3/test.proto": true,
+		}, exclude: map[string]bool{
 			"internal/testprotos/irregular/irregular.proto": true,
 		}},
 	}
@@ -180,6 +189,11 @@ func generateLocalProtos() {
 				opts += ",annotate_code"
 			}
 
+			// Generate specialized codec functions for certain files.
+			if d.codecFor[filepath.ToSlash(relPath)] {
+				opts += ",generate_codec=true"
+			}
+
 			protoc("-I"+filepath.Join(protoRoot, "src"), "-I"+repoRoot, "--go_out="+opts+":"+dstDir, relPath)
 			return nil
 		})
@@ -114,7 +114,15 @@ func consume{{.Name}}(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	if n < 0 {
 		return out, errDecode
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeAlias}}
+	} else {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoType}}
+	}
+	{{- else -}}
 	*p.{{.GoType.PointerMethod}}() = {{.ToGoType}}
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -124,6 +132,7 @@ var coder{{.Name}} = pointerCoderFuncs{
 	marshal:   append{{.Name}},
 	unmarshal: consume{{.Name}},
 	merge:     merge{{.GoType.PointerMethod}},
+	equal:     equal{{.GoType.PointerMethod}},
 }
 
 {{if or (eq .Name "Bytes") (eq .Name "String")}}
@@ -150,7 +159,15 @@ func consume{{.Name}}ValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *c
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeAlias}}
+	} else {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoType}}
+	}
+	{{- else -}}
 	*p.{{.GoType.PointerMethod}}() = {{.ToGoType}}
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -160,6 +177,7 @@ var coder{{.Name}}ValidateUTF8 = pointerCoderFuncs{
 	marshal:   append{{.Name}}ValidateUTF8,
 	unmarshal: consume{{.Name}}ValidateUTF8,
 	merge:     merge{{.GoType.PointerMethod}},
+	equal:     equal{{.GoType.PointerMethod}},
 }
 {{end}}
 
@@ -196,7 +214,15 @@ func consume{{.Name}}NoZero(b []byte, p pointer, wtyp protowire.Type, f *coderFi
 	if n < 0 {
 		return out, errDecode
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeAlias}}
+	} else {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeNoZero}}
+	}
+	{{- else -}}
 	*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeNoZero}}
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -207,6 +233,7 @@ var coder{{.Name}}NoZero = pointerCoderFuncs{
 	marshal:   append{{.Name}}NoZero,
 	unmarshal: consume{{.Name}}{{if .ToGoTypeNoZero}}NoZero{{end}},
 	merge:     merge{{.GoType.PointerMethod}}NoZero,
+	equal:     equal{{.GoType.PointerMethod}}NoZero,
 }
 
 {{if or (eq .Name "Bytes") (eq .Name "String")}}
@@ -238,7 +265,15 @@ func consume{{.Name}}NoZeroValidateUTF8(b []byte, p pointer, wtyp protowire.Type
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeAlias}}
+	} else {
+		*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeNoZero}}
+	}
+	{{- else -}}
 	*p.{{.GoType.PointerMethod}}() = {{.ToGoTypeNoZero}}
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -249,6 +284,7 @@ var coder{{.Name}}NoZeroValidateUTF8 = pointerCoderFuncs{
 	marshal:   append{{.Name}}NoZeroValidateUTF8,
 	unmarshal: consume{{.Name}}{{if .ToGoTypeNoZero}}NoZero{{end}}ValidateUTF8,
 	merge:     merge{{.GoType.PointerMethod}}NoZero,
+	equal:     equal{{.GoType.PointerMethod}}NoZero,
 }
 {{end}}
 
@@ -284,7 +320,15 @@ func consume{{.Name}}Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderField
 	if *vp == nil {
 		*vp = new({{.GoType}})
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		**vp = {{.ToGoTypeAlias}}
+	} else {
+		**vp = {{.ToGoType}}
+	}
+	{{- else -}}
 	**vp = {{.ToGoType}}
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -294,6 +338,7 @@ var coder{{.Name}}Ptr = pointerCoderFuncs{
 	marshal:   append{{.Name}}Ptr,
 	unmarshal: consume{{.Name}}Ptr,
 	merge:     merge{{.GoType.PointerMethod}}Ptr,
+	equal:     equal{{.GoType.PointerMethod}}Ptr,
 }
 {{end}}
 
@@ -326,7 +371,15 @@ func consume{{.Name}}PtrValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f
 	if *vp == nil {
 		*vp = new({{.GoType}})
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		**vp = {{.ToGoTypeAlias}}
+	} else {
+		**vp = {{.ToGoType}}
+	}
+	{{- else -}}
 	**vp = {{.ToGoType}}
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -336,6 +389,7 @@ var coder{{.Name}}PtrValidateUTF8 = pointerCoderFuncs{
 	marshal:   append{{.Name}}PtrValidateUTF8,
 	unmarshal: consume{{.Name}}PtrValidateUTF8,
 	merge:     merge{{.GoType.PointerMethod}}Ptr,
+	equal:     equal{{.GoType.PointerMethod}}Ptr,
 }
 {{end}}
 
@@ -377,7 +431,15 @@ func consume{{.Name}}Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFie
 			if n < 0 {
 				return out, errDecode
 			}
+			{{if .ToGoTypeAlias -}}
+			if opts.AliasBuffer() {
+				s = append(s, {{.ToGoTypeAlias}})
+			} else {
+				s = append(s, {{.ToGoType}})
+			}
+			{{- else -}}
 			s = append(s, {{.ToGoType}})
+			{{- end}}
 			b = b[n:]
 		}
 		*sp = s
@@ -392,7 +454,15 @@ func consume{{.Name}}Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFie
 	if n < 0 {
 		return out, errDecode
 	}
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		*sp = append(*sp, {{.ToGoTypeAlias}})
+	} else {
+		*sp = append(*sp, {{.ToGoType}})
+	}
+	{{- else -}}
 	*sp = append(*sp, {{.ToGoType}})
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -402,6 +472,7 @@ var coder{{.Name}}Slice = pointerCoderFuncs{
 	marshal:   append{{.Name}}Slice,
 	unmarshal: consume{{.Name}}Slice,
 	merge:     merge{{.GoType.PointerMethod}}Slice,
+	equal:     equal{{.GoType.PointerMethod}}Slice,
 }
 
 {{if or (eq .Name "Bytes") (eq .Name "String")}}
@@ -431,7 +502,15 @@ func consume{{.Name}}SliceValidateUTF8(b []byte, p pointer, wtyp protowire.Type,
 		return out, errInvalidUTF8{}
 	}
 	sp := p.{{.GoType.PointerMethod}}Slice()
+	{{if .ToGoTypeAlias -}}
+	if opts.AliasBuffer() {
+		*sp = append(*sp, {{.ToGoTypeAlias}})
+	} else {
+		*sp = append(*sp, {{.ToGoType}})
+	}
+	{{- else -}}
 	*sp = append(*sp, {{.ToGoType}})
+	{{- end}}
 	out.n = n
 	return out, nil
 }
@@ -441,6 +520,7 @@ var coder{{.Name}}SliceValidateUTF8 = pointerCoderFuncs{
 	marshal:   append{{.Name}}SliceValidateUTF8,
 	unmarshal: consume{{.Name}}SliceValidateUTF8,
 	merge:     merge{{.GoType.PointerMethod}}Slice,
+	equal:     equal{{.GoType.PointerMethod}}Slice,
 }
 {{end}}
 
@@ -489,6 +569,7 @@ var coder{{.Name}}PackedSlice = pointerCoderFuncs{
 	marshal:   append{{.Name}}PackedSlice,
 	unmarshal: consume{{.Name}}Slice,
 	merge:     merge{{.GoType.PointerMethod}}Slice,
+	equal:     equal{{.GoType.PointerMethod}}Slice,
 }
 {{end}}
 
@@ -517,6 +598,11 @@ func consume{{.Name}}Value(b []byte, _ protoreflect.Value, _ protowire.Number, w
 		return protoreflect.Value{}, out, errDecode
 	}
 	out.n = n
+	{{if .ToValueAlias -}}
+	if opts.AliasBuffer() {
+		return {{.ToValueAlias}}, out, nil
+	}
+	{{end -}}
 	return {{.ToValue}}, out, nil
 }
 
@@ -555,6 +641,11 @@ func consume{{.Name}}ValueValidateUTF8(b []byte, _ protoreflect.Value, _ protowi
 		return protoreflect.Value{}, out, errInvalidUTF8{}
 	}
 	out.n = n
+	{{if .ToValueAlias -}}
+	if opts.AliasBuffer() {
+		return {{.ToValueAlias}}, out, nil
+	}
+	{{end -}}
 	return {{.ToValue}}, out, nil
 }
 
@@ -605,7 +696,15 @@ func consume{{.Name}}SliceValue(b []byte, listv protoreflect.Value, _ protowire.
 			if n < 0 {
 				return protoreflect.Value{}, out, errDecode
 			}
+			{{if .ToValueAlias -}}
+			if opts.AliasBuffer() {
+				list.Append({{.ToValueAlias}})
+			} else {
+				list.Append({{.ToValue}})
+			}
+			{{- else -}}
 			list.Append({{.ToValue}})
+			{{- end}}
 			b = b[n:]
 		}
 		out.n = n
@@ -619,7 +718,15 @@ func consume{{.Name}}SliceValue(b []byte, listv protoreflect.Value, _ protowire.
 	if n < 0 {
 		return protoreflect.Value{}, out, errDecode
 	}
+	{{if .ToValueAlias -}}
+	if opts.AliasBuffer() {
+		list.Append({{.ToValueAlias}})
+	} else {
+		list.Append({{.ToValue}})
+	}
+	{{- else -}}
 	list.Append({{.ToValue}})
+	{{- end}}
 	out.n = n
 	return listv, out, nil
 }
@@ -732,6 +839,9 @@ func (m *{{.}}) protoUnwrap() interface{} {
 }
 func (m *{{.}}) ProtoMethods() *protoiface.Methods {
 	m.messageInfo().init()
+	if m.isFrozen() {
+		return &m.messageInfo().frozenMethods
+	}
 	return &m.messageInfo().methods
 }
 
@@ -746,6 +856,12 @@ func (m *{{.}}) ProtoMessageInfo() *MessageInfo {
 
 func (m *{{.}}) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
 	m.messageInfo().init()
+	if m.isFrozen() {
+		rangeFunc := f
+		f = func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			return rangeFunc(fd, frozenValue(v))
+		}
+	}
 	for _, ri := range m.messageInfo().rangeInfos {
 		switch ri := ri.(type) {
 		case *fieldInfo:
@@ -775,6 +891,7 @@ func (m *{{.}}) Has(fd protoreflect.FieldDescriptor) bool {
 }
 func (m *{{.}}) Clear(fd protoreflect.FieldDescriptor) {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		fi.clear(m.pointer())
 	} else {
@@ -784,13 +901,14 @@ func (m *{{.}}) Clear(fd protoreflect.FieldDescriptor) {
 func (m *{{.}}) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
 	m.messageInfo().init()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
-		return fi.get(m.pointer())
+		return m.readOnly(fi.get(m.pointer()))
 	} else {
-		return m.messageInfo().extensionMap(m.pointer()).Get(xt)
+		return m.readOnly(m.messageInfo().extensionMap(m.pointer()).Get(xt))
 	}
 }
 func (m *{{.}}) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		fi.set(m.pointer(), v)
 	} else {
@@ -799,6 +917,7 @@ func (m *{{.}}) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
 }
 func (m *{{.}}) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		return fi.mutable(m.pointer())
 	} else {
@@ -826,6 +945,7 @@ func (m *{{.}}) GetUnknown() protoreflect.RawFields {
 }
 func (m *{{.}}) SetUnknown(b protoreflect.RawFields) {
 	m.messageInfo().init()
+	m.checkMutable()
 	m.messageInfo().setUnknown(m.pointer(), b)
 }
 func (m *{{.}}) IsValid() bool {
@@ -848,7 +968,11 @@ func merge{{.PointerMethod}}(dst, src pointer, _ *coderFieldInfo, _ mergeOptions
 
 func merge{{.PointerMethod}}NoZero(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	v := *src.{{.PointerMethod}}()
+	{{if or (eq . "float32") (eq . "float64") -}}
+	if v != {{.Zero}} || math.Signbit(float64(v)) {
+	{{- else -}}
 	if v != {{.Zero}} {
+	{{- end}}
 		*dst.{{.PointerMethod}}() = v
 	}
 }
@@ -870,3 +994,65 @@ func merge{{.PointerMethod}}Slice(dst, src pointer, _ *coderFieldInfo, _ mergeOp
 {{end}}
 {{end}}
 `))
+
+func generateImplEqual() string {
+	return mustExecute(implEqualTemplate, GoTypes)
+}
+
+var implEqualTemplate = template.Must(template.New("").Parse(`
+{{range .}}
+{{if ne . "[]byte"}}
+func equal{{.PointerMethod}}(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.{{.PointerMethod}}(), *y.{{.PointerMethod}}()
+	{{if or (eq . "float32") (eq . "float64") -}}
+	return vx == vy || (math.IsNaN(float64(vx)) && math.IsNaN(float64(vy)))
+	{{- else -}}
+	return vx == vy
+	{{- end}}
+}
+
+func equal{{.PointerMethod}}NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.{{.PointerMethod}}(), *y.{{.PointerMethod}}()
+	{{if or (eq . "float32") (eq . "float64") -}}
+	if vx == vy {
+		// Negative zero is populated, while positive zero is not.
+		return math.Signbit(float64(vx)) == math.Signbit(float64(vy))
+	}
+	return math.IsNaN(float64(vx)) && math.IsNaN(float64(vy))
+	{{- else -}}
+	return vx == vy
+	{{- end}}
+}
+
+func equal{{.PointerMethod}}Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.{{.PointerMethod}}Ptr(), *y.{{.PointerMethod}}Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	{{if or (eq . "float32") (eq . "float64") -}}
+	return *px == *py || (math.IsNaN(float64(*px)) && math.IsNaN(float64(*py)))
+	{{- else -}}
+	return *px == *py
+	{{- end}}
+}
+
+func equal{{.PointerMethod}}Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.{{.PointerMethod}}Slice(), *y.{{.PointerMethod}}Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		{{if or (eq . "float32") (eq . "float64") -}}
+		if vy := sy[i]; vx != vy && !(math.IsNaN(float64(vx)) && math.IsNaN(float64(vy))) {
+		{{- else -}}
+		if vx != sy[i] {
+		{{- end}}
+			return false
+		}
+	}
+	return true
+}
+
+{{end}}
+{{end}}
+`))
@@ -45,6 +45,7 @@ func main() {
 	writeSource("internal/impl/codec_gen.go", generateImplCodec())
 	writeSource("internal/impl/message_reflect_gen.go", generateImplMessage())
 	writeSource("internal/impl/merge_gen.go", generateImplMerge())
+	writeSource("internal/impl/equal_gen.go", generateImplEqual())
 	writeSource("proto/decode_gen.go", generateProtoDecode())
 	writeSource("proto/encode_gen.go", generateProtoEncode())
 	writeSource("proto/size_gen.go", generateProtoSize())
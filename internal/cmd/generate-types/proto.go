@@ -103,6 +103,12 @@ type ProtoKind struct {
 	FromGoType     Expr
 	NoPointer      bool
 	NoValueCodec   bool
+
+	// Conversions which alias the input buffer rather than copying it,
+	// used when the unmarshaler is permitted to alias the input.
+	// They are empty for kinds which never copy the input.
+	ToValueAlias  Expr
+	ToGoTypeAlias Expr
 }
 
 func (k ProtoKind) Expr() Expr {
@@ -241,6 +247,9 @@ var ProtoKinds = []ProtoKind{
 		GoType:     GoString,
 		ToGoType:   "string(v)",
 		FromGoType: "v",
+
+		ToValueAlias:  "protoreflect.ValueOfString(strs.UnsafeString(v))",
+		ToGoTypeAlias: "strs.UnsafeString(v)",
 	},
 	{
 		Name:           "Bytes",
@@ -252,6 +261,9 @@ var ProtoKinds = []ProtoKind{
 		ToGoTypeNoZero: "append(([]byte)(nil), v...)",
 		FromGoType:     "v",
 		NoPointer:      true,
+
+		ToValueAlias:  "protoreflect.ValueOfBytes(v[:len(v):len(v)])",
+		ToGoTypeAlias: "v[:len(v):len(v)]",
 	},
 	{
 		Name:         "Message",
@@ -297,6 +309,11 @@ func (o UnmarshalOptions) unmarshalScalar(b []byte, wtyp protowire.Type, fd prot
 			return protoreflect.Value{}, 0, errors.InvalidUTF8(string(fd.FullName()))
 		}
 		{{end -}}
+		{{if .ToValueAlias -}}
+		if o.AliasBuffer {
+			return {{.ToValueAlias}}, n, nil
+		}
+		{{end -}}
 		return {{.ToValue}}, n, nil
 	{{- end}}
 	default:
@@ -347,6 +364,12 @@ func (o UnmarshalOptions) unmarshalList(b []byte, wtyp protowire.Type, list prot
 			return 0, err
 		}
 		list.Append(m)
+		{{- else if .ToValueAlias -}}
+		if o.AliasBuffer {
+			list.Append({{.ToValueAlias}})
+		} else {
+			list.Append({{.ToValue}})
+		}
 		{{- else -}}
 		list.Append({{.ToValue}})
 		{{- end}}
@@ -382,14 +405,11 @@ func (o MarshalOptions) marshalSingular(b []byte, fd protoreflect.FieldDescripto
 		}
 		b = protowire.AppendString(b, {{.FromValue}})
 		{{- else if (eq .Name "Message") -}}
-		var pos int
 		var err error
-		b, pos = appendSpeculativeLength(b)
-		b, err = o.marshalMessage(b, v.Message())
+		b, err = o.marshalSubmessage(b, v.Message())
 		if err != nil {
 			return b, err
 		}
-		b = finishSpeculativeLength(b, pos)
 		{{- else if (eq .Name "Group") -}}
 		var err error
 		b, err = o.marshalMessage(b, v.Message())
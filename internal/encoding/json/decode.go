@@ -217,7 +217,8 @@ func (d *Decoder) parseNext() (Token, error) {
 func (d *Decoder) newSyntaxError(pos int, f string, x ...interface{}) error {
 	e := errors.New(f, x...)
 	line, column := d.Position(pos)
-	return errors.New("syntax error (line %d:%d): %v", line, column, e)
+	e = errors.New("syntax error (line %d:%d): %v", line, column, e)
+	return errors.TextDecodeError(errors.DecodeErrorSyntax, pos, line, column, e)
 }
 
 // Position returns line and column number of given index of the original input.
@@ -603,8 +603,10 @@ func (d *Decoder) consumeToken(kind Kind, size int, attrs uint8) Token {
 // current position.
 func (d *Decoder) newSyntaxError(f string, x ...interface{}) error {
 	e := errors.New(f, x...)
-	line, column := d.Position(len(d.orig) - len(d.in))
-	return errors.New("syntax error (line %d:%d): %v", line, column, e)
+	pos := len(d.orig) - len(d.in)
+	line, column := d.Position(pos)
+	e = errors.New("syntax error (line %d:%d): %v", line, column, e)
+	return errors.TextDecodeError(errors.DecodeErrorSyntax, pos, line, column, e)
 }
 
 // Position returns line and column number of given index of the original input.
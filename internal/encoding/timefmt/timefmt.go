@@ -0,0 +1,339 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timefmt parses and formats the textual forms of
+// google.protobuf.Timestamp and google.protobuf.Duration values.
+//
+// Values are represented as a pair of seconds and nanoseconds, as in the
+// messages themselves. The parse functions do not check that the seconds
+// are within the range permitted for the message type.
+package timefmt
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxDurationSeconds is the magnitude of the largest Duration.seconds value,
+	// which is approximately 10,000 years.
+	MaxDurationSeconds = 315576000000
+
+	// MinTimestampSeconds and MaxTimestampSeconds are the range of valid
+	// Timestamp.seconds values: 0001-01-01T00:00:00Z to 9999-12-31T23:59:59Z.
+	MinTimestampSeconds = -62135596800
+	MaxTimestampSeconds = 253402300799
+)
+
+// FormatDuration formats a Duration as a decimal number of seconds with the
+// suffix "s", where the fractional part has 0, 3, 6, or 9 digits depending on
+// the required precision (e.g., "-1.5s"). The seconds and nanos must not have
+// opposing signs.
+func FormatDuration(secs int64, nanos int32) string {
+	var sign string
+	s, n := uint64(secs), int64(nanos)
+	if secs < 0 || n < 0 {
+		// The unsigned negation is also correct for math.MinInt64.
+		sign, s, n = "-", uint64(-secs), -n
+	}
+	return sign + trimNanos(fmt.Sprintf("%d.%09d", s, n)) + "s"
+}
+
+// trimNanos trims a 9-digit fractional part to 0, 3, or 6 digits
+// if the trailing digits are zero.
+func trimNanos(s string) string {
+	s = strings.TrimSuffix(s, "000")
+	s = strings.TrimSuffix(s, "000")
+	s = strings.TrimSuffix(s, ".000")
+	return s
+}
+
+// ParseDuration parses the given input string for seconds and nanoseconds value
+// for the Duration JSON format. The format is a decimal number with a suffix
+// 's'. It can have optional plus/minus sign. There needs to be at least an
+// integer or fractional part. Fractional part is limited to 9 digits only for
+// nanoseconds precision, regardless of whether there are trailing zero digits.
+// Example values are 1s, 0.1s, 1.s, .1s, +1s, -1s, -.1s.
+func ParseDuration(input string) (int64, int32, bool) {
+	b := []byte(input)
+	size := len(b)
+	if size < 2 {
+		return 0, 0, false
+	}
+	if b[size-1] != 's' {
+		return 0, 0, false
+	}
+	b = b[:size-1]
+
+	// Read optional plus/minus symbol.
+	var neg bool
+	switch b[0] {
+	case '-':
+		neg = true
+		b = b[1:]
+	case '+':
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return 0, 0, false
+	}
+
+	// Read the integer part.
+	var intp []byte
+	switch {
+	case b[0] == '0':
+		b = b[1:]
+
+	case '1' <= b[0] && b[0] <= '9':
+		intp = b[0:]
+		b = b[1:]
+		n := 1
+		for len(b) > 0 && '0' <= b[0] && b[0] <= '9' {
+			n++
+			b = b[1:]
+		}
+		intp = intp[:n]
+
+	case b[0] == '.':
+		// Continue below.
+
+	default:
+		return 0, 0, false
+	}
+
+	hasFrac := false
+	var frac [9]byte
+	if len(b) > 0 {
+		if b[0] != '.' {
+			return 0, 0, false
+		}
+		// Read the fractional part.
+		b = b[1:]
+		n := 0
+		for len(b) > 0 && n < 9 && '0' <= b[0] && b[0] <= '9' {
+			frac[n] = b[0]
+			n++
+			b = b[1:]
+		}
+		// It is not valid if there are more bytes left.
+		if len(b) > 0 {
+			return 0, 0, false
+		}
+		// Pad fractional part with 0s.
+		for i := n; i < 9; i++ {
+			frac[i] = '0'
+		}
+		hasFrac = true
+	}
+
+	var secs int64
+	if len(intp) > 0 {
+		var err error
+		secs, err = strconv.ParseInt(string(intp), 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+
+	var nanos int64
+	if hasFrac {
+		nanob := bytes.TrimLeft(frac[:], "0")
+		if len(nanob) > 0 {
+			var err error
+			nanos, err = strconv.ParseInt(string(nanob), 10, 32)
+			if err != nil {
+				return 0, 0, false
+			}
+		}
+	}
+
+	if neg {
+		if secs > 0 {
+			secs = -secs
+		}
+		if nanos > 0 {
+			nanos = -nanos
+		}
+	}
+	return secs, int32(nanos), true
+}
+
+// FormatISO8601Duration formats a Duration in the ISO 8601 format using
+// hours, minutes, and seconds (e.g., "PT1H30M0.5S"), where the fractional
+// seconds have no trailing zeros. Days are not used since they are not always
+// 24 hours long. Negative durations are prefixed with
+// a minus sign. The zero duration is formatted as "PT0S".
+// The seconds and nanos must not have opposing signs.
+func FormatISO8601Duration(secs int64, nanos int32) string {
+	var b []byte
+	s, n := uint64(secs), int64(nanos)
+	if secs < 0 || n < 0 {
+		b = append(b, '-')
+		s, n = uint64(-secs), -n
+	}
+	b = append(b, "PT"...)
+	if h := s / 3600; h > 0 {
+		b = strconv.AppendUint(b, h, 10)
+		b = append(b, 'H')
+	}
+	if m := s / 60 % 60; m > 0 {
+		b = strconv.AppendUint(b, m, 10)
+		b = append(b, 'M')
+	}
+	if s%60 > 0 || n > 0 || s == 0 {
+		frac := strings.TrimRight(fmt.Sprintf("%09d", n), "0")
+		b = strconv.AppendUint(b, s%60, 10)
+		if frac != "" {
+			b = append(b, '.')
+			b = append(b, frac...)
+		}
+		b = append(b, 'S')
+	}
+	return string(b)
+}
+
+// ParseISO8601Duration parses a Duration in the ISO 8601 format
+// "PnWnDTnHnMnS" with an optional leading plus or minus sign.
+// Weeks are 7 days and days are 24 hours long. Years and months are not
+// accepted since they do not have a fixed length. Only the seconds may have
+// a fractional part, which is limited to 9 digits.
+func ParseISO8601Duration(s string) (int64, int32, bool) {
+	var neg bool
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[len("-"):]
+	case strings.HasPrefix(s, "+"):
+		s = s[len("+"):]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, 0, false
+	}
+	s = s[len("P"):]
+
+	var secs, nanos int64
+	var inTime, found bool
+	units := "WD"
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, 0, false
+			}
+			inTime = true
+			units = "HMS"
+			s = s[len("T"):]
+			continue
+		}
+
+		// Read the integer part.
+		i := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, 0, false
+		}
+		v, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		s = s[i:]
+
+		// Read the optional fractional part, which is only valid for seconds.
+		var frac int64
+		if len(s) > 0 && s[0] == '.' {
+			s = s[len("."):]
+			i := 0
+			for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+				i++
+			}
+			if i == 0 || i > 9 || !strings.HasPrefix(s[i:], "S") {
+				return 0, 0, false
+			}
+			frac, _ = strconv.ParseInt(s[:i]+strings.Repeat("0", 9-i), 10, 64)
+			s = s[i:]
+		}
+
+		// Read the unit designator, which must be in order.
+		if len(s) == 0 {
+			return 0, 0, false
+		}
+		j := strings.IndexByte(units, s[0])
+		if j < 0 {
+			return 0, 0, false
+		}
+		var scale int64
+		switch units[j] {
+		case 'W':
+			scale = 7 * 24 * 3600
+		case 'D':
+			scale = 24 * 3600
+		case 'H':
+			scale = 3600
+		case 'M':
+			scale = 60
+		case 'S':
+			scale = 1
+		}
+		units = units[j+1:]
+		s = s[1:]
+		found = true
+
+		// Accumulate the seconds, rejecting values that overflow.
+		if v > (1<<63-1)/scale || secs > (1<<63-1)-v*scale {
+			return 0, 0, false
+		}
+		secs += v * scale
+		nanos += frac
+	}
+	if !found {
+		return 0, 0, false
+	}
+	if neg {
+		secs, nanos = -secs, -nanos
+	}
+	return secs, int32(nanos), true
+}
+
+// FormatTimestamp formats a Timestamp in the RFC 3339 format in UTC,
+// where the fractional seconds have 0, 3, 6, or 9 digits depending on the
+// required precision (e.g., "1972-01-01T10:00:20.021Z").
+// The nanos must be within [0, 999999999].
+func FormatTimestamp(secs int64, nanos int32) string {
+	t := time.Unix(secs, int64(nanos)).UTC()
+	return trimNanos(t.Format("2006-01-02T15:04:05.000000000")) + "Z"
+}
+
+// ParseTimestamp parses a Timestamp in the RFC 3339 format
+// "{year}-{month}-{day}T{hour}:{min}:{sec}[.{frac_sec}]{offset}",
+// where the year has four digits, the fractional seconds have at most
+// 9 digits, and the offset is either "Z" or "±hh:mm".
+func ParseTimestamp(s string) (int64, int32, bool) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, 0, false
+	}
+	// The time package accepts more than 9 fractional digits and commas
+	// as the decimal separator, neither of which is permitted.
+	const secsEnd = len("2006-01-02T15:04:05")
+	if len(s) > secsEnd && s[secsEnd] != 'Z' && s[secsEnd] != '+' && s[secsEnd] != '-' {
+		if s[secsEnd] != '.' {
+			return 0, 0, false
+		}
+		n := 0
+		for _, c := range s[secsEnd+len("."):] {
+			if c < '0' || c > '9' {
+				break
+			}
+			n++
+		}
+		if n == 0 || n > 9 {
+			return 0, 0, false
+		}
+	}
+	return t.Unix(), int32(t.Nanosecond()), true
+}
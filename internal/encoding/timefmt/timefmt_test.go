@@ -0,0 +1,69 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timefmt_test
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/internal/encoding/timefmt"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		secs        int64
+		nanos       int32
+		wantJSON    string
+		wantISO8601 string
+	}{
+		{0, 0, "0s", "PT0S"},
+		{0, -1, "-0.000000001s", "-PT0.000000001S"},
+		{1, 1e6, "1.001s", "PT1.001S"},
+		{-61, -5e8, "-61.500s", "-PT1M1.5S"},
+		{3600, 0, "3600s", "PT1H"},
+		{math.MaxInt64, 999999999, "9223372036854775807.999999999s", "PT2562047788015215H30M7.999999999S"},
+		{math.MinInt64, -999999999, "-9223372036854775808.999999999s", "-PT2562047788015215H30M8.999999999S"},
+	}
+	for _, tt := range tests {
+		if got := timefmt.FormatDuration(tt.secs, tt.nanos); got != tt.wantJSON {
+			t.Errorf("FormatDuration(%v, %v) = %q, want %q", tt.secs, tt.nanos, got, tt.wantJSON)
+		}
+		if got := timefmt.FormatISO8601Duration(tt.secs, tt.nanos); got != tt.wantISO8601 {
+			t.Errorf("FormatISO8601Duration(%v, %v) = %q, want %q", tt.secs, tt.nanos, got, tt.wantISO8601)
+		}
+		if tt.secs == math.MinInt64 {
+			continue // magnitude is not representable when parsing
+		}
+		if secs, nanos, ok := timefmt.ParseDuration(tt.wantJSON); !ok || secs != tt.secs || nanos != tt.nanos {
+			t.Errorf("ParseDuration(%q) = (%v, %v, %v), want (%v, %v, true)", tt.wantJSON, secs, nanos, ok, tt.secs, tt.nanos)
+		}
+		if secs, nanos, ok := timefmt.ParseISO8601Duration(tt.wantISO8601); !ok || secs != tt.secs || nanos != tt.nanos {
+			t.Errorf("ParseISO8601Duration(%q) = (%v, %v, %v), want (%v, %v, true)", tt.wantISO8601, secs, nanos, ok, tt.secs, tt.nanos)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	for _, s := range []string{
+		"2020-02-29T23:59:59Z",
+		"2020-02-29T23:59:59.1Z",
+		"2020-02-29T23:59:59.123456789+14:00",
+	} {
+		if _, _, ok := timefmt.ParseTimestamp(s); !ok {
+			t.Errorf("ParseTimestamp(%q) failed", s)
+		}
+	}
+	for _, s := range []string{
+		"2020-02-30T00:00:00Z",
+		"2020-02-29 23:59:59Z",
+		"2020-02-29T23:59:59.1234567890Z",
+		"2020-02-29T23:59:59,1Z",
+		"20200-02-29T23:59:59Z",
+	} {
+		if _, _, ok := timefmt.ParseTimestamp(s); ok {
+			t.Errorf("ParseTimestamp(%q) succeeded, want failure", s)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrWireFormat is reported for malformed wire-format data.
+// A DecodeError wrapping it is of kind DecodeErrorWireFormat.
+var ErrWireFormat = New("cannot parse invalid wire-format data")
+
+// DecodeErrorKind classifies the cause of a DecodeError.
+type DecodeErrorKind int
+
+const (
+	// DecodeErrorOther is any failure not covered by another kind.
+	DecodeErrorOther DecodeErrorKind = iota
+	// DecodeErrorWireFormat is malformed wire-format data.
+	DecodeErrorWireFormat
+	// DecodeErrorSyntax is malformed JSON or text syntax.
+	DecodeErrorSyntax
+	// DecodeErrorInvalidValue is a well-formed value that is not valid
+	// for the field it is assigned to (e.g., invalid UTF-8 in a string field).
+	DecodeErrorInvalidValue
+	// DecodeErrorUnknownField is a field name that is not known to the message.
+	DecodeErrorUnknownField
+	// DecodeErrorDuplicateField is a field that is set more than once
+	// where that is not permitted.
+	DecodeErrorDuplicateField
+	// DecodeErrorUnresolvedType is an extension or Any type that could not
+	// be resolved.
+	DecodeErrorUnresolvedType
+	// DecodeErrorRequiredNotSet is a required field that is not populated.
+	DecodeErrorRequiredNotSet
+)
+
+func (k DecodeErrorKind) String() string {
+	switch k {
+	case DecodeErrorOther:
+		return "other"
+	case DecodeErrorWireFormat:
+		return "wire format"
+	case DecodeErrorSyntax:
+		return "syntax"
+	case DecodeErrorInvalidValue:
+		return "invalid value"
+	case DecodeErrorUnknownField:
+		return "unknown field"
+	case DecodeErrorDuplicateField:
+		return "duplicate field"
+	case DecodeErrorUnresolvedType:
+		return "unresolved type"
+	case DecodeErrorRequiredNotSet:
+		return "required not set"
+	default:
+		return "<unknown:" + strconv.Itoa(int(k)) + ">"
+	}
+}
+
+// DecodeError is an error produced while parsing the wire, JSON, or text
+// format. It reports where in the message tree and where in the input
+// the failure was detected.
+type DecodeError struct {
+	// Kind is the category of the failure.
+	Kind DecodeErrorKind
+
+	// Path is the path of the field being parsed relative to the root
+	// message (e.g., "a.b[3].c"). Extension fields are written as their
+	// parenthesized full name (e.g., "a.(pkg.ext)"), and map entries are
+	// indexed by their key (e.g., `m["key"]`). It is empty if the failure
+	// is not attributable to any field.
+	Path string
+
+	// Offset is the byte offset in the input at which the failure was
+	// detected, or -1 if unknown. For the wire format, it is the offset of
+	// the tag of the innermost field containing the failure.
+	Offset int
+
+	// Line and Column are the 1-based position of the failure in the input
+	// for the JSON and text formats. They are 0 for the wire format.
+	Line, Column int
+
+	err error
+
+	// remain is the capacity of the input buffer remaining at the failure,
+	// or -1 if unknown. Wire-format parsers always operate on subslices of
+	// the original input, so this identifies the offset independent of how
+	// deeply nested the failing field is.
+	remain int
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return e.err.Error()
+	}
+	return format("%v%v: %v", prefix, e.Path, e.err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.err
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == Error
+}
+
+// WireDecodeError returns a DecodeError of the given kind wrapping err.
+// The remain argument is the capacity of the input buffer at the point of
+// failure. If err is already a DecodeError, it is returned unchanged.
+//
+// If kind is DecodeErrorOther, the kind is derived from err where possible.
+func WireDecodeError(kind DecodeErrorKind, err error, remain int) error {
+	if e, ok := err.(*DecodeError); ok {
+		return e
+	}
+	return &DecodeError{Kind: kindOf(kind, err), Offset: -1, err: err, remain: remain}
+}
+
+// AsTextDecodeError returns err, produced while parsing JSON or text input
+// that is end bytes long, as a DecodeError. If err is eof and its offset is
+// unknown, it is reported as a syntax error at the end of the input, whose
+// line and column are given by position.
+func AsTextDecodeError(err, eof error, end int, position func(int) (line, column int)) error {
+	e := asDecodeError(err)
+	if e.Offset < 0 && Is(e, eof) {
+		e.Kind = DecodeErrorSyntax
+		e.Offset = end
+		e.Line, e.Column = position(end)
+	}
+	return e
+}
+
+// TextDecodeError returns a DecodeError of the given kind wrapping err,
+// detected at the given byte offset, line, and column.
+// If err is already a DecodeError, it is returned unchanged.
+func TextDecodeError(kind DecodeErrorKind, offset, line, column int, err error) error {
+	if e, ok := err.(*DecodeError); ok {
+		return e
+	}
+	return &DecodeError{Kind: kindOf(kind, err), Offset: offset, Line: line, Column: column, err: err, remain: -1}
+}
+
+// AsDecodeError returns err as a DecodeError, wrapping it with the given kind
+// if it is not already one. It returns nil if err is nil.
+func AsDecodeError(kind DecodeErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return WireDecodeError(kind, err, -1)
+}
+
+// SetWireOffset resolves the offset of a DecodeError produced while parsing
+// the wire-format input b. Errors of other types are returned unchanged.
+func SetWireOffset(err error, b []byte) error {
+	if e, ok := err.(*DecodeError); ok && e.remain >= 0 {
+		if n := cap(b) - e.remain; n >= 0 && n <= len(b) {
+			e.Offset = n
+		} else {
+			e.Offset = -1
+		}
+	}
+	return err
+}
+
+// AddFieldPath prepends the named field to the path of err.
+// Extension fields should be named by their full name in parentheses.
+// If err is not a DecodeError, it is wrapped in one.
+func AddFieldPath(err error, name string) error {
+	e := asDecodeError(err)
+	switch {
+	case e.Path == "" || e.Path[0] == '[':
+		e.Path = name + e.Path
+	default:
+		e.Path = name + "." + e.Path
+	}
+	return e
+}
+
+// AddFieldDescPath prepends the field with the given full name to the path
+// of err. An extension field is named by its full name in parentheses,
+// and any other field by its name. The field is identified by these parts
+// of its protoreflect.FieldDescriptor, since protoreflect depends on this
+// package. If err is not a DecodeError, it is wrapped in one.
+func AddFieldDescPath(err error, fullName string, isExtension bool) error {
+	if isExtension {
+		return AddFieldPath(err, "("+fullName+")")
+	}
+	return AddFieldPath(err, fullName[strings.LastIndexByte(fullName, '.')+1:])
+}
+
+// AddIndexPath prepends the list index i to the path of err.
+// If err is not a DecodeError, it is wrapped in one.
+func AddIndexPath(err error, i int) error {
+	e := asDecodeError(err)
+	e.Path = "[" + strconv.Itoa(i) + "]" + withDot(e.Path)
+	return e
+}
+
+// AddMapKeyPath prepends the map key k to the path of err.
+// String keys are quoted; all other keys are formatted with %v.
+// If err is not a DecodeError, it is wrapped in one.
+func AddMapKeyPath(err error, k interface{}) error {
+	e := asDecodeError(err)
+	var s string
+	if ks, ok := k.(string); ok {
+		s = strconv.Quote(ks)
+	} else {
+		s = format("%v", k)
+	}
+	e.Path = "[" + s + "]" + withDot(e.Path)
+	return e
+}
+
+func withDot(path string) string {
+	if path == "" || path[0] == '[' {
+		return path
+	}
+	return "." + path
+}
+
+func asDecodeError(err error) *DecodeError {
+	if e, ok := err.(*DecodeError); ok {
+		return e
+	}
+	return &DecodeError{Kind: kindOf(DecodeErrorOther, err), Offset: -1, err: err, remain: -1}
+}
+
+// kindOf refines an unclassified kind using the well-known error methods
+// also recognized by the github.com/golang/protobuf module.
+func kindOf(kind DecodeErrorKind, err error) DecodeErrorKind {
+	if kind != DecodeErrorOther {
+		return kind
+	}
+	if err == ErrWireFormat {
+		return DecodeErrorWireFormat
+	}
+	switch err := err.(type) {
+	case interface{ InvalidUTF8() bool }:
+		if err.InvalidUTF8() {
+			return DecodeErrorInvalidValue
+		}
+	case interface{ RequiredNotSet() bool }:
+		if err.RequiredNotSet() {
+			return DecodeErrorRequiredNotSet
+		}
+	}
+	return kind
+}
@@ -73,6 +73,10 @@ func format(f string, x ...interface{}) string {
 		switch e := x[i].(type) {
 		case *prefixError:
 			x[i] = e.s
+		case *invalidUTF8Error:
+			x[i] = e.s
+		case *requiredNotSetError:
+			x[i] = e.s
 		case *wrapError:
 			x[i] = format("%v: %v", e.s, e.err)
 		}
@@ -81,9 +85,17 @@ func format(f string, x ...interface{}) string {
 }
 
 func InvalidUTF8(name string) error {
-	return New("field %v contains invalid UTF-8", name)
+	return &invalidUTF8Error{prefixError{s: format("field %v contains invalid UTF-8", name)}}
 }
 
+type invalidUTF8Error struct{ prefixError }
+
+func (*invalidUTF8Error) InvalidUTF8() bool { return true }
+
 func RequiredNotSet(name string) error {
-	return New("required field %v not set", name)
+	return &requiredNotSetError{prefixError{s: format("required field %v not set", name)}}
 }
+
+type requiredNotSetError struct{ prefixError }
+
+func (*requiredNotSetError) RequiredNotSet() bool { return true }
@@ -65,3 +65,60 @@ func TestErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeErrorPath(t *testing.T) {
+	err := New("bad value")
+	err = AddFieldPath(err, "c")
+	err = AddIndexPath(err, 3)
+	err = AddFieldPath(err, "b")
+	err = AddMapKeyPath(err, "k")
+	err = AddFieldPath(err, "(pkg.ext)")
+	err = AddFieldPath(err, "a")
+
+	e, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("error is %T, want *DecodeError", err)
+	}
+	if got, want := e.Path, `a.(pkg.ext)["k"].b[3].c`; got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+	if got, want := e.Error(), prefix+`a.(pkg.ext)["k"].b[3].c: bad value`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := Is(err, Error), true; got != want {
+		t.Errorf("errors.Is(err, errors.Error) = %v, want %v", got, want)
+	}
+
+	b := make([]byte, 10)
+	err = SetWireOffset(WireDecodeError(DecodeErrorWireFormat, New("bad wire"), cap(b[4:])), b)
+	if got, want := err.(*DecodeError).Offset, 4; got != want {
+		t.Errorf("Offset = %v, want %v", got, want)
+	}
+	if got, want := AsDecodeError(DecodeErrorOther, InvalidUTF8("f")).(*DecodeError).Kind, DecodeErrorInvalidValue; got != want {
+		t.Errorf("Kind = %v, want %v", got, want)
+	}
+	if got, want := WireDecodeError(DecodeErrorOther, ErrWireFormat, 0).(*DecodeError).Kind, DecodeErrorWireFormat; got != want {
+		t.Errorf("Kind = %v, want %v", got, want)
+	}
+}
+
+func TestAddFieldDescPath(t *testing.T) {
+	err := AddFieldDescPath(New("bad value"), "pkg.Message.field", false)
+	err = AddFieldDescPath(err, "pkg.ext", true)
+	if got, want := err.(*DecodeError).Path, "(pkg.ext).field"; got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+}
+
+func TestAsTextDecodeError(t *testing.T) {
+	eof := New("unexpected EOF")
+	position := func(n int) (int, int) { return 2, n }
+	e := AsTextDecodeError(eof, eof, 7, position).(*DecodeError)
+	if e.Kind != DecodeErrorSyntax || e.Offset != 7 || e.Line != 2 || e.Column != 7 {
+		t.Errorf("AsTextDecodeError(eof) = {Kind: %v, Offset: %v, Line: %v, Column: %v}, want {Kind: %v, Offset: 7, Line: 2, Column: 7}", e.Kind, e.Offset, e.Line, e.Column, DecodeErrorSyntax)
+	}
+	e = AsTextDecodeError(New("other"), eof, 7, position).(*DecodeError)
+	if e.Kind != DecodeErrorOther || e.Offset != -1 {
+		t.Errorf("AsTextDecodeError(other) = {Kind: %v, Offset: %v}, want {Kind: %v, Offset: -1}", e.Kind, e.Offset, DecodeErrorOther)
+	}
+}
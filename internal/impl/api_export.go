@@ -175,3 +175,15 @@ func (Export) MessageTypeOf(m message) pref.MessageType {
 func (Export) MessageStringOf(m pref.ProtoMessage) string {
 	return prototext.MarshalOptions{Multiline: false}.Format(m)
 }
+
+// ErrDecode returns the error reported by generated unmarshal code
+// for malformed wire data, where b is the remaining input at the failure.
+func (Export) ErrDecode(b []byte) error {
+	return errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b))
+}
+
+// ErrInvalidUTF8 returns the error reported by generated code
+// for a string field containing invalid UTF-8.
+func (Export) ErrInvalidUTF8() error {
+	return errInvalidUTF8{}
+}
@@ -49,7 +49,13 @@ func (mi *MessageInfo) initOneofFieldCoders(od pref.OneofDescriptor, si structIn
 		ot := si.oneofWrappersByNumber[num]
 		cf.ft = ot.Field(0).Type
 		cf.mi, cf.funcs = fieldCoder(fd, cf.ft)
+		if fd.Kind() == pref.BytesKind {
+			// A bytes field in a oneof is populated whenever the oneof is set,
+			// so a nil value is equal to an empty one.
+			cf.funcs.equal = equalBytesNoZero
+		}
 		oneofFields[ot] = &cf
+		mi.oneofCoderFields[reflect.PtrTo(ot)] = &cf
 		if cf.funcs.isInit != nil {
 			needIsInit = true
 		}
@@ -107,6 +113,19 @@ func (mi *MessageInfo) initOneofFieldCoders(od pref.OneofDescriptor, si structIn
 		}
 		srcinfo.funcs.merge(dstp, srcp, srcinfo, opts)
 	}
+	first.funcs.equal = func(x, y pointer, _ *coderFieldInfo) bool {
+		xp, xinfo := getInfo(x)
+		yp, yinfo := getInfo(y)
+		if xinfo != yinfo {
+			return false
+		}
+		if xinfo == nil || xinfo.funcs.equal == nil {
+			return true
+		}
+		// The member values are compared without regard to presence,
+		// so a nil message is equal to an empty one.
+		return xinfo.funcs.equal(xp, yp, xinfo)
+	}
 	if needIsInit {
 		first.funcs.isInit = func(p pointer, _ *coderFieldInfo) error {
 			p, info := getInfo(p)
@@ -187,6 +206,14 @@ func makeWeakMessageFieldCoder(fd pref.FieldDescriptor) pointerCoderFuncs {
 			}
 			opts.Merge(dm, sm)
 		},
+		equal: func(x, y pointer, f *coderFieldInfo) bool {
+			mx, okx := x.WeakFields().get(f.num)
+			my, oky := y.WeakFields().get(f.num)
+			if !okx || !oky {
+				return okx == oky
+			}
+			return proto.Equal(mx, my)
+		},
 	}
 }
 
@@ -197,6 +224,7 @@ func makeMessageFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerCode
 			marshal:   appendMessageInfo,
 			unmarshal: consumeMessageInfo,
 			merge:     mergeMessage,
+			equal:     equalMessage,
 		}
 		if needsInitCheck(mi.Desc) {
 			funcs.isInit = isInitMessageInfo
@@ -206,11 +234,11 @@ func makeMessageFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerCode
 		return pointerCoderFuncs{
 			size: func(p pointer, f *coderFieldInfo, opts marshalOptions) int {
 				m := asMessage(p.AsValueOf(ft).Elem())
-				return sizeMessage(m, f.tagsize, opts)
+				return sizeLegacyMessage(m, f.tagsize, opts)
 			},
 			marshal: func(b []byte, p pointer, f *coderFieldInfo, opts marshalOptions) ([]byte, error) {
 				m := asMessage(p.AsValueOf(ft).Elem())
-				return appendMessage(b, m, f.wiretag, opts)
+				return appendLegacyMessage(b, m, f.wiretag, opts)
 			},
 			unmarshal: func(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, opts unmarshalOptions) (unmarshalOutput, error) {
 				mp := p.AsValueOf(ft).Elem()
@@ -224,6 +252,7 @@ func makeMessageFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerCode
 				return proto.CheckInitialized(m)
 			},
 			merge: mergeMessage,
+			equal: equalMessage,
 		}
 	}
 }
@@ -262,6 +291,41 @@ func isInitMessageInfo(p pointer, f *coderFieldInfo) error {
 	return f.mi.checkInitializedPointer(p.Elem())
 }
 
+// sizeLegacyMessage is like sizeMessage, but records the size of m
+// in the size table of opts when possible.
+func sizeLegacyMessage(m proto.Message, tagsize int, opts marshalOptions) int {
+	if mi, p, ok := sizeTableMessage(m, opts); ok {
+		return protowire.SizeBytes(mi.sizePointer(p, opts)) + tagsize
+	}
+	return sizeMessage(m, tagsize, opts)
+}
+
+// appendLegacyMessage is like appendMessage, but uses the size table of opts
+// when possible.
+func appendLegacyMessage(b []byte, m proto.Message, wiretag uint64, opts marshalOptions) ([]byte, error) {
+	mi, p, ok := sizeTableMessage(m, opts)
+	if !ok {
+		return appendMessage(b, m, wiretag, opts)
+	}
+	b = protowire.AppendVarint(b, wiretag)
+	b = protowire.AppendVarint(b, uint64(mi.sizePointer(p, opts)))
+	return mi.marshalAppendPointer(b, p, opts)
+}
+
+// sizeTableMessage returns the MessageInfo and pointer for a legacy message m
+// if it can be marshaled directly using the size table in opts. Otherwise, m
+// is marshaled through the proto package, which recomputes its size.
+func sizeTableMessage(m proto.Message, opts marshalOptions) (*MessageInfo, pointer, bool) {
+	w, ok := m.(*messageIfaceWrapper)
+	if !ok || opts.sizes == nil {
+		return nil, pointer{}, false
+	}
+	if w.mi.Methods.Marshal != nil || w.mi.Methods.Size != nil {
+		return nil, pointer{}, false
+	}
+	return w.mi, w.p, true
+}
+
 func sizeMessage(m proto.Message, tagsize int, _ marshalOptions) int {
 	return protowire.SizeBytes(proto.Size(m)) + tagsize
 }
@@ -353,6 +417,7 @@ func makeGroupFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerCoderF
 			marshal:   appendGroupType,
 			unmarshal: consumeGroupType,
 			merge:     mergeMessage,
+			equal:     equalMessage,
 		}
 		if needsInitCheck(mi.Desc) {
 			funcs.isInit = isInitMessageInfo
@@ -380,6 +445,7 @@ func makeGroupFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerCoderF
 				return proto.CheckInitialized(m)
 			},
 			merge: mergeMessage,
+			equal: equalMessage,
 		}
 	}
 }
@@ -443,6 +509,7 @@ func makeMessageSliceFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointe
 			marshal:   appendMessageSliceInfo,
 			unmarshal: consumeMessageSliceInfo,
 			merge:     mergeMessageSlice,
+			equal:     equalMessageSlice,
 		}
 		if needsInitCheck(mi.Desc) {
 			funcs.isInit = isInitMessageSliceInfo
@@ -463,6 +530,7 @@ func makeMessageSliceFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointe
 			return isInitMessageSlice(p, ft)
 		},
 		merge: mergeMessageSlice,
+		equal: equalMessageSlice,
 	}
 }
 
@@ -498,8 +566,7 @@ func consumeMessageSliceInfo(b []byte, p pointer, wtyp protowire.Type, f *coderF
 	if n < 0 {
 		return out, errDecode
 	}
-	m := reflect.New(f.mi.GoReflectType.Elem()).Interface()
-	mp := pointerOfIface(m)
+	mp := newMessageSliceElem(p, f, opts)
 	o, err := f.mi.unmarshalPointer(v, mp, 0, opts)
 	if err != nil {
 		return out, err
@@ -510,6 +577,19 @@ func consumeMessageSliceInfo(b []byte, p pointer, wtyp protowire.Type, f *coderF
 	return out, nil
 }
 
+// newMessageSliceElem returns an empty message to append to the repeated
+// message field p. It reuses a message left in the spare capacity of the
+// slice by a reset if the unmarshal options permit it.
+func newMessageSliceElem(p pointer, f *coderFieldInfo, opts unmarshalOptions) pointer {
+	if opts.RetainCapacity() {
+		if mp, ok := p.SparePointer(); ok {
+			f.mi.resetPointer(mp, true, opts.retained)
+			return mp
+		}
+	}
+	return pointerOfValue(reflect.New(f.mi.GoReflectType.Elem()))
+}
+
 func isInitMessageSliceInfo(p pointer, f *coderFieldInfo) error {
 	s := p.PointerSlice()
 	for _, v := range s {
@@ -520,12 +600,12 @@ func isInitMessageSliceInfo(p pointer, f *coderFieldInfo) error {
 	return nil
 }
 
-func sizeMessageSlice(p pointer, goType reflect.Type, tagsize int, _ marshalOptions) int {
+func sizeMessageSlice(p pointer, goType reflect.Type, tagsize int, opts marshalOptions) int {
 	s := p.PointerSlice()
 	n := 0
 	for _, v := range s {
 		m := asMessage(v.AsValueOf(goType.Elem()))
-		n += protowire.SizeBytes(proto.Size(m)) + tagsize
+		n += sizeLegacyMessage(m, tagsize, opts)
 	}
 	return n
 }
@@ -535,10 +615,7 @@ func appendMessageSlice(b []byte, p pointer, wiretag uint64, goType reflect.Type
 	var err error
 	for _, v := range s {
 		m := asMessage(v.AsValueOf(goType.Elem()))
-		b = protowire.AppendVarint(b, wiretag)
-		siz := proto.Size(m)
-		b = protowire.AppendVarint(b, uint64(siz))
-		b, err = opts.Options().MarshalAppend(b, m)
+		b, err = appendLegacyMessage(b, m, wiretag, opts)
 		if err != nil {
 			return b, err
 		}
@@ -715,6 +792,7 @@ func makeGroupSliceFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerC
 			marshal:   appendGroupSliceInfo,
 			unmarshal: consumeGroupSliceInfo,
 			merge:     mergeMessageSlice,
+			equal:     equalMessageSlice,
 		}
 		if needsInitCheck(mi.Desc) {
 			funcs.isInit = isInitMessageSliceInfo
@@ -735,6 +813,7 @@ func makeGroupSliceFieldCoder(fd pref.FieldDescriptor, ft reflect.Type) pointerC
 			return isInitMessageSlice(p, ft)
 		},
 		merge: mergeMessageSlice,
+		equal: equalMessageSlice,
 	}
 }
 
@@ -812,8 +891,7 @@ func consumeGroupSliceInfo(b []byte, p pointer, wtyp protowire.Type, f *coderFie
 	if wtyp != protowire.StartGroupType {
 		return unmarshalOutput{}, errUnknown
 	}
-	m := reflect.New(f.mi.GoReflectType.Elem()).Interface()
-	mp := pointerOfIface(m)
+	mp := newMessageSliceElem(p, f, opts)
 	out, err := f.mi.unmarshalPointer(b, mp, f.num, opts)
 	if err != nil {
 		return out, err
@@ -11,6 +11,7 @@ import (
 	"unicode/utf8"
 
 	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/strs"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -57,6 +58,7 @@ var coderBool = pointerCoderFuncs{
 	marshal:   appendBool,
 	unmarshal: consumeBool,
 	merge:     mergeBool,
+	equal:     equalBool,
 }
 
 // sizeBoolNoZero returns the size of wire encoding a bool pointer as a Bool.
@@ -86,6 +88,7 @@ var coderBoolNoZero = pointerCoderFuncs{
 	marshal:   appendBoolNoZero,
 	unmarshal: consumeBool,
 	merge:     mergeBoolNoZero,
+	equal:     equalBoolNoZero,
 }
 
 // sizeBoolPtr returns the size of wire encoding a *bool pointer as a Bool.
@@ -137,6 +140,7 @@ var coderBoolPtr = pointerCoderFuncs{
 	marshal:   appendBoolPtr,
 	unmarshal: consumeBoolPtr,
 	merge:     mergeBoolPtr,
+	equal:     equalBoolPtr,
 }
 
 // sizeBoolSlice returns the size of wire encoding a []bool pointer as a repeated Bool.
@@ -216,6 +220,7 @@ var coderBoolSlice = pointerCoderFuncs{
 	marshal:   appendBoolSlice,
 	unmarshal: consumeBoolSlice,
 	merge:     mergeBoolSlice,
+	equal:     equalBoolSlice,
 }
 
 // sizeBoolPackedSlice returns the size of wire encoding a []bool pointer as a packed repeated Bool.
@@ -254,6 +259,7 @@ var coderBoolPackedSlice = pointerCoderFuncs{
 	marshal:   appendBoolPackedSlice,
 	unmarshal: consumeBoolSlice,
 	merge:     mergeBoolSlice,
+	equal:     equalBoolSlice,
 }
 
 // sizeBoolValue returns the size of wire encoding a bool value as a Bool.
@@ -627,6 +633,7 @@ var coderInt32 = pointerCoderFuncs{
 	marshal:   appendInt32,
 	unmarshal: consumeInt32,
 	merge:     mergeInt32,
+	equal:     equalInt32,
 }
 
 // sizeInt32NoZero returns the size of wire encoding a int32 pointer as a Int32.
@@ -656,6 +663,7 @@ var coderInt32NoZero = pointerCoderFuncs{
 	marshal:   appendInt32NoZero,
 	unmarshal: consumeInt32,
 	merge:     mergeInt32NoZero,
+	equal:     equalInt32NoZero,
 }
 
 // sizeInt32Ptr returns the size of wire encoding a *int32 pointer as a Int32.
@@ -707,6 +715,7 @@ var coderInt32Ptr = pointerCoderFuncs{
 	marshal:   appendInt32Ptr,
 	unmarshal: consumeInt32Ptr,
 	merge:     mergeInt32Ptr,
+	equal:     equalInt32Ptr,
 }
 
 // sizeInt32Slice returns the size of wire encoding a []int32 pointer as a repeated Int32.
@@ -786,6 +795,7 @@ var coderInt32Slice = pointerCoderFuncs{
 	marshal:   appendInt32Slice,
 	unmarshal: consumeInt32Slice,
 	merge:     mergeInt32Slice,
+	equal:     equalInt32Slice,
 }
 
 // sizeInt32PackedSlice returns the size of wire encoding a []int32 pointer as a packed repeated Int32.
@@ -824,6 +834,7 @@ var coderInt32PackedSlice = pointerCoderFuncs{
 	marshal:   appendInt32PackedSlice,
 	unmarshal: consumeInt32Slice,
 	merge:     mergeInt32Slice,
+	equal:     equalInt32Slice,
 }
 
 // sizeInt32Value returns the size of wire encoding a int32 value as a Int32.
@@ -1033,6 +1044,7 @@ var coderSint32 = pointerCoderFuncs{
 	marshal:   appendSint32,
 	unmarshal: consumeSint32,
 	merge:     mergeInt32,
+	equal:     equalInt32,
 }
 
 // sizeSint32NoZero returns the size of wire encoding a int32 pointer as a Sint32.
@@ -1062,6 +1074,7 @@ var coderSint32NoZero = pointerCoderFuncs{
 	marshal:   appendSint32NoZero,
 	unmarshal: consumeSint32,
 	merge:     mergeInt32NoZero,
+	equal:     equalInt32NoZero,
 }
 
 // sizeSint32Ptr returns the size of wire encoding a *int32 pointer as a Sint32.
@@ -1113,6 +1126,7 @@ var coderSint32Ptr = pointerCoderFuncs{
 	marshal:   appendSint32Ptr,
 	unmarshal: consumeSint32Ptr,
 	merge:     mergeInt32Ptr,
+	equal:     equalInt32Ptr,
 }
 
 // sizeSint32Slice returns the size of wire encoding a []int32 pointer as a repeated Sint32.
@@ -1192,6 +1206,7 @@ var coderSint32Slice = pointerCoderFuncs{
 	marshal:   appendSint32Slice,
 	unmarshal: consumeSint32Slice,
 	merge:     mergeInt32Slice,
+	equal:     equalInt32Slice,
 }
 
 // sizeSint32PackedSlice returns the size of wire encoding a []int32 pointer as a packed repeated Sint32.
@@ -1230,6 +1245,7 @@ var coderSint32PackedSlice = pointerCoderFuncs{
 	marshal:   appendSint32PackedSlice,
 	unmarshal: consumeSint32Slice,
 	merge:     mergeInt32Slice,
+	equal:     equalInt32Slice,
 }
 
 // sizeSint32Value returns the size of wire encoding a int32 value as a Sint32.
@@ -1439,6 +1455,7 @@ var coderUint32 = pointerCoderFuncs{
 	marshal:   appendUint32,
 	unmarshal: consumeUint32,
 	merge:     mergeUint32,
+	equal:     equalUint32,
 }
 
 // sizeUint32NoZero returns the size of wire encoding a uint32 pointer as a Uint32.
@@ -1468,6 +1485,7 @@ var coderUint32NoZero = pointerCoderFuncs{
 	marshal:   appendUint32NoZero,
 	unmarshal: consumeUint32,
 	merge:     mergeUint32NoZero,
+	equal:     equalUint32NoZero,
 }
 
 // sizeUint32Ptr returns the size of wire encoding a *uint32 pointer as a Uint32.
@@ -1519,6 +1537,7 @@ var coderUint32Ptr = pointerCoderFuncs{
 	marshal:   appendUint32Ptr,
 	unmarshal: consumeUint32Ptr,
 	merge:     mergeUint32Ptr,
+	equal:     equalUint32Ptr,
 }
 
 // sizeUint32Slice returns the size of wire encoding a []uint32 pointer as a repeated Uint32.
@@ -1598,6 +1617,7 @@ var coderUint32Slice = pointerCoderFuncs{
 	marshal:   appendUint32Slice,
 	unmarshal: consumeUint32Slice,
 	merge:     mergeUint32Slice,
+	equal:     equalUint32Slice,
 }
 
 // sizeUint32PackedSlice returns the size of wire encoding a []uint32 pointer as a packed repeated Uint32.
@@ -1636,6 +1656,7 @@ var coderUint32PackedSlice = pointerCoderFuncs{
 	marshal:   appendUint32PackedSlice,
 	unmarshal: consumeUint32Slice,
 	merge:     mergeUint32Slice,
+	equal:     equalUint32Slice,
 }
 
 // sizeUint32Value returns the size of wire encoding a uint32 value as a Uint32.
@@ -1845,6 +1866,7 @@ var coderInt64 = pointerCoderFuncs{
 	marshal:   appendInt64,
 	unmarshal: consumeInt64,
 	merge:     mergeInt64,
+	equal:     equalInt64,
 }
 
 // sizeInt64NoZero returns the size of wire encoding a int64 pointer as a Int64.
@@ -1874,6 +1896,7 @@ var coderInt64NoZero = pointerCoderFuncs{
 	marshal:   appendInt64NoZero,
 	unmarshal: consumeInt64,
 	merge:     mergeInt64NoZero,
+	equal:     equalInt64NoZero,
 }
 
 // sizeInt64Ptr returns the size of wire encoding a *int64 pointer as a Int64.
@@ -1925,6 +1948,7 @@ var coderInt64Ptr = pointerCoderFuncs{
 	marshal:   appendInt64Ptr,
 	unmarshal: consumeInt64Ptr,
 	merge:     mergeInt64Ptr,
+	equal:     equalInt64Ptr,
 }
 
 // sizeInt64Slice returns the size of wire encoding a []int64 pointer as a repeated Int64.
@@ -2004,6 +2028,7 @@ var coderInt64Slice = pointerCoderFuncs{
 	marshal:   appendInt64Slice,
 	unmarshal: consumeInt64Slice,
 	merge:     mergeInt64Slice,
+	equal:     equalInt64Slice,
 }
 
 // sizeInt64PackedSlice returns the size of wire encoding a []int64 pointer as a packed repeated Int64.
@@ -2042,6 +2067,7 @@ var coderInt64PackedSlice = pointerCoderFuncs{
 	marshal:   appendInt64PackedSlice,
 	unmarshal: consumeInt64Slice,
 	merge:     mergeInt64Slice,
+	equal:     equalInt64Slice,
 }
 
 // sizeInt64Value returns the size of wire encoding a int64 value as a Int64.
@@ -2251,6 +2277,7 @@ var coderSint64 = pointerCoderFuncs{
 	marshal:   appendSint64,
 	unmarshal: consumeSint64,
 	merge:     mergeInt64,
+	equal:     equalInt64,
 }
 
 // sizeSint64NoZero returns the size of wire encoding a int64 pointer as a Sint64.
@@ -2280,6 +2307,7 @@ var coderSint64NoZero = pointerCoderFuncs{
 	marshal:   appendSint64NoZero,
 	unmarshal: consumeSint64,
 	merge:     mergeInt64NoZero,
+	equal:     equalInt64NoZero,
 }
 
 // sizeSint64Ptr returns the size of wire encoding a *int64 pointer as a Sint64.
@@ -2331,6 +2359,7 @@ var coderSint64Ptr = pointerCoderFuncs{
 	marshal:   appendSint64Ptr,
 	unmarshal: consumeSint64Ptr,
 	merge:     mergeInt64Ptr,
+	equal:     equalInt64Ptr,
 }
 
 // sizeSint64Slice returns the size of wire encoding a []int64 pointer as a repeated Sint64.
@@ -2410,6 +2439,7 @@ var coderSint64Slice = pointerCoderFuncs{
 	marshal:   appendSint64Slice,
 	unmarshal: consumeSint64Slice,
 	merge:     mergeInt64Slice,
+	equal:     equalInt64Slice,
 }
 
 // sizeSint64PackedSlice returns the size of wire encoding a []int64 pointer as a packed repeated Sint64.
@@ -2448,6 +2478,7 @@ var coderSint64PackedSlice = pointerCoderFuncs{
 	marshal:   appendSint64PackedSlice,
 	unmarshal: consumeSint64Slice,
 	merge:     mergeInt64Slice,
+	equal:     equalInt64Slice,
 }
 
 // sizeSint64Value returns the size of wire encoding a int64 value as a Sint64.
@@ -2657,6 +2688,7 @@ var coderUint64 = pointerCoderFuncs{
 	marshal:   appendUint64,
 	unmarshal: consumeUint64,
 	merge:     mergeUint64,
+	equal:     equalUint64,
 }
 
 // sizeUint64NoZero returns the size of wire encoding a uint64 pointer as a Uint64.
@@ -2686,6 +2718,7 @@ var coderUint64NoZero = pointerCoderFuncs{
 	marshal:   appendUint64NoZero,
 	unmarshal: consumeUint64,
 	merge:     mergeUint64NoZero,
+	equal:     equalUint64NoZero,
 }
 
 // sizeUint64Ptr returns the size of wire encoding a *uint64 pointer as a Uint64.
@@ -2737,6 +2770,7 @@ var coderUint64Ptr = pointerCoderFuncs{
 	marshal:   appendUint64Ptr,
 	unmarshal: consumeUint64Ptr,
 	merge:     mergeUint64Ptr,
+	equal:     equalUint64Ptr,
 }
 
 // sizeUint64Slice returns the size of wire encoding a []uint64 pointer as a repeated Uint64.
@@ -2816,6 +2850,7 @@ var coderUint64Slice = pointerCoderFuncs{
 	marshal:   appendUint64Slice,
 	unmarshal: consumeUint64Slice,
 	merge:     mergeUint64Slice,
+	equal:     equalUint64Slice,
 }
 
 // sizeUint64PackedSlice returns the size of wire encoding a []uint64 pointer as a packed repeated Uint64.
@@ -2854,6 +2889,7 @@ var coderUint64PackedSlice = pointerCoderFuncs{
 	marshal:   appendUint64PackedSlice,
 	unmarshal: consumeUint64Slice,
 	merge:     mergeUint64Slice,
+	equal:     equalUint64Slice,
 }
 
 // sizeUint64Value returns the size of wire encoding a uint64 value as a Uint64.
@@ -3053,6 +3089,7 @@ var coderSfixed32 = pointerCoderFuncs{
 	marshal:   appendSfixed32,
 	unmarshal: consumeSfixed32,
 	merge:     mergeInt32,
+	equal:     equalInt32,
 }
 
 // sizeSfixed32NoZero returns the size of wire encoding a int32 pointer as a Sfixed32.
@@ -3082,6 +3119,7 @@ var coderSfixed32NoZero = pointerCoderFuncs{
 	marshal:   appendSfixed32NoZero,
 	unmarshal: consumeSfixed32,
 	merge:     mergeInt32NoZero,
+	equal:     equalInt32NoZero,
 }
 
 // sizeSfixed32Ptr returns the size of wire encoding a *int32 pointer as a Sfixed32.
@@ -3122,6 +3160,7 @@ var coderSfixed32Ptr = pointerCoderFuncs{
 	marshal:   appendSfixed32Ptr,
 	unmarshal: consumeSfixed32Ptr,
 	merge:     mergeInt32Ptr,
+	equal:     equalInt32Ptr,
 }
 
 // sizeSfixed32Slice returns the size of wire encoding a []int32 pointer as a repeated Sfixed32.
@@ -3179,6 +3218,7 @@ var coderSfixed32Slice = pointerCoderFuncs{
 	marshal:   appendSfixed32Slice,
 	unmarshal: consumeSfixed32Slice,
 	merge:     mergeInt32Slice,
+	equal:     equalInt32Slice,
 }
 
 // sizeSfixed32PackedSlice returns the size of wire encoding a []int32 pointer as a packed repeated Sfixed32.
@@ -3211,6 +3251,7 @@ var coderSfixed32PackedSlice = pointerCoderFuncs{
 	marshal:   appendSfixed32PackedSlice,
 	unmarshal: consumeSfixed32Slice,
 	merge:     mergeInt32Slice,
+	equal:     equalInt32Slice,
 }
 
 // sizeSfixed32Value returns the size of wire encoding a int32 value as a Sfixed32.
@@ -3369,6 +3410,7 @@ var coderFixed32 = pointerCoderFuncs{
 	marshal:   appendFixed32,
 	unmarshal: consumeFixed32,
 	merge:     mergeUint32,
+	equal:     equalUint32,
 }
 
 // sizeFixed32NoZero returns the size of wire encoding a uint32 pointer as a Fixed32.
@@ -3398,6 +3440,7 @@ var coderFixed32NoZero = pointerCoderFuncs{
 	marshal:   appendFixed32NoZero,
 	unmarshal: consumeFixed32,
 	merge:     mergeUint32NoZero,
+	equal:     equalUint32NoZero,
 }
 
 // sizeFixed32Ptr returns the size of wire encoding a *uint32 pointer as a Fixed32.
@@ -3438,6 +3481,7 @@ var coderFixed32Ptr = pointerCoderFuncs{
 	marshal:   appendFixed32Ptr,
 	unmarshal: consumeFixed32Ptr,
 	merge:     mergeUint32Ptr,
+	equal:     equalUint32Ptr,
 }
 
 // sizeFixed32Slice returns the size of wire encoding a []uint32 pointer as a repeated Fixed32.
@@ -3495,6 +3539,7 @@ var coderFixed32Slice = pointerCoderFuncs{
 	marshal:   appendFixed32Slice,
 	unmarshal: consumeFixed32Slice,
 	merge:     mergeUint32Slice,
+	equal:     equalUint32Slice,
 }
 
 // sizeFixed32PackedSlice returns the size of wire encoding a []uint32 pointer as a packed repeated Fixed32.
@@ -3527,6 +3572,7 @@ var coderFixed32PackedSlice = pointerCoderFuncs{
 	marshal:   appendFixed32PackedSlice,
 	unmarshal: consumeFixed32Slice,
 	merge:     mergeUint32Slice,
+	equal:     equalUint32Slice,
 }
 
 // sizeFixed32Value returns the size of wire encoding a uint32 value as a Fixed32.
@@ -3685,6 +3731,7 @@ var coderFloat = pointerCoderFuncs{
 	marshal:   appendFloat,
 	unmarshal: consumeFloat,
 	merge:     mergeFloat32,
+	equal:     equalFloat32,
 }
 
 // sizeFloatNoZero returns the size of wire encoding a float32 pointer as a Float.
@@ -3714,6 +3761,7 @@ var coderFloatNoZero = pointerCoderFuncs{
 	marshal:   appendFloatNoZero,
 	unmarshal: consumeFloat,
 	merge:     mergeFloat32NoZero,
+	equal:     equalFloat32NoZero,
 }
 
 // sizeFloatPtr returns the size of wire encoding a *float32 pointer as a Float.
@@ -3754,6 +3802,7 @@ var coderFloatPtr = pointerCoderFuncs{
 	marshal:   appendFloatPtr,
 	unmarshal: consumeFloatPtr,
 	merge:     mergeFloat32Ptr,
+	equal:     equalFloat32Ptr,
 }
 
 // sizeFloatSlice returns the size of wire encoding a []float32 pointer as a repeated Float.
@@ -3811,6 +3860,7 @@ var coderFloatSlice = pointerCoderFuncs{
 	marshal:   appendFloatSlice,
 	unmarshal: consumeFloatSlice,
 	merge:     mergeFloat32Slice,
+	equal:     equalFloat32Slice,
 }
 
 // sizeFloatPackedSlice returns the size of wire encoding a []float32 pointer as a packed repeated Float.
@@ -3843,6 +3893,7 @@ var coderFloatPackedSlice = pointerCoderFuncs{
 	marshal:   appendFloatPackedSlice,
 	unmarshal: consumeFloatSlice,
 	merge:     mergeFloat32Slice,
+	equal:     equalFloat32Slice,
 }
 
 // sizeFloatValue returns the size of wire encoding a float32 value as a Float.
@@ -4001,6 +4052,7 @@ var coderSfixed64 = pointerCoderFuncs{
 	marshal:   appendSfixed64,
 	unmarshal: consumeSfixed64,
 	merge:     mergeInt64,
+	equal:     equalInt64,
 }
 
 // sizeSfixed64NoZero returns the size of wire encoding a int64 pointer as a Sfixed64.
@@ -4030,6 +4082,7 @@ var coderSfixed64NoZero = pointerCoderFuncs{
 	marshal:   appendSfixed64NoZero,
 	unmarshal: consumeSfixed64,
 	merge:     mergeInt64NoZero,
+	equal:     equalInt64NoZero,
 }
 
 // sizeSfixed64Ptr returns the size of wire encoding a *int64 pointer as a Sfixed64.
@@ -4070,6 +4123,7 @@ var coderSfixed64Ptr = pointerCoderFuncs{
 	marshal:   appendSfixed64Ptr,
 	unmarshal: consumeSfixed64Ptr,
 	merge:     mergeInt64Ptr,
+	equal:     equalInt64Ptr,
 }
 
 // sizeSfixed64Slice returns the size of wire encoding a []int64 pointer as a repeated Sfixed64.
@@ -4127,6 +4181,7 @@ var coderSfixed64Slice = pointerCoderFuncs{
 	marshal:   appendSfixed64Slice,
 	unmarshal: consumeSfixed64Slice,
 	merge:     mergeInt64Slice,
+	equal:     equalInt64Slice,
 }
 
 // sizeSfixed64PackedSlice returns the size of wire encoding a []int64 pointer as a packed repeated Sfixed64.
@@ -4159,6 +4214,7 @@ var coderSfixed64PackedSlice = pointerCoderFuncs{
 	marshal:   appendSfixed64PackedSlice,
 	unmarshal: consumeSfixed64Slice,
 	merge:     mergeInt64Slice,
+	equal:     equalInt64Slice,
 }
 
 // sizeSfixed64Value returns the size of wire encoding a int64 value as a Sfixed64.
@@ -4317,6 +4373,7 @@ var coderFixed64 = pointerCoderFuncs{
 	marshal:   appendFixed64,
 	unmarshal: consumeFixed64,
 	merge:     mergeUint64,
+	equal:     equalUint64,
 }
 
 // sizeFixed64NoZero returns the size of wire encoding a uint64 pointer as a Fixed64.
@@ -4346,6 +4403,7 @@ var coderFixed64NoZero = pointerCoderFuncs{
 	marshal:   appendFixed64NoZero,
 	unmarshal: consumeFixed64,
 	merge:     mergeUint64NoZero,
+	equal:     equalUint64NoZero,
 }
 
 // sizeFixed64Ptr returns the size of wire encoding a *uint64 pointer as a Fixed64.
@@ -4386,6 +4444,7 @@ var coderFixed64Ptr = pointerCoderFuncs{
 	marshal:   appendFixed64Ptr,
 	unmarshal: consumeFixed64Ptr,
 	merge:     mergeUint64Ptr,
+	equal:     equalUint64Ptr,
 }
 
 // sizeFixed64Slice returns the size of wire encoding a []uint64 pointer as a repeated Fixed64.
@@ -4443,6 +4502,7 @@ var coderFixed64Slice = pointerCoderFuncs{
 	marshal:   appendFixed64Slice,
 	unmarshal: consumeFixed64Slice,
 	merge:     mergeUint64Slice,
+	equal:     equalUint64Slice,
 }
 
 // sizeFixed64PackedSlice returns the size of wire encoding a []uint64 pointer as a packed repeated Fixed64.
@@ -4475,6 +4535,7 @@ var coderFixed64PackedSlice = pointerCoderFuncs{
 	marshal:   appendFixed64PackedSlice,
 	unmarshal: consumeFixed64Slice,
 	merge:     mergeUint64Slice,
+	equal:     equalUint64Slice,
 }
 
 // sizeFixed64Value returns the size of wire encoding a uint64 value as a Fixed64.
@@ -4633,6 +4694,7 @@ var coderDouble = pointerCoderFuncs{
 	marshal:   appendDouble,
 	unmarshal: consumeDouble,
 	merge:     mergeFloat64,
+	equal:     equalFloat64,
 }
 
 // sizeDoubleNoZero returns the size of wire encoding a float64 pointer as a Double.
@@ -4662,6 +4724,7 @@ var coderDoubleNoZero = pointerCoderFuncs{
 	marshal:   appendDoubleNoZero,
 	unmarshal: consumeDouble,
 	merge:     mergeFloat64NoZero,
+	equal:     equalFloat64NoZero,
 }
 
 // sizeDoublePtr returns the size of wire encoding a *float64 pointer as a Double.
@@ -4702,6 +4765,7 @@ var coderDoublePtr = pointerCoderFuncs{
 	marshal:   appendDoublePtr,
 	unmarshal: consumeDoublePtr,
 	merge:     mergeFloat64Ptr,
+	equal:     equalFloat64Ptr,
 }
 
 // sizeDoubleSlice returns the size of wire encoding a []float64 pointer as a repeated Double.
@@ -4759,6 +4823,7 @@ var coderDoubleSlice = pointerCoderFuncs{
 	marshal:   appendDoubleSlice,
 	unmarshal: consumeDoubleSlice,
 	merge:     mergeFloat64Slice,
+	equal:     equalFloat64Slice,
 }
 
 // sizeDoublePackedSlice returns the size of wire encoding a []float64 pointer as a packed repeated Double.
@@ -4791,6 +4856,7 @@ var coderDoublePackedSlice = pointerCoderFuncs{
 	marshal:   appendDoublePackedSlice,
 	unmarshal: consumeDoubleSlice,
 	merge:     mergeFloat64Slice,
+	equal:     equalFloat64Slice,
 }
 
 // sizeDoubleValue returns the size of wire encoding a float64 value as a Double.
@@ -4939,7 +5005,11 @@ func consumeString(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo,
 	if n < 0 {
 		return out, errDecode
 	}
-	*p.String() = string(v)
+	if opts.AliasBuffer() {
+		*p.String() = strs.UnsafeString(v)
+	} else {
+		*p.String() = string(v)
+	}
 	out.n = n
 	return out, nil
 }
@@ -4949,6 +5019,7 @@ var coderString = pointerCoderFuncs{
 	marshal:   appendString,
 	unmarshal: consumeString,
 	merge:     mergeString,
+	equal:     equalString,
 }
 
 // appendStringValidateUTF8 wire encodes a string pointer as a String.
@@ -4974,7 +5045,11 @@ func consumeStringValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *code
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
-	*p.String() = string(v)
+	if opts.AliasBuffer() {
+		*p.String() = strs.UnsafeString(v)
+	} else {
+		*p.String() = string(v)
+	}
 	out.n = n
 	return out, nil
 }
@@ -4984,6 +5059,7 @@ var coderStringValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendStringValidateUTF8,
 	unmarshal: consumeStringValidateUTF8,
 	merge:     mergeString,
+	equal:     equalString,
 }
 
 // sizeStringNoZero returns the size of wire encoding a string pointer as a String.
@@ -5013,6 +5089,7 @@ var coderStringNoZero = pointerCoderFuncs{
 	marshal:   appendStringNoZero,
 	unmarshal: consumeString,
 	merge:     mergeStringNoZero,
+	equal:     equalStringNoZero,
 }
 
 // appendStringNoZeroValidateUTF8 wire encodes a string pointer as a String.
@@ -5035,6 +5112,7 @@ var coderStringNoZeroValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendStringNoZeroValidateUTF8,
 	unmarshal: consumeStringValidateUTF8,
 	merge:     mergeStringNoZero,
+	equal:     equalStringNoZero,
 }
 
 // sizeStringPtr returns the size of wire encoding a *string pointer as a String.
@@ -5066,7 +5144,11 @@ func consumeStringPtr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	if *vp == nil {
 		*vp = new(string)
 	}
-	**vp = string(v)
+	if opts.AliasBuffer() {
+		**vp = strs.UnsafeString(v)
+	} else {
+		**vp = string(v)
+	}
 	out.n = n
 	return out, nil
 }
@@ -5076,6 +5158,7 @@ var coderStringPtr = pointerCoderFuncs{
 	marshal:   appendStringPtr,
 	unmarshal: consumeStringPtr,
 	merge:     mergeStringPtr,
+	equal:     equalStringPtr,
 }
 
 // appendStringPtrValidateUTF8 wire encodes a *string pointer as a String.
@@ -5106,7 +5189,11 @@ func consumeStringPtrValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *c
 	if *vp == nil {
 		*vp = new(string)
 	}
-	**vp = string(v)
+	if opts.AliasBuffer() {
+		**vp = strs.UnsafeString(v)
+	} else {
+		**vp = string(v)
+	}
 	out.n = n
 	return out, nil
 }
@@ -5116,6 +5203,7 @@ var coderStringPtrValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendStringPtrValidateUTF8,
 	unmarshal: consumeStringPtrValidateUTF8,
 	merge:     mergeStringPtr,
+	equal:     equalStringPtr,
 }
 
 // sizeStringSlice returns the size of wire encoding a []string pointer as a repeated String.
@@ -5147,7 +5235,11 @@ func consumeStringSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
-	*sp = append(*sp, string(v))
+	if opts.AliasBuffer() {
+		*sp = append(*sp, strs.UnsafeString(v))
+	} else {
+		*sp = append(*sp, string(v))
+	}
 	out.n = n
 	return out, nil
 }
@@ -5157,6 +5249,7 @@ var coderStringSlice = pointerCoderFuncs{
 	marshal:   appendStringSlice,
 	unmarshal: consumeStringSlice,
 	merge:     mergeStringSlice,
+	equal:     equalStringSlice,
 }
 
 // appendStringSliceValidateUTF8 encodes a []string pointer as a repeated String.
@@ -5185,7 +5278,11 @@ func consumeStringSliceValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f
 		return out, errInvalidUTF8{}
 	}
 	sp := p.StringSlice()
-	*sp = append(*sp, string(v))
+	if opts.AliasBuffer() {
+		*sp = append(*sp, strs.UnsafeString(v))
+	} else {
+		*sp = append(*sp, string(v))
+	}
 	out.n = n
 	return out, nil
 }
@@ -5195,6 +5292,7 @@ var coderStringSliceValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendStringSliceValidateUTF8,
 	unmarshal: consumeStringSliceValidateUTF8,
 	merge:     mergeStringSlice,
+	equal:     equalStringSlice,
 }
 
 // sizeStringValue returns the size of wire encoding a string value as a String.
@@ -5219,6 +5317,9 @@ func consumeStringValue(b []byte, _ protoreflect.Value, _ protowire.Number, wtyp
 		return protoreflect.Value{}, out, errDecode
 	}
 	out.n = n
+	if opts.AliasBuffer() {
+		return protoreflect.ValueOfString(strs.UnsafeString(v)), out, nil
+	}
 	return protoreflect.ValueOfString(string(v)), out, nil
 }
 
@@ -5252,6 +5353,9 @@ func consumeStringValueValidateUTF8(b []byte, _ protoreflect.Value, _ protowire.
 		return protoreflect.Value{}, out, errInvalidUTF8{}
 	}
 	out.n = n
+	if opts.AliasBuffer() {
+		return protoreflect.ValueOfString(strs.UnsafeString(v)), out, nil
+	}
 	return protoreflect.ValueOfString(string(v)), out, nil
 }
 
@@ -5293,7 +5397,11 @@ func consumeStringSliceValue(b []byte, listv protoreflect.Value, _ protowire.Num
 	if n < 0 {
 		return protoreflect.Value{}, out, errDecode
 	}
-	list.Append(protoreflect.ValueOfString(string(v)))
+	if opts.AliasBuffer() {
+		list.Append(protoreflect.ValueOfString(strs.UnsafeString(v)))
+	} else {
+		list.Append(protoreflect.ValueOfString(string(v)))
+	}
 	out.n = n
 	return listv, out, nil
 }
@@ -5328,7 +5436,11 @@ func consumeBytes(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, o
 	if n < 0 {
 		return out, errDecode
 	}
-	*p.Bytes() = append(emptyBuf[:], v...)
+	if opts.AliasBuffer() {
+		*p.Bytes() = v[:len(v):len(v)]
+	} else {
+		*p.Bytes() = append(emptyBuf[:], v...)
+	}
 	out.n = n
 	return out, nil
 }
@@ -5338,6 +5450,7 @@ var coderBytes = pointerCoderFuncs{
 	marshal:   appendBytes,
 	unmarshal: consumeBytes,
 	merge:     mergeBytes,
+	equal:     equalBytes,
 }
 
 // appendBytesValidateUTF8 wire encodes a []byte pointer as a Bytes.
@@ -5363,7 +5476,11 @@ func consumeBytesValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *coder
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
-	*p.Bytes() = append(emptyBuf[:], v...)
+	if opts.AliasBuffer() {
+		*p.Bytes() = v[:len(v):len(v)]
+	} else {
+		*p.Bytes() = append(emptyBuf[:], v...)
+	}
 	out.n = n
 	return out, nil
 }
@@ -5373,6 +5490,7 @@ var coderBytesValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendBytesValidateUTF8,
 	unmarshal: consumeBytesValidateUTF8,
 	merge:     mergeBytes,
+	equal:     equalBytes,
 }
 
 // sizeBytesNoZero returns the size of wire encoding a []byte pointer as a Bytes.
@@ -5407,7 +5525,11 @@ func consumeBytesNoZero(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
-	*p.Bytes() = append(([]byte)(nil), v...)
+	if opts.AliasBuffer() {
+		*p.Bytes() = v[:len(v):len(v)]
+	} else {
+		*p.Bytes() = append(([]byte)(nil), v...)
+	}
 	out.n = n
 	return out, nil
 }
@@ -5417,6 +5539,7 @@ var coderBytesNoZero = pointerCoderFuncs{
 	marshal:   appendBytesNoZero,
 	unmarshal: consumeBytesNoZero,
 	merge:     mergeBytesNoZero,
+	equal:     equalBytesNoZero,
 }
 
 // appendBytesNoZeroValidateUTF8 wire encodes a []byte pointer as a Bytes.
@@ -5446,7 +5569,11 @@ func consumeBytesNoZeroValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
-	*p.Bytes() = append(([]byte)(nil), v...)
+	if opts.AliasBuffer() {
+		*p.Bytes() = v[:len(v):len(v)]
+	} else {
+		*p.Bytes() = append(([]byte)(nil), v...)
+	}
 	out.n = n
 	return out, nil
 }
@@ -5456,6 +5583,7 @@ var coderBytesNoZeroValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendBytesNoZeroValidateUTF8,
 	unmarshal: consumeBytesNoZeroValidateUTF8,
 	merge:     mergeBytesNoZero,
+	equal:     equalBytesNoZero,
 }
 
 // sizeBytesSlice returns the size of wire encoding a [][]byte pointer as a repeated Bytes.
@@ -5487,7 +5615,11 @@ func consumeBytesSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	if n < 0 {
 		return out, errDecode
 	}
-	*sp = append(*sp, append(emptyBuf[:], v...))
+	if opts.AliasBuffer() {
+		*sp = append(*sp, v[:len(v):len(v)])
+	} else {
+		*sp = append(*sp, append(emptyBuf[:], v...))
+	}
 	out.n = n
 	return out, nil
 }
@@ -5497,6 +5629,7 @@ var coderBytesSlice = pointerCoderFuncs{
 	marshal:   appendBytesSlice,
 	unmarshal: consumeBytesSlice,
 	merge:     mergeBytesSlice,
+	equal:     equalBytesSlice,
 }
 
 // appendBytesSliceValidateUTF8 encodes a [][]byte pointer as a repeated Bytes.
@@ -5525,7 +5658,11 @@ func consumeBytesSliceValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *
 		return out, errInvalidUTF8{}
 	}
 	sp := p.BytesSlice()
-	*sp = append(*sp, append(emptyBuf[:], v...))
+	if opts.AliasBuffer() {
+		*sp = append(*sp, v[:len(v):len(v)])
+	} else {
+		*sp = append(*sp, append(emptyBuf[:], v...))
+	}
 	out.n = n
 	return out, nil
 }
@@ -5535,6 +5672,7 @@ var coderBytesSliceValidateUTF8 = pointerCoderFuncs{
 	marshal:   appendBytesSliceValidateUTF8,
 	unmarshal: consumeBytesSliceValidateUTF8,
 	merge:     mergeBytesSlice,
+	equal:     equalBytesSlice,
 }
 
 // sizeBytesValue returns the size of wire encoding a []byte value as a Bytes.
@@ -5559,6 +5697,9 @@ func consumeBytesValue(b []byte, _ protoreflect.Value, _ protowire.Number, wtyp
 		return protoreflect.Value{}, out, errDecode
 	}
 	out.n = n
+	if opts.AliasBuffer() {
+		return protoreflect.ValueOfBytes(v[:len(v):len(v)]), out, nil
+	}
 	return protoreflect.ValueOfBytes(append(emptyBuf[:], v...)), out, nil
 }
 
@@ -5600,7 +5741,11 @@ func consumeBytesSliceValue(b []byte, listv protoreflect.Value, _ protowire.Numb
 	if n < 0 {
 		return protoreflect.Value{}, out, errDecode
 	}
-	list.Append(protoreflect.ValueOfBytes(append(emptyBuf[:], v...)))
+	if opts.AliasBuffer() {
+		list.Append(protoreflect.ValueOfBytes(v[:len(v):len(v)]))
+	} else {
+		list.Append(protoreflect.ValueOfBytes(append(emptyBuf[:], v...)))
+	}
 	out.n = n
 	return listv, out, nil
 }
@@ -9,6 +9,7 @@ import (
 	"sort"
 
 	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/errors"
 	"google.golang.org/protobuf/internal/genid"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -70,10 +71,16 @@ func encoderFuncsForMap(fd pref.FieldDescriptor, ft reflect.Type) (valueMessage
 	switch valField.Kind() {
 	case pref.MessageKind:
 		funcs.merge = mergeMapOfMessage
+		funcs.equal = equalMapOfMessage
 	case pref.BytesKind:
 		funcs.merge = mergeMapOfBytes
+		funcs.equal = equalMapOfBytes
+	case pref.FloatKind, pref.DoubleKind:
+		funcs.merge = mergeMap
+		funcs.equal = equalMapOfFloat
 	default:
 		funcs.merge = mergeMap
+		funcs.equal = equalMap
 	}
 	if valFuncs.isInit != nil {
 		funcs.isInit = func(p pointer, f *coderFieldInfo) error {
@@ -124,14 +131,15 @@ func consumeMap(b []byte, mapv reflect.Value, wtyp protowire.Type, mapi *mapInfo
 		val = mapi.conv.valConv.New()
 	)
 	for len(b) > 0 {
-		num, wtyp, n := protowire.ConsumeTag(b)
-		if n < 0 {
+		num, wtyp, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
 			return out, errDecode
 		}
 		if num > protowire.MaxValidNumber {
 			return out, errDecode
 		}
-		b = b[n:]
+		b = b[tagLen:]
+		var n int
 		err := errUnknown
 		switch num {
 		case genid.MapEntry_Key_field_number:
@@ -159,6 +167,9 @@ func consumeMap(b []byte, mapv reflect.Value, wtyp protowire.Type, mapi *mapInfo
 				return out, errDecode
 			}
 		} else if err != nil {
+			if num == genid.MapEntry_Value_field_number {
+				err = errors.AddMapKeyPath(errors.WireDecodeError(errors.DecodeErrorOther, err, cap(b)+tagLen), key.Interface())
+			}
 			return out, err
 		}
 		b = b[n:]
@@ -181,14 +192,15 @@ func consumeMapOfMessage(b []byte, mapv reflect.Value, wtyp protowire.Type, mapi
 		val = reflect.New(f.mi.GoReflectType.Elem())
 	)
 	for len(b) > 0 {
-		num, wtyp, n := protowire.ConsumeTag(b)
-		if n < 0 {
+		num, wtyp, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
 			return out, errDecode
 		}
 		if num > protowire.MaxValidNumber {
 			return out, errDecode
 		}
-		b = b[n:]
+		b = b[tagLen:]
+		var n int
 		err := errUnknown
 		switch num {
 		case 1:
@@ -223,6 +235,9 @@ func consumeMapOfMessage(b []byte, mapv reflect.Value, wtyp protowire.Type, mapi
 				return out, errDecode
 			}
 		} else if err != nil {
+			if num == genid.MapEntry_Value_field_number {
+				err = errors.AddMapKeyPath(errors.WireDecodeError(errors.DecodeErrorOther, err, cap(b)+tagLen), key.Interface())
+			}
 			return out, err
 		}
 		b = b[n:]
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"sync"
 
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/internal/encoding/messageset"
@@ -20,11 +21,13 @@ import (
 // This is a different type from MessageInfo to keep MessageInfo as general-purpose as
 // possible.
 type coderMessageInfo struct {
-	methods piface.Methods
+	methods       piface.Methods
+	frozenMethods piface.Methods // methods of frozen messages
 
 	orderedCoderFields []*coderFieldInfo
 	denseCoderFields   []*coderFieldInfo
 	coderFields        map[protowire.Number]*coderFieldInfo
+	oneofCoderFields   map[reflect.Type]*coderFieldInfo // by oneof wrapper type
 	sizecacheOffset    offset
 	unknownOffset      offset
 	unknownPtrKind     bool
@@ -32,6 +35,13 @@ type coderMessageInfo struct {
 	needsInitCheck     bool
 	isMessageSet       bool
 	numRequiredFields  uint8
+
+	equalOnce    sync.Once
+	equalMissing bool // some field has no fast-path equal function
+
+	frozenOnce sync.Once
+	frozen     *MessageInfo // stored in frozen messages in place of this
+	mutable    *MessageInfo // set in frozen, the MessageInfo it stands for
 }
 
 type coderFieldInfo struct {
@@ -45,6 +55,7 @@ type coderFieldInfo struct {
 	tagsize    int              // size of the varint-encoded tag
 	isPointer  bool             // true if IsNil may be called on the struct field
 	isRequired bool             // true if field is required
+	isWeak     bool             // true if field is a weak message field
 }
 
 func (mi *MessageInfo) makeCoderMethods(t reflect.Type, si structInfo) {
@@ -64,6 +75,7 @@ func (mi *MessageInfo) makeCoderMethods(t reflect.Type, si structInfo) {
 	}
 
 	mi.coderFields = make(map[protowire.Number]*coderFieldInfo)
+	mi.oneofCoderFields = make(map[reflect.Type]*coderFieldInfo)
 	fields := mi.Desc.Fields()
 	preallocFields := make([]coderFieldInfo, fields.Len())
 	for i := 0; i < fields.Len(); i++ {
@@ -106,6 +118,7 @@ func (mi *MessageInfo) makeCoderMethods(t reflect.Type, si structInfo) {
 			validation: newFieldValidationInfo(mi, si, fd, ft),
 			isPointer:  fd.Cardinality() == pref.Repeated || fd.HasPresence(),
 			isRequired: fd.Cardinality() == pref.Required,
+			isWeak:     fd.IsWeak(),
 		}
 		mi.orderedCoderFields = append(mi.orderedCoderFields, cf)
 		mi.coderFields[cf.num] = cf
@@ -153,13 +166,14 @@ func (mi *MessageInfo) makeCoderMethods(t reflect.Type, si structInfo) {
 	}
 
 	mi.needsInitCheck = needsInitCheck(mi.Desc)
+	mi.methods = mi.Methods
 	if mi.methods.Marshal == nil && mi.methods.Size == nil {
 		mi.methods.Flags |= piface.SupportMarshalDeterministic
 		mi.methods.Marshal = mi.marshal
 		mi.methods.Size = mi.size
 	}
 	if mi.methods.Unmarshal == nil {
-		mi.methods.Flags |= piface.SupportUnmarshalDiscardUnknown
+		mi.methods.Flags |= piface.SupportUnmarshalDiscardUnknown | piface.SupportUnmarshalFieldSelector | piface.SupportUnmarshalReset
 		mi.methods.Unmarshal = mi.unmarshal
 	}
 	if mi.methods.CheckInitialized == nil {
@@ -168,6 +182,16 @@ func (mi *MessageInfo) makeCoderMethods(t reflect.Type, si structInfo) {
 	if mi.methods.Merge == nil {
 		mi.methods.Merge = mi.merge
 	}
+	if mi.methods.Equal == nil {
+		mi.methods.Equal = mi.equal
+	}
+	if mi.methods.Reset == nil {
+		mi.methods.Reset = mi.reset
+	}
+	if mi.methods.Freeze == nil {
+		mi.methods.Freeze = mi.freeze
+	}
+	mi.makeFrozenMethods()
 }
 
 // getUnknownBytes returns a *[]byte for the unknown fields.
@@ -41,11 +41,16 @@ func mergeEnum(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	dst.v.Elem().Set(src.v.Elem())
 }
 
+func equalEnum(x, y pointer, _ *coderFieldInfo) bool {
+	return x.v.Elem().Int() == y.v.Elem().Int()
+}
+
 var coderEnum = pointerCoderFuncs{
 	size:      sizeEnum,
 	marshal:   appendEnum,
 	unmarshal: consumeEnum,
 	merge:     mergeEnum,
+	equal:     equalEnum,
 }
 
 func sizeEnumNoZero(p pointer, f *coderFieldInfo, opts marshalOptions) (size int) {
@@ -73,6 +78,7 @@ var coderEnumNoZero = pointerCoderFuncs{
 	marshal:   appendEnumNoZero,
 	unmarshal: consumeEnum,
 	merge:     mergeEnumNoZero,
+	equal:     equalEnum,
 }
 
 func sizeEnumPtr(p pointer, f *coderFieldInfo, opts marshalOptions) (size int) {
@@ -101,11 +107,19 @@ func mergeEnumPtr(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	}
 }
 
+func equalEnumPtr(x, y pointer, _ *coderFieldInfo) bool {
+	if x.v.Elem().IsNil() || y.v.Elem().IsNil() {
+		return x.v.Elem().IsNil() && y.v.Elem().IsNil()
+	}
+	return x.v.Elem().Elem().Int() == y.v.Elem().Elem().Int()
+}
+
 var coderEnumPtr = pointerCoderFuncs{
 	size:      sizeEnumPtr,
 	marshal:   appendEnumPtr,
 	unmarshal: consumeEnumPtr,
 	merge:     mergeEnumPtr,
+	equal:     equalEnumPtr,
 }
 
 func sizeEnumSlice(p pointer, f *coderFieldInfo, opts marshalOptions) (size int) {
@@ -163,11 +177,25 @@ func mergeEnumSlice(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	dst.v.Elem().Set(reflect.AppendSlice(dst.v.Elem(), src.v.Elem()))
 }
 
+func equalEnumSlice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := x.v.Elem(), y.v.Elem()
+	if sx.Len() != sy.Len() {
+		return false
+	}
+	for i, llen := 0, sx.Len(); i < llen; i++ {
+		if sx.Index(i).Int() != sy.Index(i).Int() {
+			return false
+		}
+	}
+	return true
+}
+
 var coderEnumSlice = pointerCoderFuncs{
 	size:      sizeEnumSlice,
 	marshal:   appendEnumSlice,
 	unmarshal: consumeEnumSlice,
 	merge:     mergeEnumSlice,
+	equal:     equalEnumSlice,
 }
 
 func sizeEnumPackedSlice(p pointer, f *coderFieldInfo, opts marshalOptions) (size int) {
@@ -206,4 +234,5 @@ var coderEnumPackedSlice = pointerCoderFuncs{
 	marshal:   appendEnumPackedSlice,
 	unmarshal: consumeEnumSlice,
 	merge:     mergeEnumSlice,
+	equal:     equalEnumSlice,
 }
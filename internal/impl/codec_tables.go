@@ -21,6 +21,7 @@ type pointerCoderFuncs struct {
 	unmarshal func(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, opts unmarshalOptions) (unmarshalOutput, error)
 	isInit    func(p pointer, f *coderFieldInfo) error
 	merge     func(dst, src pointer, f *coderFieldInfo, opts mergeOptions)
+	equal     func(x, y pointer, f *coderFieldInfo) bool
 }
 
 // valueCoderFuncs is a set of protoreflect.Value encoding functions.
@@ -17,7 +17,7 @@ import (
 	piface "google.golang.org/protobuf/runtime/protoiface"
 )
 
-var errDecode = errors.New("cannot parse invalid wire-format data")
+var errDecode = errors.ErrWireFormat
 
 type unmarshalOptions struct {
 	flags    protoiface.UnmarshalInputFlags
@@ -25,21 +25,50 @@ type unmarshalOptions struct {
 		FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
 		FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error)
 	}
+	selector fieldSelector
+
+	// retained holds the messages kept for reuse by a reset that retains
+	// capacity. It is only set for the duration of an unmarshal operation.
+	retained *retainedSet
+}
+
+// fieldSelector is the type of protoiface.UnmarshalInput.FieldSelector.
+type fieldSelector = interface {
+	SelectField(n protoreflect.FieldNumber) (sub interface{}, ok bool)
 }
 
 func (o unmarshalOptions) Options() proto.UnmarshalOptions {
+	fs, _ := o.selector.(*proto.FieldSelector)
 	return proto.UnmarshalOptions{
 		Merge:          true,
 		AllowPartial:   true,
 		DiscardUnknown: o.DiscardUnknown(),
+		AliasBuffer:    o.AliasBuffer(),
+		RetainCapacity: o.RetainCapacity(),
 		Resolver:       o.resolver,
+		Fields:         fs,
 	}
 }
 
 func (o unmarshalOptions) DiscardUnknown() bool { return o.flags&piface.UnmarshalDiscardUnknown != 0 }
+func (o unmarshalOptions) AliasBuffer() bool    { return o.flags&piface.UnmarshalAliasBuffer != 0 }
+func (o unmarshalOptions) RetainCapacity() bool {
+	return o.flags&piface.UnmarshalRetainCapacity != 0
+}
 
 func (o unmarshalOptions) IsDefault() bool {
-	return o.flags == 0 && o.resolver == preg.GlobalTypes
+	return o.flags == 0 && o.resolver == preg.GlobalTypes && o.selector == nil
+}
+
+// selectField reports whether the field numbered num is selected, and
+// returns the options to use when unmarshaling its value.
+func (o unmarshalOptions) selectField(num protowire.Number) (unmarshalOptions, bool) {
+	sub, ok := o.selector.SelectField(num)
+	if !ok {
+		return o, false
+	}
+	o.selector, _ = sub.(fieldSelector)
+	return o, true
 }
 
 var lazyUnmarshalOptions = unmarshalOptions{
@@ -59,10 +88,25 @@ func (mi *MessageInfo) unmarshal(in piface.UnmarshalInput) (piface.UnmarshalOutp
 	} else {
 		p = in.Message.(*messageReflectWrapper).pointer()
 	}
-	out, err := mi.unmarshalPointer(in.Buf, p, 0, unmarshalOptions{
+	opts := unmarshalOptions{
 		flags:    in.Flags,
 		resolver: in.Resolver,
-	})
+		selector: in.FieldSelector,
+	}
+	if opts.RetainCapacity() {
+		opts.retained = getRetainedSet()
+		defer putRetainedSet(opts.retained)
+	}
+	if in.Flags&piface.UnmarshalReset != 0 {
+		mi.resetPointer(p, true, opts.retained)
+	}
+	out, err := mi.unmarshalPointer(in.Buf, p, 0, opts)
+	if opts.retained != nil {
+		mi.releaseRetained(p, opts.retained)
+	}
+	if err != nil {
+		err = errors.SetWireOffset(err, in.Buf)
+	}
 	var flags piface.UnmarshalOutputFlags
 	if out.initialized {
 		flags |= piface.UnmarshalInitialized
@@ -82,6 +126,10 @@ var errUnknown = errors.New("unknown")
 
 func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.Number, opts unmarshalOptions) (out unmarshalOutput, err error) {
 	mi.init()
+	if opts.retained != nil {
+		// The message is populated, so it is no longer merely retained.
+		opts.retained.remove(mi, p)
+	}
 	if flags.ProtoLegacy && mi.isMessageSet {
 		return unmarshalMessageSet(mi, b, p, opts)
 	}
@@ -102,13 +150,13 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 			var n int
 			tag, n = protowire.ConsumeVarint(b)
 			if n < 0 {
-				return out, errDecode
+				return out, errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b))
 			}
 			b = b[n:]
 		}
 		var num protowire.Number
 		if n := tag >> 3; n < uint64(protowire.MinValidNumber) || n > uint64(protowire.MaxValidNumber) {
-			return out, errDecode
+			return out, errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b)+protowire.SizeVarint(tag))
 		} else {
 			num = protowire.Number(n)
 		}
@@ -116,7 +164,7 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 
 		if wtyp == protowire.EndGroupType {
 			if num != groupTag {
-				return out, errDecode
+				return out, errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b)+protowire.SizeVarint(tag))
 			}
 			groupTag = 0
 			break
@@ -130,13 +178,18 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 		}
 		var n int
 		err := errUnknown
+		fopts, selected := opts, true
+		if opts.selector != nil {
+			fopts, selected = opts.selectField(num)
+		}
 		switch {
+		case !selected:
 		case f != nil:
 			if f.funcs.unmarshal == nil {
 				break
 			}
 			var o unmarshalOutput
-			o, err = f.funcs.unmarshal(b, p.Apply(f.offset), wtyp, f, opts)
+			o, err = f.funcs.unmarshal(b, p.Apply(f.offset), wtyp, f, fopts)
 			n = o.n
 			if err != nil {
 				break
@@ -157,7 +210,7 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 				break
 			}
 			var o unmarshalOutput
-			o, err = mi.unmarshalExtension(b, num, wtyp, *exts, opts)
+			o, err = mi.unmarshalExtension(b, num, wtyp, *exts, fopts)
 			if err != nil {
 				break
 			}
@@ -168,11 +221,11 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 		}
 		if err != nil {
 			if err != errUnknown {
-				return out, err
+				return out, mi.fieldDecodeError(err, p, num, cap(b)+protowire.SizeVarint(tag), opts)
 			}
 			n = protowire.ConsumeFieldValue(num, wtyp, b)
 			if n < 0 {
-				return out, errDecode
+				return out, errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b)+protowire.SizeVarint(tag))
 			}
 			if !opts.DiscardUnknown() && mi.unknownOffset.IsValid() {
 				u := mi.mutableUnknownBytes(p)
@@ -183,7 +236,7 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 		b = b[n:]
 	}
 	if groupTag != 0 {
-		return out, errDecode
+		return out, errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b))
 	}
 	if mi.numRequiredFields > 0 && bits.OnesCount64(requiredMask) != int(mi.numRequiredFields) {
 		initialized = false
@@ -195,6 +248,27 @@ func (mi *MessageInfo) unmarshalPointer(b []byte, p pointer, groupTag protowire.
 	return out, nil
 }
 
+// fieldDecodeError annotates an error encountered while parsing field num of
+// the message at p with the path of that field. The remain argument is the
+// capacity of the input buffer at the start of the field's tag.
+func (mi *MessageInfo) fieldDecodeError(err error, p pointer, num protowire.Number, remain int, opts unmarshalOptions) error {
+	err = errors.WireDecodeError(errors.DecodeErrorOther, err, remain)
+	var fd protoreflect.FieldDescriptor
+	if fi := mi.fields[num]; fi != nil {
+		fd = fi.fieldDesc
+		if fd.IsList() && fd.Message() != nil {
+			// A failed message element is never appended,
+			// so its index is the current length of the list.
+			err = errors.AddIndexPath(err, fi.get(p).List().Len())
+		}
+	} else if xt, _ := opts.resolver.FindExtensionByNumber(mi.Desc.FullName(), num); xt != nil {
+		fd = xt.TypeDescriptor()
+	} else {
+		return err
+	}
+	return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
+}
+
 func (mi *MessageInfo) unmarshalExtension(b []byte, num protowire.Number, wtyp protowire.Type, exts map[int32]ExtensionField, opts unmarshalOptions) (out unmarshalOutput, err error) {
 	x := exts[int32(num)]
 	xt := x.Type()
@@ -205,7 +279,8 @@ func (mi *MessageInfo) unmarshalExtension(b []byte, num protowire.Number, wtyp p
 			if err == preg.NotFound {
 				return out, errUnknown
 			}
-			return out, errors.New("%v: unable to resolve extension %v: %v", mi.Desc.FullName(), num, err)
+			err = errors.New("%v: unable to resolve extension %v: %v", mi.Desc.FullName(), num, err)
+			return out, errors.WireDecodeError(errors.DecodeErrorUnresolvedType, err, -1)
 		}
 	}
 	xi := getExtensionFieldInfo(xt)
@@ -10,12 +10,18 @@ import (
 	"sync/atomic"
 
 	"google.golang.org/protobuf/internal/flags"
+	"google.golang.org/protobuf/internal/sizetable"
 	proto "google.golang.org/protobuf/proto"
 	piface "google.golang.org/protobuf/runtime/protoiface"
 )
 
 type marshalOptions struct {
 	flags piface.MarshalInputFlags
+
+	// sizes records the sizes of messages without a sizecache field,
+	// and of all messages when marshaling read-only.
+	// It is only set for the duration of a marshal operation.
+	sizes *sizetable.Table
 }
 
 func (o marshalOptions) Options() proto.MarshalOptions {
@@ -28,6 +34,13 @@ func (o marshalOptions) Options() proto.MarshalOptions {
 
 func (o marshalOptions) Deterministic() bool { return o.flags&piface.MarshalDeterministic != 0 }
 func (o marshalOptions) UseCachedSize() bool { return o.flags&piface.MarshalUseCachedSize != 0 }
+func (o marshalOptions) ReadOnly() bool      { return o.flags&piface.MarshalReadOnly != 0 }
+
+// cachesSize reports whether the size of a message may be stored in
+// its sizecache field.
+func (mi *MessageInfo) cachesSize(opts marshalOptions) bool {
+	return mi.sizecacheOffset.IsValid() && !opts.ReadOnly()
+}
 
 // size is protoreflect.Methods.Size.
 func (mi *MessageInfo) size(in piface.SizeInput) piface.SizeOutput {
@@ -37,9 +50,14 @@ func (mi *MessageInfo) size(in piface.SizeInput) piface.SizeOutput {
 	} else {
 		p = in.Message.(*messageReflectWrapper).pointer()
 	}
-	size := mi.sizePointer(p, marshalOptions{
+	opts := marshalOptions{
 		flags: in.Flags,
-	})
+	}
+	if !mi.cachesSize(opts) {
+		opts.sizes = sizetable.Get()
+		defer sizetable.Put(opts.sizes)
+	}
+	size := mi.sizePointer(p, opts)
 	return piface.SizeOutput{Size: size}
 }
 
@@ -48,18 +66,27 @@ func (mi *MessageInfo) sizePointer(p pointer, opts marshalOptions) (size int) {
 	if p.IsNil() {
 		return 0
 	}
-	if opts.UseCachedSize() && mi.sizecacheOffset.IsValid() {
+	if opts.UseCachedSize() && mi.cachesSize(opts) {
 		if size := atomic.LoadInt32(p.Apply(mi.sizecacheOffset).Int32()); size >= 0 {
 			return int(size)
 		}
 	}
+	if opts.sizes != nil && !mi.cachesSize(opts) {
+		k := sizeKey{mi, p.key()}
+		if size, ok := opts.sizes.Load(k); ok {
+			return size
+		}
+		size := mi.sizePointerSlow(p, opts)
+		opts.sizes.Store(k, size)
+		return size
+	}
 	return mi.sizePointerSlow(p, opts)
 }
 
 func (mi *MessageInfo) sizePointerSlow(p pointer, opts marshalOptions) (size int) {
 	if flags.ProtoLegacy && mi.isMessageSet {
 		size = sizeMessageSet(mi, p, opts)
-		if mi.sizecacheOffset.IsValid() {
+		if mi.cachesSize(opts) {
 			atomic.StoreInt32(p.Apply(mi.sizecacheOffset).Int32(), int32(size))
 		}
 		return size
@@ -83,7 +110,7 @@ func (mi *MessageInfo) sizePointerSlow(p pointer, opts marshalOptions) (size int
 			size += len(*u)
 		}
 	}
-	if mi.sizecacheOffset.IsValid() {
+	if mi.cachesSize(opts) {
 		if size > math.MaxInt32 {
 			// The size is too large for the int32 sizecache field.
 			// We will need to recompute the size when encoding;
@@ -104,12 +131,25 @@ func (mi *MessageInfo) marshal(in piface.MarshalInput) (out piface.MarshalOutput
 	} else {
 		p = in.Message.(*messageReflectWrapper).pointer()
 	}
-	b, err := mi.marshalAppendPointer(in.Buf, p, marshalOptions{
+	opts := marshalOptions{
 		flags: in.Flags,
-	})
+	}
+	if !mi.cachesSize(opts) {
+		// Without a sizecache field, the size of every submessage would be
+		// recomputed at each level of nesting. Record them for this call.
+		opts.sizes = sizetable.Get()
+		defer sizetable.Put(opts.sizes)
+	}
+	b, err := mi.marshalAppendPointer(in.Buf, p, opts)
 	return piface.MarshalOutput{Buf: b}, err
 }
 
+// sizeKey identifies a message in a size table by its address.
+type sizeKey struct {
+	mi *MessageInfo
+	p  interface{}
+}
+
 func (mi *MessageInfo) marshalAppendPointer(b []byte, p pointer, opts marshalOptions) ([]byte, error) {
 	mi.init()
 	if p.IsNil() {
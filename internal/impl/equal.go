@@ -0,0 +1,245 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+
+	"google.golang.org/protobuf/internal/rawfields"
+	"google.golang.org/protobuf/proto"
+	piface "google.golang.org/protobuf/runtime/protoiface"
+)
+
+// equal is protoreflect.Methods.Equal.
+func (mi *MessageInfo) equal(in piface.EqualInput) piface.EqualOutput {
+	xp, ok := mi.getPointer(in.MessageA)
+	if !ok {
+		return piface.EqualOutput{}
+	}
+	yp, ok := mi.getPointer(in.MessageB)
+	if !ok {
+		return piface.EqualOutput{}
+	}
+	mi.init()
+	if mi.hasExtensions(xp) || mi.hasExtensions(yp) {
+		// Extension values are compared by the reflective implementation.
+		return piface.EqualOutput{}
+	}
+	if mi.equalIncomplete() {
+		// Fields without a fast-path comparison are compared by the
+		// reflective implementation.
+		return piface.EqualOutput{}
+	}
+	if !mi.equalPointer(xp, yp) {
+		return piface.EqualOutput{Flags: piface.EqualComplete}
+	}
+	return piface.EqualOutput{Flags: piface.EqualComplete | piface.EqualEqual}
+}
+
+// hasExtensions reports whether the message has any extension fields set.
+func (mi *MessageInfo) hasExtensions(p pointer) bool {
+	if p.IsNil() || !mi.extensionOffset.IsValid() {
+		return false
+	}
+	return len(*p.Apply(mi.extensionOffset).Extensions()) > 0
+}
+
+// equalIncomplete reports whether a field of the message, or of a message
+// it may contain, has no fast-path equal function.
+func (mi *MessageInfo) equalIncomplete() bool {
+	mi.equalOnce.Do(func() {
+		mi.equalMissing = mi.lacksEqual(make(map[*MessageInfo]bool))
+	})
+	return mi.equalMissing
+}
+
+func (mi *MessageInfo) lacksEqual(seen map[*MessageInfo]bool) bool {
+	if seen[mi] {
+		return false
+	}
+	seen[mi] = true
+	mi.init()
+	for _, f := range mi.orderedCoderFields {
+		// Oneofs are compared by the function of their first field.
+		if f.funcs.equal == nil && f.ft.Kind() != reflect.Interface {
+			return true
+		}
+		if f.mi != nil && f.mi.lacksEqual(seen) {
+			return true
+		}
+	}
+	for _, f := range mi.oneofCoderFields {
+		if f.funcs.equal == nil {
+			return true
+		}
+		if f.mi != nil && f.mi.lacksEqual(seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalPointer reports whether the known and unknown fields of two messages
+// are equal. Extension fields are not compared.
+func (mi *MessageInfo) equalPointer(x, y pointer) bool {
+	mi.init()
+	if x.IsNil() || y.IsNil() {
+		if x.IsNil() && y.IsNil() {
+			return true
+		}
+		// A nil message is equal to an empty one.
+		empty := pointerOfValue(reflect.New(mi.GoReflectType.Elem()))
+		if x.IsNil() {
+			x = empty
+		} else {
+			y = empty
+		}
+	}
+	for _, f := range mi.orderedCoderFields {
+		if f.funcs.equal == nil {
+			continue
+		}
+		xfptr := x.Apply(f.offset)
+		yfptr := y.Apply(f.offset)
+		if f.isPointer {
+			xnil, ynil := xfptr.Elem().IsNil(), yfptr.Elem().IsNil()
+			if xnil && ynil {
+				continue
+			}
+			if xnil != ynil && f.ft.Kind() == reflect.Ptr {
+				// A populated singular field is not equal to an unpopulated one.
+				return false
+			}
+		}
+		if !f.funcs.equal(xfptr, yfptr, f) {
+			return false
+		}
+	}
+	if mi.unknownOffset.IsValid() {
+		var xu, yu []byte
+		if u := mi.getUnknownBytes(x); u != nil {
+			xu = *u
+		}
+		if u := mi.getUnknownBytes(y); u != nil {
+			yu = *u
+		}
+		if !rawfields.Equal(xu, yu) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalMessage compares two message values, where a nil message is equal
+// to an empty one. The presence of singular message fields is compared by
+// equalPointer, so this only matters for a oneof that holds a nil message.
+func equalMessage(x, y pointer, f *coderFieldInfo) bool {
+	if f.mi != nil {
+		return f.mi.equalPointer(x.Elem(), y.Elem())
+	}
+	mx := x.AsValueOf(f.ft).Elem()
+	my := y.AsValueOf(f.ft).Elem()
+	if mx.IsNil() && my.IsNil() {
+		return true
+	}
+	if mx.IsNil() {
+		mx = reflect.New(f.ft.Elem())
+	}
+	if my.IsNil() {
+		my = reflect.New(f.ft.Elem())
+	}
+	return proto.Equal(asMessage(mx), asMessage(my))
+}
+
+func equalMessageSlice(x, y pointer, f *coderFieldInfo) bool {
+	sx := x.PointerSlice()
+	sy := y.PointerSlice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if f.mi != nil {
+			if !f.mi.equalPointer(vx, sy[i]) {
+				return false
+			}
+			continue
+		}
+		mt := f.ft.Elem().Elem()
+		if !proto.Equal(asMessage(vx.AsValueOf(mt)), asMessage(sy[i].AsValueOf(mt))) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalBytes(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Bytes(), *y.Bytes()
+	return (vx == nil) == (vy == nil) && bytes.Equal(vx, vy)
+}
+
+func equalBytesNoZero(x, y pointer, _ *coderFieldInfo) bool {
+	return bytes.Equal(*x.Bytes(), *y.Bytes())
+}
+
+func equalBytesSlice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.BytesSlice(), *y.BytesSlice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if !bytes.Equal(vx, sy[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalMapFunc reports whether two maps have the same set of keys,
+// where the pair of values for each key is equal according to eq.
+func equalMapFunc(x, y pointer, f *coderFieldInfo, eq func(vx, vy reflect.Value) bool) bool {
+	mx := x.AsValueOf(f.ft).Elem()
+	my := y.AsValueOf(f.ft).Elem()
+	if mx.Len() != my.Len() {
+		return false
+	}
+	iter := mapRange(mx)
+	for iter.Next() {
+		vy := my.MapIndex(iter.Key())
+		if !vy.IsValid() || !eq(iter.Value(), vy) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalMap(x, y pointer, f *coderFieldInfo) bool {
+	return equalMapFunc(x, y, f, func(vx, vy reflect.Value) bool {
+		return vx.Interface() == vy.Interface()
+	})
+}
+
+func equalMapOfFloat(x, y pointer, f *coderFieldInfo) bool {
+	return equalMapFunc(x, y, f, func(vx, vy reflect.Value) bool {
+		fx, fy := vx.Float(), vy.Float()
+		return fx == fy || (math.IsNaN(fx) && math.IsNaN(fy))
+	})
+}
+
+func equalMapOfBytes(x, y pointer, f *coderFieldInfo) bool {
+	return equalMapFunc(x, y, f, func(vx, vy reflect.Value) bool {
+		return bytes.Equal(vx.Bytes(), vy.Bytes())
+	})
+}
+
+func equalMapOfMessage(x, y pointer, f *coderFieldInfo) bool {
+	return equalMapFunc(x, y, f, func(vx, vy reflect.Value) bool {
+		if f.mi != nil {
+			return f.mi.equalPointer(pointerOfValue(vx), pointerOfValue(vy))
+		}
+		return proto.Equal(asMessage(vx), asMessage(vy))
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by generate-types. DO NOT EDIT.
+
+package impl
+
+import (
+	"math"
+)
+
+func equalBool(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Bool(), *y.Bool()
+	return vx == vy
+}
+
+func equalBoolNoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Bool(), *y.Bool()
+	return vx == vy
+}
+
+func equalBoolPtr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.BoolPtr(), *y.BoolPtr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py
+}
+
+func equalBoolSlice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.BoolSlice(), *y.BoolSlice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vx != sy[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalInt32(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Int32(), *y.Int32()
+	return vx == vy
+}
+
+func equalInt32NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Int32(), *y.Int32()
+	return vx == vy
+}
+
+func equalInt32Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.Int32Ptr(), *y.Int32Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py
+}
+
+func equalInt32Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.Int32Slice(), *y.Int32Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vx != sy[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalUint32(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Uint32(), *y.Uint32()
+	return vx == vy
+}
+
+func equalUint32NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Uint32(), *y.Uint32()
+	return vx == vy
+}
+
+func equalUint32Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.Uint32Ptr(), *y.Uint32Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py
+}
+
+func equalUint32Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.Uint32Slice(), *y.Uint32Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vx != sy[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalInt64(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Int64(), *y.Int64()
+	return vx == vy
+}
+
+func equalInt64NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Int64(), *y.Int64()
+	return vx == vy
+}
+
+func equalInt64Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.Int64Ptr(), *y.Int64Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py
+}
+
+func equalInt64Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.Int64Slice(), *y.Int64Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vx != sy[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalUint64(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Uint64(), *y.Uint64()
+	return vx == vy
+}
+
+func equalUint64NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Uint64(), *y.Uint64()
+	return vx == vy
+}
+
+func equalUint64Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.Uint64Ptr(), *y.Uint64Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py
+}
+
+func equalUint64Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.Uint64Slice(), *y.Uint64Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vx != sy[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFloat32(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Float32(), *y.Float32()
+	return vx == vy || (math.IsNaN(float64(vx)) && math.IsNaN(float64(vy)))
+}
+
+func equalFloat32NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Float32(), *y.Float32()
+	if vx == vy {
+		// Negative zero is populated, while positive zero is not.
+		return math.Signbit(float64(vx)) == math.Signbit(float64(vy))
+	}
+	return math.IsNaN(float64(vx)) && math.IsNaN(float64(vy))
+}
+
+func equalFloat32Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.Float32Ptr(), *y.Float32Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py || (math.IsNaN(float64(*px)) && math.IsNaN(float64(*py)))
+}
+
+func equalFloat32Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.Float32Slice(), *y.Float32Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vy := sy[i]; vx != vy && !(math.IsNaN(float64(vx)) && math.IsNaN(float64(vy))) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFloat64(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Float64(), *y.Float64()
+	return vx == vy || (math.IsNaN(float64(vx)) && math.IsNaN(float64(vy)))
+}
+
+func equalFloat64NoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.Float64(), *y.Float64()
+	if vx == vy {
+		// Negative zero is populated, while positive zero is not.
+		return math.Signbit(float64(vx)) == math.Signbit(float64(vy))
+	}
+	return math.IsNaN(float64(vx)) && math.IsNaN(float64(vy))
+}
+
+func equalFloat64Ptr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.Float64Ptr(), *y.Float64Ptr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py || (math.IsNaN(float64(*px)) && math.IsNaN(float64(*py)))
+}
+
+func equalFloat64Slice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.Float64Slice(), *y.Float64Slice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vy := sy[i]; vx != vy && !(math.IsNaN(float64(vx)) && math.IsNaN(float64(vy))) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalString(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.String(), *y.String()
+	return vx == vy
+}
+
+func equalStringNoZero(x, y pointer, _ *coderFieldInfo) bool {
+	vx, vy := *x.String(), *y.String()
+	return vx == vy
+}
+
+func equalStringPtr(x, y pointer, _ *coderFieldInfo) bool {
+	px, py := *x.StringPtr(), *y.StringPtr()
+	if px == nil || py == nil {
+		return px == nil && py == nil
+	}
+	return *px == *py
+}
+
+func equalStringSlice(x, y pointer, _ *coderFieldInfo) bool {
+	sx, sy := *x.StringSlice(), *y.StringSlice()
+	if len(sx) != len(sy) {
+		return false
+	}
+	for i, vx := range sx {
+		if vx != sy[i] {
+			return false
+		}
+	}
+	return true
+}
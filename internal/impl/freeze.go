@@ -0,0 +1,123 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	pref "google.golang.org/protobuf/reflect/protoreflect"
+	piface "google.golang.org/protobuf/runtime/protoiface"
+)
+
+// A message is frozen by storing in its MessageState a stand-in MessageInfo,
+// whose mutable field refers to the MessageInfo of the message type.
+// The reflective methods of a frozen message use the MessageInfo of its type,
+// except that methods which modify the message panic, and ProtoMethods
+// returns the frozenMethods of the message type.
+//
+// Only messages which hold a MessageState can be frozen.
+
+// freeze is protoreflect.Methods.Freeze.
+func (mi *MessageInfo) freeze(in piface.FreezeInput) piface.FreezeOutput {
+	m, ok := in.Message.(*messageState)
+	if !ok || m.messageInfo() != mi {
+		return piface.FreezeOutput{}
+	}
+	if !m.isFrozen() {
+		m.StoreMessageInfo(mi.frozenMessageInfo())
+	}
+	return piface.FreezeOutput{Flags: piface.FreezeComplete}
+}
+
+// frozenMessageInfo returns the stand-in MessageInfo of frozen messages of
+// the type of mi.
+func (mi *MessageInfo) frozenMessageInfo() *MessageInfo {
+	mi.frozenOnce.Do(func() {
+		mi.frozen = &MessageInfo{
+			GoReflectType: mi.GoReflectType,
+			Desc:          mi.Desc,
+		}
+		mi.frozen.mutable = mi
+	})
+	return mi.frozen
+}
+
+// makeFrozenMethods populates the methods of frozen messages, which omit
+// those that modify the message and marshal without recording sizes.
+func (mi *MessageInfo) makeFrozenMethods() {
+	methods := &mi.methods
+	mi.frozenMethods = piface.Methods{
+		Flags:            methods.Flags & piface.SupportMarshalDeterministic,
+		Equal:            methods.Equal,
+		CheckInitialized: methods.CheckInitialized,
+		Freeze:           methods.Freeze,
+	}
+	if size := methods.Size; size != nil {
+		mi.frozenMethods.Size = func(in piface.SizeInput) piface.SizeOutput {
+			in.Flags |= piface.MarshalReadOnly
+			return size(in)
+		}
+	}
+	if marshal := methods.Marshal; marshal != nil {
+		mi.frozenMethods.Marshal = func(in piface.MarshalInput) (piface.MarshalOutput, error) {
+			in.Flags |= piface.MarshalReadOnly
+			return marshal(in)
+		}
+	}
+}
+
+func panicFrozen(m pref.Message) {
+	panic("proto: cannot modify frozen " + string(m.Descriptor().FullName()) + " message")
+}
+
+func (m *messageState) checkMutable() {
+	if m.isFrozen() {
+		panicFrozen(m)
+	}
+}
+
+// readOnly returns v, or a read-only view of it if m is frozen.
+func (m *messageState) readOnly(v pref.Value) pref.Value {
+	if m.isFrozen() {
+		return frozenValue(v)
+	}
+	return v
+}
+
+// Messages accessed through a messageReflectWrapper cannot be frozen.
+func (m *messageReflectWrapper) isFrozen() bool                   { return false }
+func (m *messageReflectWrapper) checkMutable()                    {}
+func (m *messageReflectWrapper) readOnly(v pref.Value) pref.Value { return v }
+
+// frozenValue returns a read-only view of a list or map,
+// and returns any other value unchanged.
+func frozenValue(v pref.Value) pref.Value {
+	switch x := v.Interface().(type) {
+	case pref.List:
+		return pref.ValueOfList(frozenList{x})
+	case pref.Map:
+		return pref.ValueOfMap(frozenMap{x})
+	}
+	return v
+}
+
+// frozenList is a read-only view of a list in a frozen message.
+type frozenList struct {
+	pref.List
+}
+
+func (l frozenList) Set(int, pref.Value)       { panic("proto: cannot modify list of frozen message") }
+func (l frozenList) Append(pref.Value)         { panic("proto: cannot modify list of frozen message") }
+func (l frozenList) AppendMutable() pref.Value { panic("proto: cannot modify list of frozen message") }
+func (l frozenList) Truncate(int)              { panic("proto: cannot modify list of frozen message") }
+
+// frozenMap is a read-only view of a map in a frozen message.
+type frozenMap struct {
+	pref.Map
+}
+
+func (m frozenMap) Set(pref.MapKey, pref.Value) { panic("proto: cannot modify map of frozen message") }
+func (m frozenMap) Clear(pref.MapKey)           { panic("proto: cannot modify map of frozen message") }
+func (m frozenMap) Mutable(pref.MapKey) pref.Value {
+	panic("proto: cannot modify map of frozen message")
+}
@@ -0,0 +1,507 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/internal/impl"
+	"google.golang.org/protobuf/internal/protobuild"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/testing/protopack"
+
+	codecpb "google.golang.org/protobuf/internal/testprotos/codec"
+	codec3pb "google.golang.org/protobuf/internal/testprotos/codec3"
+)
+
+// The messages in the codec and codec3 packages are generated with the
+// generate_codec option, so their fast-path methods are provided by
+// generated code. These tests check that the generated code behaves
+// identically to the table-driven implementation for the same Go types.
+
+var tableInfos sync.Map // map[reflect.Type]*impl.MessageInfo
+
+// tableMessage returns a reflective view of m whose fast-path methods
+// are provided by the table-driven implementation.
+func tableMessage(m proto.Message) protoreflect.Message {
+	t := reflect.TypeOf(m)
+	if mi, ok := tableInfos.Load(t); ok {
+		return mi.(*impl.MessageInfo).MessageOf(m)
+	}
+	gen := m.ProtoReflect().(interface{ ProtoMessageInfo() *impl.MessageInfo }).ProtoMessageInfo()
+	mi := &impl.MessageInfo{
+		GoReflectType: gen.GoReflectType,
+		Desc:          gen.Desc,
+		Exporter:      gen.Exporter,
+		OneofWrappers: gen.OneofWrappers,
+	}
+	mi2, _ := tableInfos.LoadOrStore(t, mi)
+	return mi2.(*impl.MessageInfo).MessageOf(m)
+}
+
+func codecMessages(template protobuild.Message) []proto.Message {
+	messages := []proto.Message{
+		&codecpb.TestAllTypes{},
+		&codec3pb.TestAllTypes{},
+	}
+	for _, m := range messages {
+		template.Build(m.ProtoReflect())
+	}
+	return messages
+}
+
+var codecTestMessages = []struct {
+	desc     string
+	template protobuild.Message
+}{{
+	desc:     "empty",
+	template: protobuild.Message{},
+}, {
+	desc: "singular scalars",
+	template: protobuild.Message{
+		"singular_int32":    -1001,
+		"singular_int64":    -1002,
+		"singular_uint32":   1003,
+		"singular_uint64":   1004,
+		"singular_sint32":   -1005,
+		"singular_sint64":   -1006,
+		"singular_fixed32":  1007,
+		"singular_fixed64":  1008,
+		"singular_sfixed32": -1009,
+		"singular_sfixed64": -1010,
+		"singular_float":    1011.5,
+		"singular_double":   math.Inf(-1),
+		"singular_bool":     true,
+		"singular_string":   "string",
+		"singular_bytes":    []byte("bytes"),
+	},
+}, {
+	desc: "singular negative zero and NaN",
+	template: protobuild.Message{
+		"singular_float":  float32(math.Copysign(0, -1)),
+		"singular_double": math.NaN(),
+	},
+}, {
+	desc: "optional zero values",
+	template: protobuild.Message{
+		"optional_int32":          0,
+		"optional_int64":          0,
+		"optional_uint32":         0,
+		"optional_uint64":         0,
+		"optional_sint32":         0,
+		"optional_sint64":         0,
+		"optional_fixed32":        0,
+		"optional_fixed64":        0,
+		"optional_sfixed32":       0,
+		"optional_sfixed64":       0,
+		"optional_float":          0,
+		"optional_double":         0,
+		"optional_bool":           false,
+		"optional_string":         "",
+		"optional_bytes":          []byte{},
+		"optional_nested_message": protobuild.Message{},
+		"optional_nested_enum":    "FOO",
+		"optional_foreign_enum":   "FOREIGN_ZERO",
+	},
+}, {
+	desc: "messages and enums",
+	template: protobuild.Message{
+		"singular_nested_message": protobuild.Message{
+			"a": 1,
+			"corecursive": protobuild.Message{
+				"singular_int32":          2,
+				"optional_nested_message": protobuild.Message{"a": 3},
+			},
+		},
+		"singular_foreign_message": protobuild.Message{"c": 4, "d": 5},
+		"singular_import_message":  protobuild.Message{},
+		"singular_nested_enum":     "NEG",
+		"singular_foreign_enum":    "FOREIGN_BAR",
+		"optional_foreign_message": protobuild.Message{"c": -6},
+		"optional_import_message":  protobuild.Message{},
+		"optional_nested_enum":     "BAZ",
+	},
+}, {
+	desc: "repeated fields",
+	template: protobuild.Message{
+		"repeated_int32":    []int32{1, -2, math.MaxInt32, math.MinInt32},
+		"repeated_int64":    []int64{3, -4, math.MaxInt64, math.MinInt64},
+		"repeated_uint32":   []uint32{5, math.MaxUint32},
+		"repeated_uint64":   []uint64{6, math.MaxUint64},
+		"repeated_sint32":   []int32{-7, 8, math.MinInt32},
+		"repeated_sint64":   []int64{-9, 10, math.MinInt64},
+		"repeated_fixed32":  []uint32{11, 12},
+		"repeated_fixed64":  []uint64{13, 14},
+		"repeated_sfixed32": []int32{-15, 16},
+		"repeated_sfixed64": []int64{-17, 18},
+		"repeated_float":    []float32{19.5, float32(math.Inf(1))},
+		"repeated_double":   []float64{-20.5, math.NaN()},
+		"repeated_bool":     []bool{true, false, true},
+		"repeated_string":   []string{"", "twenty-one"},
+		"repeated_bytes":    [][]byte{{}, []byte("twenty-two")},
+		"repeated_nested_message": []protobuild.Message{
+			{"a": 23},
+			{},
+			{"corecursive": protobuild.Message{"repeated_int32": []int32{24}}},
+		},
+		"repeated_foreign_message": []protobuild.Message{{"c": 25}},
+		"repeated_importmessage":   []protobuild.Message{{}, {}},
+		"repeated_nested_enum":     []string{"FOO", "NEG"},
+		"repeated_foreign_enum":    []string{"FOREIGN_BAZ"},
+	},
+}, {
+	desc: "maps",
+	template: protobuild.Message{
+		"map_int32_int32":       map[int32]int32{1: 2, -3: -4, 0: 0},
+		"map_int64_int64":       map[int64]int64{5: 6, -7: -8},
+		"map_uint32_uint32":     map[uint32]uint32{9: 10, 11: 12},
+		"map_uint64_uint64":     map[uint64]uint64{13: 14, 15: 16},
+		"map_sint32_sint32":     map[int32]int32{-17: 18, 19: -20},
+		"map_sint64_sint64":     map[int64]int64{-21: 22, 23: -24},
+		"map_fixed32_fixed32":   map[uint32]uint32{25: 26, 27: 28},
+		"map_fixed64_fixed64":   map[uint64]uint64{29: 30, 31: 32},
+		"map_sfixed32_sfixed32": map[int32]int32{-33: 34, 35: -36},
+		"map_sfixed64_sfixed64": map[int64]int64{-37: 38, 39: -40},
+		"map_int32_float":       map[int32]float32{41: 42.5, 43: 0},
+		"map_int32_double":      map[int32]float64{44: 45.5, 46: math.NaN()},
+		"map_bool_bool":         map[bool]bool{true: false, false: true},
+		"map_string_string":     map[string]string{"": "", "a": "b", "c": "d"},
+		"map_string_bytes":      map[string][]byte{"e": nil, "f": []byte("g")},
+		"map_string_nested_message": map[string]protobuild.Message{
+			"h": {},
+			"i": {"a": 47},
+			"j": {"corecursive": protobuild.Message{"map_int32_int32": map[int32]int32{48: 49}}},
+		},
+		"map_string_nested_enum": map[string]string{"k": "FOO", "l": "NEG"},
+	},
+}, {
+	desc:     "oneof uint32",
+	template: protobuild.Message{"oneof_uint32": 0},
+}, {
+	desc:     "oneof message",
+	template: protobuild.Message{"oneof_nested_message": protobuild.Message{"a": 50}},
+}, {
+	desc:     "oneof empty message",
+	template: protobuild.Message{"oneof_nested_message": protobuild.Message{}},
+}, {
+	desc:     "oneof string",
+	template: protobuild.Message{"oneof_string": ""},
+}, {
+	desc:     "oneof bytes",
+	template: protobuild.Message{"oneof_bytes": []byte{}},
+}, {
+	desc:     "oneof bool",
+	template: protobuild.Message{"oneof_bool": false},
+}, {
+	desc:     "oneof uint64",
+	template: protobuild.Message{"oneof_uint64": 51},
+}, {
+	desc:     "oneof float",
+	template: protobuild.Message{"oneof_float": 52.5},
+}, {
+	desc:     "oneof double",
+	template: protobuild.Message{"oneof_double": 53.5},
+}, {
+	desc:     "oneof enum",
+	template: protobuild.Message{"oneof_enum": "BAR"},
+}, {
+	desc: "unknown fields",
+	template: protobuild.Message{
+		"singular_int32": 54,
+		protobuild.Unknown: protopack.Message{
+			protopack.Tag{100000, protopack.VarintType}, protopack.Varint(55),
+			protopack.Tag{100001, protopack.StartGroupType},
+			protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(56),
+			protopack.Tag{100001, protopack.EndGroupType},
+		}.Marshal(),
+	},
+}}
+
+func TestGeneratedCodecMarshal(t *testing.T) {
+	for _, test := range codecTestMessages {
+		for _, m := range codecMessages(test.template) {
+			for _, deterministic := range []bool{false, true} {
+				opts := proto.MarshalOptions{Deterministic: deterministic}
+				got, err := opts.Marshal(m)
+				if err != nil {
+					t.Errorf("%v: %T: Marshal() error: %v", test.desc, m, err)
+					continue
+				}
+				if size := proto.Size(m); size != len(got) {
+					t.Errorf("%v: %T: Size() = %v, want %v", test.desc, m, size, len(got))
+				}
+				out, err := opts.MarshalState(protoiface.MarshalInput{Message: tableMessage(m)})
+				if err != nil {
+					t.Errorf("%v: %T: table-driven Marshal() error: %v", test.desc, m, err)
+					continue
+				}
+				want := out.Buf
+				if !deterministic {
+					// Map iteration order is random, so only the
+					// deterministic output can be compared directly.
+					if len(got) != len(want) {
+						t.Errorf("%v: %T: Marshal() length = %v, table-driven length = %v", test.desc, m, len(got), len(want))
+					}
+					continue
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%v: %T: Marshal() output differs from table-driven output\ngot:  %x\nwant: %x", test.desc, m, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratedCodecUnmarshal(t *testing.T) {
+	for _, test := range codecTestMessages {
+		for _, want := range codecMessages(test.template) {
+			b, err := proto.MarshalOptions{Deterministic: true}.Marshal(want)
+			if err != nil {
+				t.Errorf("%v: %T: Marshal() error: %v", test.desc, want, err)
+				continue
+			}
+			for _, discard := range []bool{false, true} {
+				opts := proto.UnmarshalOptions{DiscardUnknown: discard}
+				got := want.ProtoReflect().Type().New().Interface()
+				if err := opts.Unmarshal(b, got); err != nil {
+					t.Errorf("%v: %T: Unmarshal() error: %v", test.desc, want, err)
+					continue
+				}
+				table := want.ProtoReflect().Type().New().Interface()
+				if _, err := opts.UnmarshalState(protoiface.UnmarshalInput{
+					Message: tableMessage(table),
+					Buf:     b,
+				}); err != nil {
+					t.Errorf("%v: %T: table-driven Unmarshal() error: %v", test.desc, want, err)
+					continue
+				}
+				if !proto.Equal(got, table) {
+					t.Errorf("%v: %T: Unmarshal(DiscardUnknown=%v) result differs from table-driven result\ngot:  %v\nwant: %v", test.desc, want, discard, got, table)
+				}
+				if !discard && !proto.Equal(got, want) {
+					t.Errorf("%v: %T: Unmarshal() mismatch\ngot:  %v\nwant: %v", test.desc, want, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratedCodecUnmarshalWire(t *testing.T) {
+	tests := []struct {
+		desc      string
+		wire      []byte
+		deepEqual bool // compare the Go values, not just the message contents
+	}{{
+		desc: "packed and unpacked repeated fields",
+		wire: protopack.Message{
+			protopack.Tag{31, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{protopack.Varint(2), protopack.Varint(3)},
+			protopack.Tag{37, protopack.BytesType}, protopack.LengthPrefix{protopack.Uint32(4)},
+			protopack.Tag{37, protopack.Fixed32Type}, protopack.Uint32(5),
+			protopack.Tag{42, protopack.Fixed64Type}, protopack.Float64(6.5),
+			protopack.Tag{42, protopack.BytesType}, protopack.LengthPrefix{protopack.Float64(7.5)},
+		}.Marshal(),
+	}, {
+		desc: "mismatched wire types",
+		wire: protopack.Message{
+			protopack.Tag{81, protopack.Fixed32Type}, protopack.Uint32(1),
+			protopack.Tag{94, protopack.VarintType}, protopack.Varint(2),
+			protopack.Tag{98, protopack.Fixed64Type}, protopack.Uint64(3),
+			protopack.Tag{56, protopack.VarintType}, protopack.Varint(4),
+		}.Marshal(),
+	}, {
+		desc: "merged singular messages",
+		wire: protopack.Message{
+			protopack.Tag{98, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			}),
+			protopack.Tag{98, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+					protopack.Tag{81, protopack.VarintType}, protopack.Varint(2),
+				}),
+			}),
+			protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+			}),
+			protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1000, protopack.VarintType}, protopack.Varint(4),
+			}),
+		}.Marshal(),
+	}, {
+		desc: "oneof replaced by later member",
+		wire: protopack.Message{
+			protopack.Tag{112, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			}),
+			protopack.Tag{111, protopack.VarintType}, protopack.Varint(2),
+		}.Marshal(),
+	}, {
+		desc: "map entries with missing, repeated, and unknown fields",
+		wire: protopack.Message{
+			protopack.Tag{56, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{}),
+			protopack.Tag{57, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{2, protopack.VarintType}, protopack.Varint(1),
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+				protopack.Tag{3, protopack.VarintType}, protopack.Varint(3),
+				protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(4),
+			}),
+			protopack.Tag{71, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.BytesType}, protopack.String("a"),
+			}),
+			protopack.Tag{71, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+					protopack.Tag{1, protopack.VarintType}, protopack.Varint(5),
+				}),
+				protopack.Tag{1, protopack.BytesType}, protopack.String("b"),
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+					protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{}),
+				}),
+			}),
+		}.Marshal(),
+	}, {
+		desc: "zero-length bytes",
+		wire: protopack.Message{
+			protopack.Tag{15, protopack.BytesType}, protopack.Bytes(nil),
+			protopack.Tag{45, protopack.BytesType}, protopack.Bytes(nil),
+			protopack.Tag{95, protopack.BytesType}, protopack.Bytes(nil),
+			protopack.Tag{114, protopack.BytesType}, protopack.Bytes(nil),
+		}.Marshal(),
+		deepEqual: true,
+	}}
+	for _, test := range tests {
+		for _, m := range codecMessages(protobuild.Message{}) {
+			got := m.ProtoReflect().Type().New().Interface()
+			if err := proto.Unmarshal(test.wire, got); err != nil {
+				t.Errorf("%v: %T: Unmarshal() error: %v", test.desc, m, err)
+				continue
+			}
+			want := m.ProtoReflect().Type().New().Interface()
+			if _, err := (proto.UnmarshalOptions{}).UnmarshalState(protoiface.UnmarshalInput{
+				Message: tableMessage(want),
+				Buf:     test.wire,
+			}); err != nil {
+				t.Errorf("%v: %T: table-driven Unmarshal() error: %v", test.desc, m, err)
+				continue
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("%v: %T: Unmarshal() result differs from table-driven result\ngot:  %v\nwant: %v", test.desc, m, got, want)
+			}
+			if test.deepEqual && !reflect.DeepEqual(got, want) {
+				t.Errorf("%v: %T: Unmarshal() result is not deeply equal to table-driven result\ngot:  %#v\nwant: %#v", test.desc, m, got, want)
+			}
+		}
+	}
+}
+
+func TestGeneratedCodecInvalid(t *testing.T) {
+	tests := []struct {
+		desc     string
+		wire     []byte
+		messages []proto.Message
+	}{{
+		desc: "truncated varint",
+		wire: protopack.Message{
+			protopack.Tag{81, protopack.VarintType}, protopack.Raw{0x80},
+		}.Marshal(),
+	}, {
+		desc: "truncated message",
+		wire: protopack.Message{
+			protopack.Tag{98, protopack.BytesType}, protopack.Raw{0x05, 0x08},
+		}.Marshal(),
+	}, {
+		desc: "invalid field in nested message",
+		wire: protopack.Message{
+			protopack.Tag{98, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{0, protopack.VarintType}, protopack.Varint(1),
+			}),
+		}.Marshal(),
+	}, {
+		desc: "invalid packed field",
+		wire: protopack.Message{
+			protopack.Tag{37, protopack.BytesType}, protopack.LengthPrefix{protopack.Raw{1, 2, 3}},
+		}.Marshal(),
+	}, {
+		desc: "invalid map entry",
+		wire: protopack.Message{
+			protopack.Tag{56, protopack.BytesType}, protopack.LengthPrefix{protopack.Raw{0x08}},
+		}.Marshal(),
+	}, {
+		desc: "invalid UTF-8",
+		wire: protopack.Message{
+			protopack.Tag{94, protopack.BytesType}, protopack.String("\xff"),
+		}.Marshal(),
+		messages: []proto.Message{&codec3pb.TestAllTypes{}},
+	}, {
+		desc: "invalid UTF-8 in map key",
+		wire: protopack.Message{
+			protopack.Tag{69, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.BytesType}, protopack.String("\xff"),
+			}),
+		}.Marshal(),
+		messages: []proto.Message{&codec3pb.TestAllTypes{}},
+	}}
+	for _, test := range tests {
+		messages := test.messages
+		if messages == nil {
+			messages = codecMessages(protobuild.Message{})
+		}
+		for _, m := range messages {
+			got := m.ProtoReflect().Type().New().Interface()
+			err := proto.Unmarshal(test.wire, got)
+			if err == nil {
+				t.Errorf("%v: %T: Unmarshal() succeeded, want error", test.desc, m)
+				continue
+			}
+			_, want := (proto.UnmarshalOptions{}).UnmarshalState(protoiface.UnmarshalInput{
+				Message: tableMessage(m.ProtoReflect().Type().New().Interface()),
+				Buf:     test.wire,
+			})
+			if want == nil {
+				t.Errorf("%v: %T: table-driven Unmarshal() succeeded, want error", test.desc, m)
+				continue
+			}
+			gotErr, ok1 := err.(*proto.DecodeError)
+			wantErr, ok2 := want.(*proto.DecodeError)
+			if !ok1 || !ok2 {
+				t.Errorf("%v: %T: Unmarshal() error %v (table-driven %v), want DecodeError", test.desc, m, err, want)
+				continue
+			}
+			if gotErr.Kind != wantErr.Kind {
+				t.Errorf("%v: %T: Unmarshal() error kind = %v, table-driven kind = %v", test.desc, m, gotErr.Kind, wantErr.Kind)
+			}
+		}
+	}
+
+	m := &codec3pb.TestAllTypes{SingularString: "\xff"}
+	if _, err := proto.Marshal(m); err == nil {
+		t.Errorf("Marshal(%v) succeeded, want invalid UTF-8 error", m)
+	}
+}
+
+func TestGeneratedCodecNilOneofWrapper(t *testing.T) {
+	m := &codec3pb.TestAllTypes{OneofField: (*codec3pb.TestAllTypes_OneofNestedMessage)(nil)}
+	b, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if len(b) != 0 {
+		t.Errorf("Marshal() = %x, want empty output", b)
+	}
+}
+
+func TestGeneratedCodecMethods(t *testing.T) {
+	for _, m := range codecMessages(protobuild.Message{}) {
+		mi := m.ProtoReflect().(interface{ ProtoMessageInfo() *impl.MessageInfo }).ProtoMessageInfo()
+		if mi.Methods.Size == nil || mi.Methods.Marshal == nil || mi.Methods.Unmarshal == nil {
+			t.Errorf("%T: generated Size, Marshal, and Unmarshal methods are not all set", m)
+		}
+	}
+}
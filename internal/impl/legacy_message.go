@@ -61,19 +61,19 @@ func legacyLoadMessageInfo(t reflect.Type, name pref.FullName) *MessageInfo {
 
 	v := reflect.Zero(t).Interface()
 	if _, ok := v.(legacyMarshaler); ok {
-		mi.methods.Marshal = legacyMarshal
+		mi.Methods.Marshal = legacyMarshal
 
 		// We have no way to tell whether the type's Marshal method
 		// supports deterministic serialization or not, but this
 		// preserves the v1 implementation's behavior of always
 		// calling Marshal methods when present.
-		mi.methods.Flags |= piface.SupportMarshalDeterministic
+		mi.Methods.Flags |= piface.SupportMarshalDeterministic
 	}
 	if _, ok := v.(legacyUnmarshaler); ok {
-		mi.methods.Unmarshal = legacyUnmarshal
+		mi.Methods.Unmarshal = legacyUnmarshal
 	}
 	if _, ok := v.(legacyMerger); ok {
-		mi.methods.Merge = legacyMerge
+		mi.Methods.Merge = legacyMerge
 	}
 
 	if mi, ok := legacyMessageTypeCache.LoadOrStore(t, mi); ok {
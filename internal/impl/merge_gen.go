@@ -6,7 +6,9 @@
 
 package impl
 
-import ()
+import (
+	"math"
+)
 
 func mergeBool(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	*dst.Bool() = *src.Bool()
@@ -139,7 +141,7 @@ func mergeFloat32(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 
 func mergeFloat32NoZero(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	v := *src.Float32()
-	if v != 0 {
+	if v != 0 || math.Signbit(float64(v)) {
 		*dst.Float32() = v
 	}
 }
@@ -164,7 +166,7 @@ func mergeFloat64(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 
 func mergeFloat64NoZero(dst, src pointer, _ *coderFieldInfo, _ mergeOptions) {
 	v := *src.Float64()
-	if v != 0 {
+	if v != 0 || math.Signbit(float64(v)) {
 		*dst.Float64() = v
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
 	preg "google.golang.org/protobuf/reflect/protoregistry"
+	piface "google.golang.org/protobuf/runtime/protoiface"
 )
 
 // MessageInfo provides protobuf related functionality for a given Go type
@@ -38,6 +39,11 @@ type MessageInfo struct {
 	// OneofWrappers is list of pointers to oneof wrapper struct types.
 	OneofWrappers []interface{}
 
+	// Methods are optional fast-path implementations provided by generated
+	// code, such as that produced by protoc-gen-go with generate_codec.
+	// Any function left nil uses the table-driven implementation.
+	Methods piface.Methods
+
 	initMu   sync.Mutex // protects all unexported fields
 	initDone uint32
 
@@ -28,6 +28,9 @@ func (m *messageState) protoUnwrap() interface{} {
 }
 func (m *messageState) ProtoMethods() *protoiface.Methods {
 	m.messageInfo().init()
+	if m.isFrozen() {
+		return &m.messageInfo().frozenMethods
+	}
 	return &m.messageInfo().methods
 }
 
@@ -42,6 +45,12 @@ func (m *messageState) ProtoMessageInfo() *MessageInfo {
 
 func (m *messageState) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
 	m.messageInfo().init()
+	if m.isFrozen() {
+		rangeFunc := f
+		f = func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			return rangeFunc(fd, frozenValue(v))
+		}
+	}
 	for _, ri := range m.messageInfo().rangeInfos {
 		switch ri := ri.(type) {
 		case *fieldInfo:
@@ -71,6 +80,7 @@ func (m *messageState) Has(fd protoreflect.FieldDescriptor) bool {
 }
 func (m *messageState) Clear(fd protoreflect.FieldDescriptor) {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		fi.clear(m.pointer())
 	} else {
@@ -80,13 +90,14 @@ func (m *messageState) Clear(fd protoreflect.FieldDescriptor) {
 func (m *messageState) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
 	m.messageInfo().init()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
-		return fi.get(m.pointer())
+		return m.readOnly(fi.get(m.pointer()))
 	} else {
-		return m.messageInfo().extensionMap(m.pointer()).Get(xt)
+		return m.readOnly(m.messageInfo().extensionMap(m.pointer()).Get(xt))
 	}
 }
 func (m *messageState) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		fi.set(m.pointer(), v)
 	} else {
@@ -95,6 +106,7 @@ func (m *messageState) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value
 }
 func (m *messageState) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		return fi.mutable(m.pointer())
 	} else {
@@ -122,6 +134,7 @@ func (m *messageState) GetUnknown() protoreflect.RawFields {
 }
 func (m *messageState) SetUnknown(b protoreflect.RawFields) {
 	m.messageInfo().init()
+	m.checkMutable()
 	m.messageInfo().setUnknown(m.pointer(), b)
 }
 func (m *messageState) IsValid() bool {
@@ -148,6 +161,9 @@ func (m *messageReflectWrapper) protoUnwrap() interface{} {
 }
 func (m *messageReflectWrapper) ProtoMethods() *protoiface.Methods {
 	m.messageInfo().init()
+	if m.isFrozen() {
+		return &m.messageInfo().frozenMethods
+	}
 	return &m.messageInfo().methods
 }
 
@@ -162,6 +178,12 @@ func (m *messageReflectWrapper) ProtoMessageInfo() *MessageInfo {
 
 func (m *messageReflectWrapper) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
 	m.messageInfo().init()
+	if m.isFrozen() {
+		rangeFunc := f
+		f = func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			return rangeFunc(fd, frozenValue(v))
+		}
+	}
 	for _, ri := range m.messageInfo().rangeInfos {
 		switch ri := ri.(type) {
 		case *fieldInfo:
@@ -191,6 +213,7 @@ func (m *messageReflectWrapper) Has(fd protoreflect.FieldDescriptor) bool {
 }
 func (m *messageReflectWrapper) Clear(fd protoreflect.FieldDescriptor) {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		fi.clear(m.pointer())
 	} else {
@@ -200,13 +223,14 @@ func (m *messageReflectWrapper) Clear(fd protoreflect.FieldDescriptor) {
 func (m *messageReflectWrapper) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
 	m.messageInfo().init()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
-		return fi.get(m.pointer())
+		return m.readOnly(fi.get(m.pointer()))
 	} else {
-		return m.messageInfo().extensionMap(m.pointer()).Get(xt)
+		return m.readOnly(m.messageInfo().extensionMap(m.pointer()).Get(xt))
 	}
 }
 func (m *messageReflectWrapper) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		fi.set(m.pointer(), v)
 	} else {
@@ -215,6 +239,7 @@ func (m *messageReflectWrapper) Set(fd protoreflect.FieldDescriptor, v protorefl
 }
 func (m *messageReflectWrapper) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
 	m.messageInfo().init()
+	m.checkMutable()
 	if fi, xt := m.messageInfo().checkField(fd); fi != nil {
 		return fi.mutable(m.pointer())
 	} else {
@@ -242,6 +267,7 @@ func (m *messageReflectWrapper) GetUnknown() protoreflect.RawFields {
 }
 func (m *messageReflectWrapper) SetUnknown(b protoreflect.RawFields) {
 	m.messageInfo().init()
+	m.checkMutable()
 	m.messageInfo().setUnknown(m.pointer(), b)
 }
 func (m *messageReflectWrapper) IsValid() bool {
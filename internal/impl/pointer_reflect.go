@@ -70,6 +70,11 @@ func (p pointer) IsNil() bool {
 	return p.v.IsNil()
 }
 
+// key returns a comparable value that identifies the address of p.
+func (p pointer) key() interface{} {
+	return p.v.Pointer()
+}
+
 // Apply adds an offset to the pointer to derive a new pointer
 // to a specified field. The current pointer must be pointing at a struct.
 func (p pointer) Apply(f offset) pointer {
@@ -153,6 +158,21 @@ func (p pointer) AppendPointerSlice(v pointer) {
 	sp.Set(reflect.Append(sp, v.v))
 }
 
+// SparePointer returns the non-nil element just past the end of p,
+// which must be a []*T, if p has spare capacity holding one.
+func (p pointer) SparePointer() (pointer, bool) {
+	sp := p.v.Elem()
+	n := sp.Len()
+	if n == sp.Cap() {
+		return pointer{}, false
+	}
+	v := sp.Slice(0, n+1).Index(n)
+	if v.IsNil() {
+		return pointer{}, false
+	}
+	return pointer{v: v}, true
+}
+
 // SetPointer sets *p to v.
 func (p pointer) SetPointer(v pointer) {
 	p.v.Elem().Set(v.v)
@@ -161,6 +181,7 @@ func (p pointer) SetPointer(v pointer) {
 func (Export) MessageStateOf(p Pointer) *messageState     { panic("not supported") }
 func (ms *messageState) pointer() pointer                 { panic("not supported") }
 func (ms *messageState) messageInfo() *MessageInfo        { panic("not supported") }
+func (ms *messageState) isFrozen() bool                   { panic("not supported") }
 func (ms *messageState) LoadMessageInfo() *MessageInfo    { panic("not supported") }
 func (ms *messageState) StoreMessageInfo(mi *MessageInfo) { panic("not supported") }
 
@@ -62,6 +62,11 @@ func (p pointer) IsNil() bool {
 	return p.p == nil
 }
 
+// key returns a comparable value that identifies the address of p.
+func (p pointer) key() interface{} {
+	return p.p
+}
+
 // Apply adds an offset to the pointer to derive a new pointer
 // to a specified field. The pointer must be valid and pointing at a struct.
 func (p pointer) Apply(f offset) pointer {
@@ -132,6 +137,17 @@ func (p pointer) AppendPointerSlice(v pointer) {
 	*(*[]pointer)(p.p) = append(*(*[]pointer)(p.p), v)
 }
 
+// SparePointer returns the non-nil element just past the end of p,
+// which must be a []*T, if p has spare capacity holding one.
+func (p pointer) SparePointer() (pointer, bool) {
+	s := *(*[]pointer)(p.p)
+	if len(s) == cap(s) {
+		return pointer{}, false
+	}
+	v := s[:len(s)+1][len(s)]
+	return v, !v.IsNil()
+}
+
 // SetPointer sets *p to v.
 func (p pointer) SetPointer(v pointer) {
 	*(*unsafe.Pointer)(p.p) = (unsafe.Pointer)(v.p)
@@ -153,8 +169,14 @@ func (ms *messageState) messageInfo() *MessageInfo {
 	if mi == nil {
 		panic("invalid nil message info; this suggests memory corruption due to a race or shallow copy on the message struct")
 	}
+	if mi.mutable != nil {
+		return mi.mutable // message is frozen
+	}
 	return mi
 }
+func (ms *messageState) isFrozen() bool {
+	return ms.LoadMessageInfo().mutable != nil
+}
 func (ms *messageState) LoadMessageInfo() *MessageInfo {
 	return (*MessageInfo)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ms.atomicMessageInfo))))
 }
@@ -0,0 +1,241 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	piface "google.golang.org/protobuf/runtime/protoiface"
+)
+
+// reset is protoreflect.Methods.Reset.
+func (mi *MessageInfo) reset(in piface.ResetInput) piface.ResetOutput {
+	p, ok := mi.getPointer(in.Message)
+	if !ok || p.IsNil() {
+		return piface.ResetOutput{}
+	}
+	// Nothing is unmarshaled into the message here, so the values of
+	// singular message fields cannot be reused and are not retained.
+	mi.resetPointer(p, in.Flags&piface.ResetRetainCapacity != 0, nil)
+	return piface.ResetOutput{Flags: piface.ResetComplete}
+}
+
+// retainedSet records the messages that resetPointer keeps in singular
+// message fields for reuse, for the duration of a single unmarshal operation.
+// Unmarshaling into a message removes it from the set.
+type retainedSet struct {
+	m map[retainedKey]struct{}
+}
+
+type retainedKey struct {
+	mi *MessageInfo
+	p  interface{} // pointer.key
+}
+
+// maxRetainedSetLen is the maximum number of entries in a set that is
+// returned to the pool for reuse.
+const maxRetainedSetLen = 1 << 16
+
+var retainedSetPool = sync.Pool{
+	New: func() interface{} {
+		return &retainedSet{m: make(map[retainedKey]struct{})}
+	},
+}
+
+// getRetainedSet returns an empty set.
+// Call putRetainedSet to return it for reuse when the operation is done.
+func getRetainedSet() *retainedSet {
+	return retainedSetPool.Get().(*retainedSet)
+}
+
+// putRetainedSet clears rs and returns it for reuse.
+func putRetainedSet(rs *retainedSet) {
+	if len(rs.m) > maxRetainedSetLen {
+		rs.m = make(map[retainedKey]struct{})
+	} else {
+		for k := range rs.m {
+			delete(rs.m, k)
+		}
+	}
+	retainedSetPool.Put(rs)
+}
+
+func (rs *retainedSet) add(mi *MessageInfo, p pointer) {
+	rs.m[retainedKey{mi, p.key()}] = struct{}{}
+}
+
+func (rs *retainedSet) remove(mi *MessageInfo, p pointer) {
+	if len(rs.m) > 0 {
+		delete(rs.m, retainedKey{mi, p.key()})
+	}
+}
+
+func (rs *retainedSet) has(mi *MessageInfo, p pointer) bool {
+	_, ok := rs.m[retainedKey{mi, p.key()}]
+	return ok
+}
+
+// resetPointer clears every field of the message at p.
+//
+// If retain is set, repeated fields are truncated rather than released,
+// and map, extension, and unknown fields are emptied in place. The elements
+// of repeated message fields are left in the spare capacity of the list,
+// where unmarshal may reuse them. If rs is also non-nil, the values of
+// singular message fields, including those in oneofs, are reset in place
+// and added to rs so that unmarshal may reuse them too; since they still
+// populate their fields, the caller must call releaseRetained once it is
+// done unmarshaling.
+func (mi *MessageInfo) resetPointer(p pointer, retain bool, rs *retainedSet) {
+	mi.init()
+	for _, f := range mi.orderedCoderFields {
+		if f.isWeak {
+			*p.Apply(f.offset).WeakFields() = nil
+			continue
+		}
+		fptr := p.Apply(f.offset)
+		if retain && rs != nil && mi.retainField(fptr, f, rs) {
+			continue
+		}
+		resetField(fptr, f.ft, retain)
+	}
+	if mi.extensionOffset.IsValid() {
+		ext := p.Apply(mi.extensionOffset).Extensions()
+		if retain {
+			for num := range *ext {
+				delete(*ext, num)
+			}
+		} else {
+			*ext = nil
+		}
+	}
+	if mi.unknownOffset.IsValid() {
+		if u := mi.getUnknownBytes(p); u != nil {
+			if retain {
+				*u = (*u)[:0]
+			} else {
+				*u = nil
+			}
+		}
+	}
+	if mi.sizecacheOffset.IsValid() {
+		atomic.StoreInt32(p.Apply(mi.sizecacheOffset).Int32(), 0)
+	}
+}
+
+// resetField clears the struct field of type ft at p.
+func resetField(p pointer, ft reflect.Type, retain bool) {
+	v := p.AsValueOf(ft).Elem()
+	switch {
+	case !retain:
+	case ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8:
+		// A repeated field, but not a bytes field.
+		v.SetLen(0)
+		return
+	case ft.Kind() == reflect.Map:
+		for iter := mapRange(v); iter.Next(); {
+			v.SetMapIndex(iter.Key(), reflect.Value{})
+		}
+		return
+	}
+	v.Set(reflect.Zero(ft))
+}
+
+// retainField resets the value of the singular message field or oneof f
+// at p in place and keeps it, reporting whether the field is left as is.
+func (mi *MessageInfo) retainField(p pointer, f *coderFieldInfo, rs *retainedSet) bool {
+	switch f.ft.Kind() {
+	case reflect.Ptr:
+		if f.mi == nil || p.Elem().IsNil() {
+			return false
+		}
+		f.mi.retainPointer(p.Elem(), rs)
+		return true
+	case reflect.Interface:
+		v := p.AsValueOf(f.ft).Elem()
+		if v.IsNil() {
+			return true
+		}
+		w := v.Elem() // pointer to oneof wrapper
+		cf := mi.oneofCoderFields[w.Type()]
+		if cf == nil {
+			return false
+		}
+		if cf.num != f.num {
+			// The oneof is handled along with the field that it holds.
+			return true
+		}
+		if cf.mi == nil || w.IsNil() {
+			return false
+		}
+		mp := pointerOfValue(w).Apply(zeroOffset).Elem()
+		if mp.IsNil() {
+			return false
+		}
+		cf.mi.retainPointer(mp, rs)
+		return true
+	}
+	return false
+}
+
+// retainPointer resets the message at p in place and adds it to rs.
+func (mi *MessageInfo) retainPointer(p pointer, rs *retainedSet) {
+	mi.resetPointer(p, true, rs)
+	rs.add(mi, p)
+}
+
+// releaseRetained clears the singular message fields of the message at p,
+// and of the messages it contains, that hold a value in rs, which is one
+// kept by resetPointer that has not since been unmarshaled into.
+func (mi *MessageInfo) releaseRetained(p pointer, rs *retainedSet) {
+	if len(rs.m) == 0 {
+		return
+	}
+	mi.init()
+	for _, f := range mi.orderedCoderFields {
+		if f.isWeak {
+			continue
+		}
+		fptr := p.Apply(f.offset)
+		switch f.ft.Kind() {
+		case reflect.Ptr:
+			if f.mi == nil || fptr.Elem().IsNil() {
+				continue
+			}
+			if rs.has(f.mi, fptr.Elem()) {
+				resetField(fptr, f.ft, false)
+			} else {
+				f.mi.releaseRetained(fptr.Elem(), rs)
+			}
+		case reflect.Slice:
+			if f.mi == nil {
+				continue
+			}
+			for _, mp := range fptr.PointerSlice() {
+				f.mi.releaseRetained(mp, rs)
+			}
+		case reflect.Interface:
+			v := fptr.AsValueOf(f.ft).Elem()
+			if v.IsNil() {
+				continue
+			}
+			w := v.Elem()
+			cf := mi.oneofCoderFields[w.Type()]
+			if cf == nil || cf.num != f.num || cf.mi == nil || w.IsNil() {
+				continue
+			}
+			mp := pointerOfValue(w).Apply(zeroOffset).Elem()
+			if mp.IsNil() {
+				continue
+			}
+			if rs.has(cf.mi, mp) {
+				resetField(fptr, f.ft, false)
+			} else {
+				cf.mi.releaseRetained(mp, rs)
+			}
+		}
+	}
+}
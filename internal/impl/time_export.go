@@ -0,0 +1,41 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"google.golang.org/protobuf/internal/encoding/timefmt"
+)
+
+// FormatDuration formats a google.protobuf.Duration in its JSON form.
+func (Export) FormatDuration(secs int64, nanos int32) string {
+	return timefmt.FormatDuration(secs, nanos)
+}
+
+// ParseDuration parses a google.protobuf.Duration in its JSON form.
+func (Export) ParseDuration(s string) (secs int64, nanos int32, ok bool) {
+	return timefmt.ParseDuration(s)
+}
+
+// FormatISO8601Duration formats a google.protobuf.Duration in the ISO 8601 form.
+func (Export) FormatISO8601Duration(secs int64, nanos int32) string {
+	return timefmt.FormatISO8601Duration(secs, nanos)
+}
+
+// ParseISO8601Duration parses a google.protobuf.Duration in the ISO 8601 form.
+func (Export) ParseISO8601Duration(s string) (secs int64, nanos int32, ok bool) {
+	return timefmt.ParseISO8601Duration(s)
+}
+
+// FormatTimestamp formats a google.protobuf.Timestamp in its JSON form,
+// which is RFC 3339.
+func (Export) FormatTimestamp(secs int64, nanos int32) string {
+	return timefmt.FormatTimestamp(secs, nanos)
+}
+
+// ParseTimestamp parses a google.protobuf.Timestamp in its JSON form,
+// which is RFC 3339.
+func (Export) ParseTimestamp(s string) (secs int64, nanos int32, ok bool) {
+	return timefmt.ParseTimestamp(s)
+}
@@ -20,6 +20,7 @@ import (
 	"google.golang.org/protobuf/internal/detrand"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
@@ -67,7 +68,11 @@ func appendMessage(b []byte, m protoreflect.Message) []byte {
 	order.RangeFields(m, order.IndexNameFieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
 		b = append(b, fd.TextName()...)
 		b = append(b, ':')
-		b = appendValue(b, v, fd)
+		if redact.IsRedacted(fd, nil) {
+			b = append(b, redact.Placeholder...)
+		} else {
+			b = appendValue(b, v, fd)
+		}
 		b = append(b, delim()...)
 		return true
 	})
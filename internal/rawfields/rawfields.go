@@ -0,0 +1,39 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rawfields provides functionality for raw wire-format fields,
+// such as the unknown fields of a message.
+package rawfields
+
+import (
+	"bytes"
+	"reflect"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Equal compares raw fields by direct comparison on the raw bytes
+// of each individual field number.
+func Equal(x, y []byte) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	if bytes.Equal(x, y) {
+		return true
+	}
+
+	mx := make(map[protowire.Number][]byte)
+	my := make(map[protowire.Number][]byte)
+	for len(x) > 0 {
+		num, _, n := protowire.ConsumeField(x)
+		mx[num] = append(mx[num], x[:n]...)
+		x = x[n:]
+	}
+	for len(y) > 0 {
+		num, _, n := protowire.ConsumeField(y)
+		my[num] = append(my[num], y[:n]...)
+		y = y[n:]
+	}
+	return reflect.DeepEqual(mx, my)
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package redact determines which fields hold sensitive data
+// that must not appear in debug output.
+package redact
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/descopts"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Placeholder is printed in place of the value of a redacted field.
+const Placeholder = "[REDACTED]"
+
+// debugRedactNumber is the field number of the debug_redact option
+// in google.protobuf.FieldOptions.
+const debugRedactNumber = 16
+
+// IsRedacted reports whether the field fd holds sensitive data.
+// That is the case if it has the debug_redact field option set,
+// or if f is non-nil and reports true for fd.
+func IsRedacted(fd protoreflect.FieldDescriptor, f func(protoreflect.FieldDescriptor) bool) bool {
+	if f != nil && f(fd) {
+		return true
+	}
+	return hasDebugRedact(fd)
+}
+
+// hasDebugRedact reports whether fd has the debug_redact option set.
+//
+// The option is read reflectively since the FieldOptions message linked
+// into the program may predate it, in which case it is an unknown field.
+func hasDebugRedact(fd protoreflect.FieldDescriptor) bool {
+	if descopts.Field == nil {
+		// Options cannot be accessed without the descriptor package,
+		// in which case no field can be known to be redacted.
+		return false
+	}
+	opts := fd.Options()
+	if opts == nil {
+		return false
+	}
+	m := opts.ProtoReflect()
+	if !m.IsValid() {
+		return false
+	}
+	if xd := m.Descriptor().Fields().ByNumber(debugRedactNumber); xd != nil {
+		if xd.Kind() != protoreflect.BoolKind || xd.IsList() {
+			return false
+		}
+		return m.Get(xd).Bool()
+	}
+	var redact bool
+	b := m.GetUnknown()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return false
+		}
+		b = b[n:]
+		if num == debugRedactNumber && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return false
+			}
+			redact = v != 0
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return false
+		}
+		b = b[n:]
+	}
+	return redact
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sizetable records the sizes of messages for the duration of
+// a single marshal operation.
+//
+// Messages without a size cache of their own would otherwise have their
+// sizes recomputed at each level of nesting, which takes time quadratic
+// in the nesting depth.
+package sizetable
+
+import "sync"
+
+// maxRetainedLen is the maximum number of entries in a table that is
+// returned to the pool for reuse. Larger tables are discarded so that
+// the pool does not retain large maps indefinitely.
+const maxRetainedLen = 1 << 16
+
+// Table maps a key that identifies a message to the size of the message.
+// The key must be comparable.
+type Table struct {
+	m map[interface{}]int
+}
+
+var pool = sync.Pool{
+	New: func() interface{} {
+		return &Table{m: make(map[interface{}]int)}
+	},
+}
+
+// Get returns an empty table.
+// Call Put to return it for reuse when the operation is done.
+func Get() *Table {
+	return pool.Get().(*Table)
+}
+
+// Put clears t and returns it for reuse.
+// The table must not be used after calling Put.
+func Put(t *Table) {
+	if len(t.m) > maxRetainedLen {
+		t.m = make(map[interface{}]int)
+	} else {
+		for k := range t.m {
+			delete(t.m, k)
+		}
+	}
+	pool.Put(t)
+}
+
+// Load returns the recorded size of the message identified by k.
+func (t *Table) Load(k interface{}) (size int, ok bool) {
+	size, ok = t.m[k]
+	return size, ok
+}
+
+// Store records the size of the message identified by k.
+func (t *Table) Store(k interface{}, size int) {
+	t.m[k] = size
+}
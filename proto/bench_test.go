@@ -59,3 +59,21 @@ func BenchmarkDecode(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkEqual benchmarks comparing all the test messages with a copy.
+func BenchmarkEqual(b *testing.B) {
+	for _, test := range testValidMessages {
+		for _, want := range test.decodeTo {
+			other := proto.Clone(want)
+			b.Run(fmt.Sprintf("%s (%T)", test.desc, want), func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						if !proto.Equal(want, other) {
+							b.Fatal("Equal = false, want true")
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -36,12 +36,47 @@ type UnmarshalOptions struct {
 	// If DiscardUnknown is set, unknown fields are ignored.
 	DiscardUnknown bool
 
+	// AliasBuffer permits the unmarshaler to avoid copying the input by
+	// making the values of bytes fields, and of string fields where the
+	// build permits it, refer directly to the input buffer.
+	// Aliasing is permitted but not guaranteed, so the message may or may
+	// not share memory with the input.
+	//
+	// If AliasBuffer is set, the caller must not modify the input buffer
+	// for as long as the message, or any bytes or string value obtained
+	// from it, is in use. Modifying the value of a bytes field in place
+	// may modify the input buffer, though appending to it never does.
+	AliasBuffer bool
+
+	// RetainCapacity permits the unmarshaler to reuse memory already
+	// allocated for the fields of the destination message.
+	// Unless Merge is set, the message is reset as if by a ResetOptions
+	// with RetainCapacity set, and messages left in the spare capacity of
+	// repeated message fields may be reset and reused as list elements.
+	// The values of singular message fields may likewise be reset and
+	// reused if the input populates those fields again.
+	//
+	// If RetainCapacity is set, the caller must not retain references to
+	// the elements of repeated message fields or to the values of singular
+	// message fields of the destination message across calls, since they
+	// may be overwritten.
+	RetainCapacity bool
+
 	// Resolver is used for looking up types when unmarshaling extension fields.
 	// If nil, this defaults to using protoregistry.GlobalTypes.
 	Resolver interface {
 		FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
 		FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error)
 	}
+
+	// Fields, if non-nil, restricts unmarshaling to the selected fields.
+	// Fields that are not selected are skipped without being parsed and
+	// are treated as unknown fields, so they are preserved in the unknown
+	// fields of the message unless DiscardUnknown is set.
+	//
+	// Required fields that are not selected are never populated,
+	// so AllowPartial is usually needed as well.
+	Fields *FieldSelector
 }
 
 // Unmarshal parses the wire-format message in b and places the result in m.
@@ -71,34 +106,56 @@ func (o UnmarshalOptions) unmarshal(b []byte, m protoreflect.Message) (out proto
 	if o.Resolver == nil {
 		o.Resolver = protoregistry.GlobalTypes
 	}
+	methods := protoMethods(m)
+	fast := methods != nil && methods.Unmarshal != nil &&
+		!(o.DiscardUnknown && methods.Flags&protoiface.SupportUnmarshalDiscardUnknown == 0) &&
+		!(o.Fields != nil && methods.Flags&protoiface.SupportUnmarshalFieldSelector == 0)
+	resetInUnmarshal := false
 	if !o.Merge {
-		Reset(m.Interface())
+		switch {
+		case o.RetainCapacity && fast && methods.Flags&protoiface.SupportUnmarshalReset != 0:
+			resetInUnmarshal = true
+		case o.RetainCapacity:
+			resetMessageRetain(m)
+		default:
+			Reset(m.Interface())
+		}
 	}
 	allowPartial := o.AllowPartial
 	o.Merge = true
 	o.AllowPartial = true
-	methods := protoMethods(m)
-	if methods != nil && methods.Unmarshal != nil &&
-		!(o.DiscardUnknown && methods.Flags&protoiface.SupportUnmarshalDiscardUnknown == 0) {
+	if fast {
 		in := protoiface.UnmarshalInput{
 			Message:  m,
 			Buf:      b,
 			Resolver: o.Resolver,
 		}
+		if resetInUnmarshal {
+			in.Flags |= protoiface.UnmarshalReset
+		}
 		if o.DiscardUnknown {
 			in.Flags |= protoiface.UnmarshalDiscardUnknown
 		}
+		if o.AliasBuffer {
+			in.Flags |= protoiface.UnmarshalAliasBuffer
+		}
+		if o.RetainCapacity {
+			in.Flags |= protoiface.UnmarshalRetainCapacity
+		}
+		if o.Fields != nil {
+			in.FieldSelector = o.Fields
+		}
 		out, err = methods.Unmarshal(in)
 	} else {
 		err = o.unmarshalMessageSlow(b, m)
 	}
 	if err != nil {
-		return out, err
+		return out, errors.SetWireOffset(errors.AsDecodeError(errors.DecodeErrorOther, err), b)
 	}
 	if allowPartial || (out.Flags&protoiface.UnmarshalInitialized != 0) {
 		return out, nil
 	}
-	return out, checkInitialized(m)
+	return out, errors.AsDecodeError(errors.DecodeErrorRequiredNotSet, checkInitialized(m))
 }
 
 func (o UnmarshalOptions) unmarshalMessage(b []byte, m protoreflect.Message) error {
@@ -116,18 +173,25 @@ func (o UnmarshalOptions) unmarshalMessageSlow(b []byte, m protoreflect.Message)
 		// Parse the tag (field number and wire type).
 		num, wtyp, tagLen := protowire.ConsumeTag(b)
 		if tagLen < 0 {
-			return errDecode
+			return errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b))
 		}
 		if num > protowire.MaxValidNumber {
-			return errDecode
+			return errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b))
 		}
 
 		// Find the field descriptor for this field number.
 		fd := fields.ByNumber(num)
-		if fd == nil && md.ExtensionRanges().Has(num) {
+		fo, selected := o, true
+		if o.Fields != nil {
+			fo.Fields, selected = o.Fields.fields[num]
+		}
+		if !selected {
+			fd = nil
+		} else if fd == nil && md.ExtensionRanges().Has(num) {
 			extType, err := o.Resolver.FindExtensionByNumber(md.FullName(), num)
 			if err != nil && err != protoregistry.NotFound {
-				return errors.New("%v: unable to resolve extension %v: %v", md.FullName(), num, err)
+				err = errors.New("%v: unable to resolve extension %v: %v", md.FullName(), num, err)
+				return errors.WireDecodeError(errors.DecodeErrorUnresolvedType, err, cap(b))
 			}
 			if extType != nil {
 				fd = extType.TypeDescriptor()
@@ -147,19 +211,25 @@ func (o UnmarshalOptions) unmarshalMessageSlow(b []byte, m protoreflect.Message)
 		switch {
 		case err != nil:
 		case fd.IsList():
-			valLen, err = o.unmarshalList(b[tagLen:], wtyp, m.Mutable(fd).List(), fd)
+			valLen, err = fo.unmarshalList(b[tagLen:], wtyp, m.Mutable(fd).List(), fd)
 		case fd.IsMap():
-			valLen, err = o.unmarshalMap(b[tagLen:], wtyp, m.Mutable(fd).Map(), fd)
+			valLen, err = fo.unmarshalMap(b[tagLen:], wtyp, m.Mutable(fd).Map(), fd)
 		default:
-			valLen, err = o.unmarshalSingular(b[tagLen:], wtyp, m, fd)
+			valLen, err = fo.unmarshalSingular(b[tagLen:], wtyp, m, fd)
 		}
 		if err != nil {
 			if err != errUnknown {
-				return err
+				err = errors.WireDecodeError(errors.DecodeErrorOther, err, cap(b))
+				if fd.IsList() && fd.Message() != nil {
+					// A failed message element is never appended,
+					// so its index is the current length of the list.
+					err = errors.AddIndexPath(err, m.Get(fd).List().Len())
+				}
+				return errors.AddFieldDescPath(err, string(fd.FullName()), fd.IsExtension())
 			}
 			valLen = protowire.ConsumeFieldValue(num, wtyp, b[tagLen:])
 			if valLen < 0 {
-				return errDecode
+				return errors.WireDecodeError(errors.DecodeErrorWireFormat, errDecode, cap(b))
 			}
 			if !o.DiscardUnknown {
 				m.SetUnknown(append(m.GetUnknown(), b[:tagLen+valLen]...))
@@ -178,7 +248,7 @@ func (o UnmarshalOptions) unmarshalSingular(b []byte, wtyp protowire.Type, m pro
 	switch fd.Kind() {
 	case protoreflect.GroupKind, protoreflect.MessageKind:
 		m2 := m.Mutable(fd).Message()
-		if err := o.unmarshalMessage(v.Bytes(), m2); err != nil {
+		if err := o.unmarshalMessage(messageBytes(b, n, v, fd), m2); err != nil {
 			return n, err
 		}
 	default:
@@ -188,6 +258,17 @@ func (o UnmarshalOptions) unmarshalSingular(b []byte, wtyp protowire.Type, m pro
 	return n, nil
 }
 
+// messageBytes returns the contents of the message or group value v parsed
+// from the first n bytes of b as a subslice of b. The protoreflect.Value does
+// not retain the capacity of the input, which locates errors within it.
+func messageBytes(b []byte, n int, v protoreflect.Value, fd protoreflect.FieldDescriptor) []byte {
+	m := len(v.Bytes())
+	if fd.Kind() == protoreflect.GroupKind {
+		return b[:m]
+	}
+	return b[n-m : n]
+}
+
 func (o UnmarshalOptions) unmarshalMap(b []byte, wtyp protowire.Type, mapv protoreflect.Map, fd protoreflect.FieldDescriptor) (n int, err error) {
 	if wtyp != protowire.BytesType {
 		return 0, errUnknown
@@ -211,21 +292,24 @@ func (o UnmarshalOptions) unmarshalMap(b []byte, wtyp protowire.Type, mapv proto
 	// Map entries are represented as a two-element message with fields
 	// containing the key and value.
 	for len(b) > 0 {
-		num, wtyp, n := protowire.ConsumeTag(b)
-		if n < 0 {
+		num, wtyp, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
 			return 0, errDecode
 		}
 		if num > protowire.MaxValidNumber {
 			return 0, errDecode
 		}
-		b = b[n:]
+		b = b[tagLen:]
+		var n int
 		err = errUnknown
 		switch num {
 		case genid.MapEntry_Key_field_number:
-			key, n, err = o.unmarshalScalar(b, wtyp, keyField)
+			var k protoreflect.Value
+			k, n, err = o.unmarshalScalar(b, wtyp, keyField)
 			if err != nil {
 				break
 			}
+			key = k
 			haveKey = true
 		case genid.MapEntry_Value_field_number:
 			var v protoreflect.Value
@@ -235,9 +319,7 @@ func (o UnmarshalOptions) unmarshalMap(b []byte, wtyp protowire.Type, mapv proto
 			}
 			switch valField.Kind() {
 			case protoreflect.GroupKind, protoreflect.MessageKind:
-				if err := o.unmarshalMessage(v.Bytes(), val.Message()); err != nil {
-					return 0, err
-				}
+				err = o.unmarshalMessage(messageBytes(b, n, v, valField), val.Message())
 			default:
 				val = v
 			}
@@ -249,6 +331,9 @@ func (o UnmarshalOptions) unmarshalMap(b []byte, wtyp protowire.Type, mapv proto
 				return 0, errDecode
 			}
 		} else if err != nil {
+			if num == genid.MapEntry_Value_field_number && haveKey {
+				err = errors.AddMapKeyPath(errors.WireDecodeError(errors.DecodeErrorOther, err, cap(b)+tagLen), key.Interface())
+			}
 			return 0, err
 		}
 		b = b[n:]
@@ -273,4 +358,4 @@ func (o UnmarshalOptions) unmarshalMap(b []byte, wtyp protowire.Type, mapv proto
 // function.
 var errUnknown = errors.New("BUG: internal error (unknown)")
 
-var errDecode = errors.New("cannot parse invalid wire-format data")
+var errDecode = errors.ErrWireFormat
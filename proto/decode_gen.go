@@ -158,6 +158,9 @@ func (o UnmarshalOptions) unmarshalScalar(b []byte, wtyp protowire.Type, fd prot
 		if strs.EnforceUTF8(fd) && !utf8.Valid(v) {
 			return protoreflect.Value{}, 0, errors.InvalidUTF8(string(fd.FullName()))
 		}
+		if o.AliasBuffer {
+			return protoreflect.ValueOfString(strs.UnsafeString(v)), n, nil
+		}
 		return protoreflect.ValueOfString(string(v)), n, nil
 	case protoreflect.BytesKind:
 		if wtyp != protowire.BytesType {
@@ -167,6 +170,9 @@ func (o UnmarshalOptions) unmarshalScalar(b []byte, wtyp protowire.Type, fd prot
 		if n < 0 {
 			return val, 0, errDecode
 		}
+		if o.AliasBuffer {
+			return protoreflect.ValueOfBytes(v[:len(v):len(v)]), n, nil
+		}
 		return protoreflect.ValueOfBytes(append(emptyBuf[:], v...)), n, nil
 	case protoreflect.MessageKind:
 		if wtyp != protowire.BytesType {
@@ -554,7 +560,11 @@ func (o UnmarshalOptions) unmarshalList(b []byte, wtyp protowire.Type, list prot
 		if strs.EnforceUTF8(fd) && !utf8.Valid(v) {
 			return 0, errors.InvalidUTF8(string(fd.FullName()))
 		}
-		list.Append(protoreflect.ValueOfString(string(v)))
+		if o.AliasBuffer {
+			list.Append(protoreflect.ValueOfString(strs.UnsafeString(v)))
+		} else {
+			list.Append(protoreflect.ValueOfString(string(v)))
+		}
 		return n, nil
 	case protoreflect.BytesKind:
 		if wtyp != protowire.BytesType {
@@ -564,7 +574,11 @@ func (o UnmarshalOptions) unmarshalList(b []byte, wtyp protowire.Type, list prot
 		if n < 0 {
 			return 0, errDecode
 		}
-		list.Append(protoreflect.ValueOfBytes(append(emptyBuf[:], v...)))
+		if o.AliasBuffer {
+			list.Append(protoreflect.ValueOfBytes(v[:len(v):len(v)]))
+		} else {
+			list.Append(protoreflect.ValueOfBytes(append(emptyBuf[:], v...)))
+		}
 		return n, nil
 	case protoreflect.MessageKind:
 		if wtyp != protowire.BytesType {
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	"google.golang.org/protobuf/internal/errors"
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
@@ -53,6 +54,66 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeAliasBuffer(t *testing.T) {
+	for _, test := range testValidMessages {
+		for _, want := range test.decodeTo {
+			t.Run(fmt.Sprintf("%s (%T)", test.desc, want), func(t *testing.T) {
+				opts := test.unmarshalOptions
+				opts.AllowPartial = test.partial
+				opts.AliasBuffer = true
+				wire := append(([]byte)(nil), test.wire...)
+				got := reflect.New(reflect.TypeOf(want).Elem()).Interface().(proto.Message)
+				if err := opts.Unmarshal(wire, got); err != nil {
+					t.Errorf("Unmarshal error: %v\nMessage:\n%v", err, prototext.Format(want))
+					return
+				}
+				if !bytes.Equal(test.wire, wire) {
+					t.Errorf("Unmarshal unexpectedly modified its input")
+				}
+				if !proto.Equal(got, want) && got.ProtoReflect().IsValid() && want.ProtoReflect().IsValid() {
+					t.Errorf("Unmarshal returned unexpected result; got:\n%v\nwant:\n%v", prototext.Format(got), prototext.Format(want))
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeAliasBufferBytes(t *testing.T) {
+	wire := protopack.Message{
+		protopack.Tag{15, protopack.BytesType}, protopack.Bytes("abc"),
+		protopack.Tag{45, protopack.BytesType}, protopack.Bytes("def"),
+	}.Marshal()
+	for _, m := range []proto.Message{
+		&testpb.TestAllTypes{},
+		&test3pb.TestAllTypes{},
+		dynamicpb.NewMessage((&testpb.TestAllTypes{}).ProtoReflect().Descriptor()),
+	} {
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			b := append([]byte(nil), wire...)
+			if err := (proto.UnmarshalOptions{AliasBuffer: true}).Unmarshal(b, m); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			fds := m.ProtoReflect().Descriptor().Fields()
+			optional := m.ProtoReflect().Get(fds.ByName("optional_bytes")).Bytes()
+			repeated := m.ProtoReflect().Get(fds.ByName("repeated_bytes")).List().Get(0).Bytes()
+
+			// Appending to an aliased value must not overwrite the input.
+			_ = append(optional, 'x')
+			if !bytes.Equal(b, wire) {
+				t.Errorf("appending to optional_bytes modified the input")
+			}
+
+			// Modifying the input is visible through aliased values.
+			for i := range b {
+				b[i] = 'z'
+			}
+			if string(optional) != "zzz" || string(repeated) != "zzz" {
+				t.Errorf("bytes fields = %q, %q after modifying input; want values aliasing the input", optional, repeated)
+			}
+		})
+	}
+}
+
 func TestDecodeRequiredFieldChecks(t *testing.T) {
 	for _, test := range testValidMessages {
 		if !test.partial {
@@ -135,6 +196,114 @@ func TestDecodeEmptyBytes(t *testing.T) {
 	}
 }
 
+func TestDecodeMapKeyMismatch(t *testing.T) {
+	// A map entry key with the wrong wire type is an unknown field,
+	// and does not replace an earlier valid key.
+	wire := protopack.Message{
+		protopack.Tag{56, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(2),
+			protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(3),
+		}),
+	}.Marshal()
+	want := &testpb.TestAllTypes{MapInt32Int32: map[int32]int32{1: 2}}
+	for _, m := range []proto.Message{
+		&testpb.TestAllTypes{},
+		dynamicpb.NewMessage((&testpb.TestAllTypes{}).ProtoReflect().Descriptor()),
+	} {
+		if err := proto.Unmarshal(wire, m); err != nil {
+			t.Errorf("%T: Unmarshal error: %v", m, err)
+			continue
+		}
+		if !proto.Equal(m, want) {
+			t.Errorf("%T: Unmarshal returned unexpected result; got:\n%v\nwant:\n%v", m, prototext.Format(m), prototext.Format(want))
+		}
+	}
+}
+
+func TestDecodeErrorPath(t *testing.T) {
+	for _, test := range []struct {
+		desc       string
+		wire       []byte
+		wantKind   proto.DecodeErrorKind
+		wantPath   string
+		wantOffset int
+	}{{
+		desc: "invalid UTF-8 in nested list element",
+		wire: protopack.Message{
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			}),
+			protopack.Tag{48, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+					protopack.Tag{14, protopack.BytesType}, protopack.String("\xff"),
+				}),
+			}),
+		}.Marshal(),
+		wantKind:   proto.DecodeErrorInvalidValue,
+		wantPath:   "repeated_nested_message[1].corecursive.optional_string",
+		wantOffset: 10,
+	}, {
+		desc: "truncated field in map value",
+		wire: protopack.Message{
+			protopack.Tag{71, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.BytesType}, protopack.String("k"),
+				protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+					protopack.Tag{1, protopack.VarintType}, protopack.Raw{0x80},
+				}),
+			}),
+		}.Marshal(),
+		wantKind:   proto.DecodeErrorWireFormat,
+		wantPath:   `map_string_nested_message["k"].a`,
+		wantOffset: 8,
+	}, {
+		desc: "invalid UTF-8 in map value with a non-minimal tag",
+		wire: protopack.Message{
+			protopack.Tag{69, protopack.BytesType}, protopack.LengthPrefix(protopack.Message{
+				protopack.Tag{1, protopack.BytesType}, protopack.String("k"),
+				protopack.Raw{0x92, 0x00, 0x01, 0xff}, // non-minimal tag for field 2
+			}),
+		}.Marshal(),
+		wantKind:   proto.DecodeErrorInvalidValue,
+		wantPath:   `map_string_string["k"]`,
+		wantOffset: 6,
+	}, {
+		desc: "invalid tag at top level",
+		wire: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Raw{0x80},
+		}.Marshal(),
+		wantKind:   proto.DecodeErrorWireFormat,
+		wantPath:   "",
+		wantOffset: 2,
+	}} {
+		for _, m := range []proto.Message{
+			&test3pb.TestAllTypes{},
+			dynamicpb.NewMessage((&test3pb.TestAllTypes{}).ProtoReflect().Descriptor()),
+		} {
+			t.Run(fmt.Sprintf("%s (%T)", test.desc, m), func(t *testing.T) {
+				err := proto.Unmarshal(test.wire, m)
+				derr, ok := err.(*proto.DecodeError)
+				if !ok {
+					t.Fatalf("Unmarshal error = %v (%T), want *proto.DecodeError", err, err)
+				}
+				if derr.Kind != test.wantKind {
+					t.Errorf("Kind = %v, want %v", derr.Kind, test.wantKind)
+				}
+				if derr.Path != test.wantPath {
+					t.Errorf("Path = %q, want %q", derr.Path, test.wantPath)
+				}
+				if derr.Offset != test.wantOffset {
+					t.Errorf("Offset = %v, want %v", derr.Offset, test.wantOffset)
+				}
+				if !errors.Is(err, proto.Error) {
+					t.Errorf("errors.Is(err, proto.Error) = false, want true")
+				}
+			})
+		}
+	}
+}
+
 func build(m proto.Message, opts ...buildOpt) proto.Message {
 	for _, opt := range opts {
 		opt(m)
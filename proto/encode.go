@@ -9,6 +9,7 @@ import (
 	"google.golang.org/protobuf/internal/encoding/messageset"
 	"google.golang.org/protobuf/internal/order"
 	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/internal/sizetable"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/runtime/protoiface"
 )
@@ -67,6 +68,16 @@ type MarshalOptions struct {
 	// There is absolutely no guarantee that Size followed by Marshal with
 	// UseCachedSize set will perform equivalently to Marshal alone.
 	UseCachedSize bool
+
+	// Concurrency is the maximum number of goroutines that MarshalToWriter
+	// uses to encode the elements of a repeated message field.
+	// Values less than 2 encode everything on the calling goroutine.
+	// It is ignored by all other methods.
+	Concurrency int
+
+	// sizes records the sizes of messages marshaled by the reflection-based
+	// implementation. It is only set for the duration of a marshal operation.
+	sizes *sizetable.Table
 }
 
 // Marshal returns the wire-format encoding of m.
@@ -162,6 +173,10 @@ func (o MarshalOptions) marshal(b []byte, m protoreflect.Message) (out protoifac
 		}
 		out, err = methods.Marshal(in)
 	} else {
+		if o.sizes == nil {
+			o.sizes = sizetable.Get()
+			defer sizetable.Put(o.sizes)
+		}
 		out.Buf, err = o.marshalMessageSlow(b, m)
 	}
 	if err != nil {
@@ -178,6 +193,22 @@ func (o MarshalOptions) marshalMessage(b []byte, m protoreflect.Message) ([]byte
 	return out.Buf, err
 }
 
+// marshalSubmessage appends the length-prefixed encoding of m to b.
+func (o MarshalOptions) marshalSubmessage(b []byte, m protoreflect.Message) ([]byte, error) {
+	if o.tracksSize(m) {
+		b = protowire.AppendVarint(b, uint64(o.size(m)))
+		return o.marshalMessage(b, m)
+	}
+	var pos int
+	var err error
+	b, pos = appendSpeculativeLength(b)
+	b, err = o.marshalMessage(b, m)
+	if err != nil {
+		return b, err
+	}
+	return finishSpeculativeLength(b, pos), nil
+}
+
 // growcap scales up the capacity of a slice.
 //
 // Given a slice with a current capacity of oldcap and a desired
@@ -276,8 +307,13 @@ func (o MarshalOptions) marshalMap(b []byte, fd protoreflect.FieldDescriptor, ma
 	var err error
 	order.RangeEntries(mapv, keyOrder, func(key protoreflect.MapKey, value protoreflect.Value) bool {
 		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+		exact := valf.Message() != nil && o.tracksSize(value.Message())
 		var pos int
-		b, pos = appendSpeculativeLength(b)
+		if exact {
+			b = protowire.AppendVarint(b, uint64(o.sizeField(keyf, key.Value())+o.sizeField(valf, value)))
+		} else {
+			b, pos = appendSpeculativeLength(b)
+		}
 
 		b, err = o.marshalField(b, keyf, key.Value())
 		if err != nil {
@@ -287,7 +323,9 @@ func (o MarshalOptions) marshalMap(b []byte, fd protoreflect.FieldDescriptor, ma
 		if err != nil {
 			return false
 		}
-		b = finishSpeculativeLength(b, pos)
+		if !exact {
+			b = finishSpeculativeLength(b, pos)
+		}
 		return true
 	})
 	return b, err
@@ -75,14 +75,11 @@ func (o MarshalOptions) marshalSingular(b []byte, fd protoreflect.FieldDescripto
 	case protoreflect.BytesKind:
 		b = protowire.AppendBytes(b, v.Bytes())
 	case protoreflect.MessageKind:
-		var pos int
 		var err error
-		b, pos = appendSpeculativeLength(b)
-		b, err = o.marshalMessage(b, v.Message())
+		b, err = o.marshalSubmessage(b, v.Message())
 		if err != nil {
 			return b, err
 		}
-		b = finishSpeculativeLength(b, pos)
 	case protoreflect.GroupKind:
 		var err error
 		b, err = o.marshalMessage(b, v.Message())
@@ -0,0 +1,328 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/encoding/messageset"
+	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/internal/sizetable"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// streamChunkSize is the amount of output buffered before it is written.
+// Messages no larger than this are encoded in a single step.
+const streamChunkSize = 64 << 10
+
+// streamBatchSize is the approximate amount of output encoded by each
+// goroutine when a repeated message field is marshaled concurrently.
+const streamBatchSize = 1 << 20
+
+// MarshalToWriter writes the wire-format encoding of m to w.
+//
+// Unlike MarshalAppend, MarshalToWriter never holds the complete encoding
+// in memory. After a single Size pass over m, large messages are encoded
+// field by field using the cached sizes and written to w in chunks.
+// If Concurrency is greater than one, the elements of repeated message
+// fields are encoded by up to that many goroutines at once.
+//
+// Fields are written in the order used by a deterministic marshal, so the
+// output is identical to that of Marshal when Deterministic is set.
+// The message must not be modified until MarshalToWriter returns.
+// If an error occurs, part of the encoding may already have been written.
+func (o MarshalOptions) MarshalToWriter(w io.Writer, m Message) error {
+	// Treat nil message interface as an empty message; nothing to output.
+	if m == nil {
+		return nil
+	}
+
+	mr := m.ProtoReflect()
+	if !o.AllowPartial {
+		if err := checkInitialized(mr); err != nil {
+			return err
+		}
+	}
+	o.AllowPartial = true
+	o.UseCachedSize = false
+	o.sizes = sizetable.Get()
+	defer sizetable.Put(o.sizes)
+	size := o.size(mr)
+	o.UseCachedSize = true
+
+	s := &streamMarshaler{o: o, w: w}
+	if err := s.message(mr, size); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// sizeCached returns the size of m, reusing the result of an earlier
+// size call on m where the message implementation supports it.
+func (o MarshalOptions) sizeCached(m protoreflect.Message) int {
+	if methods := protoMethods(m); methods != nil && methods.Size != nil {
+		out := methods.Size(protoiface.SizeInput{
+			Message: m,
+			Flags:   protoiface.MarshalUseCachedSize,
+		})
+		return out.Size
+	}
+	return o.size(m)
+}
+
+type streamMarshaler struct {
+	o   MarshalOptions
+	w   io.Writer
+	buf []byte
+}
+
+// message encodes m, which has an encoded size of n.
+func (s *streamMarshaler) message(m protoreflect.Message, n int) error {
+	if n <= streamChunkSize || !canStream(m) {
+		var err error
+		s.buf, err = s.o.marshalMessage(s.buf, m)
+		if err != nil {
+			return err
+		}
+		return s.maybeFlush()
+	}
+	var err error
+	order.RangeFields(m, order.LegacyFieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		err = s.field(fd, v)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	s.buf = append(s.buf, m.GetUnknown()...)
+	return s.maybeFlush()
+}
+
+// canStream reports whether m may be encoded one field at a time.
+func canStream(m protoreflect.Message) bool {
+	if messageset.IsMessageSet(m.Descriptor()) {
+		return false
+	}
+	// Legacy messages with a Marshal method but no Size method have
+	// an encoding that can only be produced by that method.
+	methods := protoMethods(m)
+	return methods == nil || methods.Marshal == nil || methods.Size != nil
+}
+
+func (s *streamMarshaler) field(fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch {
+	case fd.IsList():
+		return s.list(fd, v.List())
+	case fd.IsMap():
+		return s.mapField(fd, v.Map())
+	case fd.Kind() == protoreflect.MessageKind:
+		return s.messageField(fd.Number(), v.Message())
+	default:
+		var err error
+		s.buf, err = s.o.marshalField(s.buf, fd, v)
+		if err != nil {
+			return err
+		}
+		return s.maybeFlush()
+	}
+}
+
+func (s *streamMarshaler) messageField(num protowire.Number, m protoreflect.Message) error {
+	n := s.o.sizeCached(m)
+	s.buf = protowire.AppendTag(s.buf, num, protowire.BytesType)
+	s.buf = protowire.AppendVarint(s.buf, uint64(n))
+	return s.message(m, n)
+}
+
+func (s *streamMarshaler) list(fd protoreflect.FieldDescriptor, list protoreflect.List) error {
+	num, kind := fd.Number(), fd.Kind()
+	switch {
+	case list.Len() == 0:
+		return nil
+	case fd.IsPacked():
+		n := 0
+		for i, llen := 0, list.Len(); i < llen; i++ {
+			n += s.o.sizeSingular(num, kind, list.Get(i))
+		}
+		s.buf = protowire.AppendTag(s.buf, num, protowire.BytesType)
+		s.buf = protowire.AppendVarint(s.buf, uint64(n))
+		for i, llen := 0, list.Len(); i < llen; i++ {
+			var err error
+			s.buf, err = s.o.marshalSingular(s.buf, fd, list.Get(i))
+			if err != nil {
+				return err
+			}
+			if err := s.maybeFlush(); err != nil {
+				return err
+			}
+		}
+	case kind == protoreflect.MessageKind && s.o.Concurrency > 1:
+		return s.messageListConcurrent(num, list)
+	case kind == protoreflect.MessageKind:
+		for i, llen := 0, list.Len(); i < llen; i++ {
+			if err := s.messageField(num, list.Get(i).Message()); err != nil {
+				return err
+			}
+		}
+	default:
+		for i, llen := 0, list.Len(); i < llen; i++ {
+			var err error
+			s.buf = protowire.AppendTag(s.buf, num, wireTypes[kind])
+			s.buf, err = s.o.marshalSingular(s.buf, fd, list.Get(i))
+			if err != nil {
+				return err
+			}
+			if err := s.maybeFlush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *streamMarshaler) mapField(fd protoreflect.FieldDescriptor, mapv protoreflect.Map) error {
+	keyOrder := order.AnyKeyOrder
+	if s.o.Deterministic {
+		keyOrder = order.GenericKeyOrder
+	}
+	var err error
+	order.RangeEntries(mapv, keyOrder, func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		s.buf = protowire.AppendTag(s.buf, fd.Number(), protowire.BytesType)
+		var pos int
+		s.buf, pos = appendSpeculativeLength(s.buf)
+		s.buf, err = s.o.marshalField(s.buf, fd.MapKey(), key.Value())
+		if err != nil {
+			return false
+		}
+		s.buf, err = s.o.marshalField(s.buf, fd.MapValue(), value)
+		if err != nil {
+			return false
+		}
+		s.buf = finishSpeculativeLength(s.buf, pos)
+		err = s.maybeFlush()
+		return err == nil
+	})
+	return err
+}
+
+// streamBatch is a run of consecutive elements of a repeated message field
+// that are encoded together by a single goroutine.
+type streamBatch struct {
+	msgs  []protoreflect.Message
+	sizes []int
+	size  int // encoded size of all elements, including tags and lengths
+}
+
+// messageListConcurrent encodes the elements of a repeated message field.
+// Consecutive elements are grouped into batches that are encoded
+// concurrently and written in order. Elements too large to share a batch
+// are encoded individually.
+func (s *streamMarshaler) messageListConcurrent(num protowire.Number, list protoreflect.List) error {
+	var batches []streamBatch
+	var cur streamBatch
+	endBatch := func() {
+		if len(cur.msgs) > 0 {
+			batches = append(batches, cur)
+			cur = streamBatch{}
+		}
+	}
+	for i, llen := 0, list.Len(); i < llen; i++ {
+		m := list.Get(i).Message()
+		n := s.o.sizeCached(m)
+		if n > streamBatchSize {
+			endBatch()
+			if err := s.writeBatches(num, batches); err != nil {
+				return err
+			}
+			batches = batches[:0]
+			if err := s.messageField(num, m); err != nil {
+				return err
+			}
+			continue
+		}
+		cur.msgs = append(cur.msgs, m)
+		cur.sizes = append(cur.sizes, n)
+		cur.size += protowire.SizeTag(num) + protowire.SizeBytes(n)
+		if cur.size >= streamBatchSize {
+			endBatch()
+		}
+		if len(batches) == s.o.Concurrency {
+			if err := s.writeBatches(num, batches); err != nil {
+				return err
+			}
+			batches = batches[:0]
+		}
+	}
+	endBatch()
+	return s.writeBatches(num, batches)
+}
+
+// writeBatches encodes each batch on its own goroutine and writes
+// the results in order.
+func (s *streamMarshaler) writeBatches(num protowire.Number, batches []streamBatch) error {
+	if len(batches) == 0 {
+		return nil
+	}
+	out := make([][]byte, len(batches))
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	for i := range batches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out[i], errs[i] = s.o.marshalBatch(num, batches[i])
+		}(i)
+	}
+	wg.Wait()
+	for i, b := range out {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		if err := s.flush(); err != nil {
+			return err
+		}
+		if _, err := s.w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o MarshalOptions) marshalBatch(num protowire.Number, batch streamBatch) ([]byte, error) {
+	// The size table of the stream is not safe for concurrent use,
+	// so each batch records the sizes of its messages in its own table.
+	o.sizes = sizetable.Get()
+	defer sizetable.Put(o.sizes)
+	b := make([]byte, 0, batch.size)
+	for i, m := range batch.msgs {
+		var err error
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendVarint(b, uint64(batch.sizes[i]))
+		b, err = o.marshalMessage(b, m)
+		if err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+func (s *streamMarshaler) maybeFlush() error {
+	if len(s.buf) < streamChunkSize {
+		return nil
+	}
+	return s.flush()
+}
+
+func (s *streamMarshaler) flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	_, err := s.w.Write(s.buf)
+	s.buf = s.buf[:0]
+	return err
+}
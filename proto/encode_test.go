@@ -7,8 +7,10 @@ package proto_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"reflect"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -17,8 +19,13 @@ import (
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/runtime/protoimpl"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	"google.golang.org/protobuf/internal/errors"
+	legacy1pb "google.golang.org/protobuf/internal/testprotos/legacy/proto2_20160225_2fc053c5"
 	orderpb "google.golang.org/protobuf/internal/testprotos/order"
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
 	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
@@ -276,3 +283,323 @@ func TestEncodeEmpty(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalToWriter(t *testing.T) {
+	for _, test := range testValidMessages {
+		for _, m := range test.decodeTo {
+			t.Run(fmt.Sprintf("%s (%T)", test.desc, m), func(t *testing.T) {
+				opts := proto.MarshalOptions{
+					AllowPartial:  test.partial,
+					Deterministic: true,
+				}
+				want, err := opts.Marshal(m)
+				if err != nil {
+					t.Fatalf("Marshal error: %v", err)
+				}
+				var got bytes.Buffer
+				if err := opts.MarshalToWriter(&got, m); err != nil {
+					t.Fatalf("MarshalToWriter error: %v", err)
+				}
+				if !bytes.Equal(got.Bytes(), want) {
+					t.Errorf("MarshalToWriter output differs from Marshal:\ngot:  [%x]\nwant: [%x]", got.Bytes(), want)
+				}
+			})
+		}
+	}
+}
+
+// chunkWriter counts the number of Write calls.
+type chunkWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *chunkWriter) Write(b []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(b)
+}
+
+func TestMarshalToWriterLarge(t *testing.T) {
+	nested := func(i, n int) *testpb.TestAllTypes_NestedMessage {
+		return &testpb.TestAllTypes_NestedMessage{
+			A: proto.Int32(int32(i)),
+			Corecursive: &testpb.TestAllTypes{
+				OptionalBytes:  bytes.Repeat([]byte{byte(i)}, n),
+				RepeatedString: []string{fmt.Sprint(i)},
+			},
+		}
+	}
+	m := &testpb.TestAllTypes{
+		OptionalInt32:         proto.Int32(1),
+		OptionalNestedMessage: nested(-1, 3<<20),
+		MapStringString:       map[string]string{},
+		OneofField:            &testpb.TestAllTypes_OneofString{OneofString: "oneof"},
+	}
+	for i := 0; i < 5000; i++ {
+		m.RepeatedNestedMessage = append(m.RepeatedNestedMessage, nested(i, 500))
+		m.RepeatedInt32 = append(m.RepeatedInt32, int32(i))
+		m.MapStringString[fmt.Sprint("key", i)] = fmt.Sprint("value", i)
+	}
+	m.RepeatedNestedMessage[100] = nested(100, 2<<20)
+	m.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 10000, protowire.BytesType), make([]byte, 100<<10)))
+
+	x := &testpb.TestAllExtensions{}
+	proto.SetExtension(x, testpb.E_OptionalInt32, int32(1))
+	proto.SetExtension(x, testpb.E_RepeatedNestedMessage, []*testpb.TestAllExtensions_NestedMessage{
+		{A: proto.Int32(1), Corecursive: &testpb.TestAllExtensions{}},
+	})
+	var xs []*testpb.TestAllExtensions_NestedMessage
+	for i := 0; i < 2000; i++ {
+		c := &testpb.TestAllExtensions{}
+		proto.SetExtension(c, testpb.E_OptionalBytes, make([]byte, 1000))
+		xs = append(xs, &testpb.TestAllExtensions_NestedMessage{A: proto.Int32(int32(i)), Corecursive: c})
+	}
+	proto.SetExtension(x, testpb.E_RepeatedNestedMessage, xs)
+
+	packed := &testpb.TestPackedTypes{}
+	for i := 0; i < 100000; i++ {
+		packed.PackedInt32 = append(packed.PackedInt32, int32(i))
+		packed.PackedDouble = append(packed.PackedDouble, float64(i))
+	}
+
+	for _, m := range []proto.Message{m, x, packed} {
+		want, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		dm := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+		if err := (proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(want, dm); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		for _, m := range []proto.Message{m, dm} {
+			for _, concurrency := range []int{0, 4} {
+				t.Run(fmt.Sprintf("%T/Concurrency=%v", m, concurrency), func(t *testing.T) {
+					opts := proto.MarshalOptions{
+						Deterministic: true,
+						Concurrency:   concurrency,
+					}
+					var w chunkWriter
+					if err := opts.MarshalToWriter(&w, m); err != nil {
+						t.Fatalf("MarshalToWriter error: %v", err)
+					}
+					if !bytes.Equal(w.Bytes(), want) {
+						t.Errorf("MarshalToWriter output differs from Marshal (len %v, want %v)", w.Len(), len(want))
+					}
+					if w.writes < 2 {
+						t.Errorf("MarshalToWriter made %v writes, want several", w.writes)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestMarshalToWriterErrors(t *testing.T) {
+	var w bytes.Buffer
+	m := &testpb.TestRequiredForeign{OptionalMessage: &testpb.TestRequired{}}
+	if err := (proto.MarshalOptions{}).MarshalToWriter(&w, m); err == nil {
+		t.Errorf("MarshalToWriter of message with missing required field succeeded, want error")
+	}
+	if w.Len() > 0 {
+		t.Errorf("MarshalToWriter wrote %v bytes for message with missing required field, want none", w.Len())
+	}
+
+	m3 := &test3pb.TestAllTypes{}
+	for i := 0; i < 10000; i++ {
+		m3.RepeatedNestedMessage = append(m3.RepeatedNestedMessage, &test3pb.TestAllTypes_NestedMessage{A: int32(i)})
+	}
+	m3.RepeatedNestedMessage[5000].Corecursive = &test3pb.TestAllTypes{SingularString: "\xff"}
+	for _, concurrency := range []int{0, 4} {
+		err := proto.MarshalOptions{Concurrency: concurrency}.MarshalToWriter(ioutil.Discard, m3)
+		if !errors.Is(err, proto.Error) {
+			t.Errorf("MarshalToWriter of invalid UTF-8 with Concurrency=%v: got error %v, want proto.Error", concurrency, err)
+		}
+	}
+}
+
+func TestEncodeDeep(t *testing.T) {
+	// Messages without a size cache of their own must still encode
+	// correctly when their sizes are recorded for the duration of a call.
+	const depth = 200
+	m := &testpb.TestAllTypes{}
+	for i, n := 0, m; i < depth; i++ {
+		n.OptionalString = proto.String(fmt.Sprint("level", i))
+		n.RepeatedInt32 = []int32{int32(i)}
+		n.MapStringNestedMessage = map[string]*testpb.TestAllTypes_NestedMessage{
+			"a": {A: proto.Int32(int32(i))},
+		}
+		n.OptionalNestedMessage = &testpb.TestAllTypes_NestedMessage{
+			Corecursive: &testpb.TestAllTypes{},
+		}
+		n = n.OptionalNestedMessage.Corecursive
+	}
+	want, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	dm := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+	if err := proto.Unmarshal(want, dm); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	got, err := proto.MarshalOptions{Deterministic: true}.Marshal(dm)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Marshal of dynamic message differs from generated message:\ngot:  [%x]\nwant: [%x]", got, want)
+	}
+
+	lm := &legacy1pb.Message{}
+	for i, n := 0, lm; i < depth; i++ {
+		n.OptionalString = proto.String(fmt.Sprint("level", i))
+		n.OptionalChildMessage = &legacy1pb.Message_ChildMessage{
+			F1: proto.String(fmt.Sprint("child", i)),
+			F4: &legacy1pb.Message{},
+		}
+		n = n.OptionalChildMessage.F4
+	}
+	lmsg := protoimpl.X.MessageOf(lm).Interface()
+	b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(lmsg)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if got, want := len(b), proto.Size(lmsg); got != want {
+		t.Errorf("len(Marshal(m)) = %v, want Size(m) = %v", got, want)
+	}
+	lmsg2 := protoimpl.X.MessageOf(&legacy1pb.Message{}).Interface()
+	if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(b, lmsg2); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !proto.Equal(lmsg, lmsg2) {
+		t.Errorf("round-trip marshal of legacy message is not equal to the original")
+	}
+}
+
+// rangeCounter wraps a message and the messages it contains,
+// counting the calls to Range on any of them.
+type rangeCounter struct {
+	pref.Message
+	n *int64
+}
+
+func (m rangeCounter) ProtoReflect() pref.Message        { return m }
+func (m rangeCounter) Interface() pref.ProtoMessage      { return m }
+func (m rangeCounter) ProtoMethods() *protoiface.Methods { return nil }
+
+func (m rangeCounter) wrap(fd pref.FieldDescriptor, v pref.Value) pref.Value {
+	switch {
+	case fd.Message() == nil || fd.IsMap():
+		return v
+	case fd.IsList():
+		return pref.ValueOfList(rangeCounterList{v.List(), m.n})
+	default:
+		return pref.ValueOfMessage(rangeCounter{v.Message(), m.n})
+	}
+}
+func (m rangeCounter) Get(fd pref.FieldDescriptor) pref.Value {
+	return m.wrap(fd, m.Message.Get(fd))
+}
+func (m rangeCounter) Range(f func(pref.FieldDescriptor, pref.Value) bool) {
+	atomic.AddInt64(m.n, 1)
+	m.Message.Range(func(fd pref.FieldDescriptor, v pref.Value) bool {
+		return f(fd, m.wrap(fd, v))
+	})
+}
+
+// rangeCounterList wraps the elements of a list of messages in rangeCounter.
+type rangeCounterList struct {
+	pref.List
+	n *int64
+}
+
+func (l rangeCounterList) Get(i int) pref.Value {
+	return pref.ValueOfMessage(rangeCounter{l.List.Get(i).Message(), l.n})
+}
+
+// deepMessage returns a message with 2*depth+1 nested messages,
+// the innermost of which holds a bytes field of the given length.
+func deepMessage(depth, bytes int) *testpb.TestAllTypes {
+	m := &testpb.TestAllTypes{}
+	n := m
+	for i := 0; i < depth; i++ {
+		n.OptionalInt32 = proto.Int32(int32(i))
+		n.OptionalNestedMessage = &testpb.TestAllTypes_NestedMessage{
+			Corecursive: &testpb.TestAllTypes{},
+		}
+		n = n.OptionalNestedMessage.Corecursive
+	}
+	n.OptionalBytes = make([]byte, bytes)
+	return m
+}
+
+func TestEncodeDeepLinear(t *testing.T) {
+	// Sizing the messages of a deeply nested message without size caches
+	// must visit each message a constant number of times, rather than once
+	// for each of its ancestors.
+	const depth = 200
+	const elems = 4
+	deep := deepMessage(depth, 0)
+	// The elements are large enough to be streamed in separate batches.
+	wide := &testpb.TestAllTypes{}
+	for i := 0; i < elems; i++ {
+		wide.RepeatedNestedMessage = append(wide.RepeatedNestedMessage, &testpb.TestAllTypes_NestedMessage{
+			Corecursive: deepMessage(depth, 600<<10),
+		})
+	}
+
+	for _, test := range []struct {
+		desc     string
+		m        proto.Message
+		messages int
+		marshal  func(proto.Message) ([]byte, error)
+		// perMessage is the number of times each message may be ranged over:
+		// once to compute its size, and once to encode it. A concurrent
+		// marshal sizes each message again within its batch.
+		perMessage int
+	}{{
+		desc:     "Marshal",
+		m:        deep,
+		messages: 2*depth + 1,
+		marshal: func(m proto.Message) ([]byte, error) {
+			return proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(m)
+		},
+		perMessage: 2,
+	}, {
+		desc:     "MarshalToWriter",
+		m:        wide,
+		messages: 1 + elems*(2*depth+2),
+		marshal: func(m proto.Message) ([]byte, error) {
+			var b bytes.Buffer
+			err := proto.MarshalOptions{
+				AllowPartial:  true,
+				Deterministic: true,
+				Concurrency:   elems,
+			}.MarshalToWriter(&b, m)
+			return b.Bytes(), err
+		},
+		perMessage: 3,
+	}} {
+		t.Run(test.desc, func(t *testing.T) {
+			want, err := proto.MarshalOptions{Deterministic: true}.Marshal(test.m)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			dm := dynamicpb.NewMessage(test.m.ProtoReflect().Descriptor())
+			if err := proto.Unmarshal(want, dm); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+
+			var ranges int64
+			got, err := test.marshal(rangeCounter{dm, &ranges})
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Marshal of wrapped message differs from generated message")
+			}
+			if max := int64(test.perMessage * test.messages); ranges > max {
+				t.Errorf("Marshal called Range %v times for %v messages, want at most %v", ranges, test.messages, max)
+			}
+		})
+	}
+}
@@ -7,10 +7,10 @@ package proto
 import (
 	"bytes"
 	"math"
-	"reflect"
 
-	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/rawfields"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
 )
 
 // Equal reports whether two messages are equal.
@@ -38,6 +38,15 @@ func Equal(x, y Message) bool {
 	if mx.IsValid() != my.IsValid() {
 		return false
 	}
+	if methods := protoMethods(mx); methods != nil && methods.Equal != nil && methods == protoMethods(my) {
+		out := methods.Equal(protoiface.EqualInput{
+			MessageA: mx,
+			MessageB: my,
+		})
+		if out.Flags&protoiface.EqualComplete != 0 {
+			return out.Flags&protoiface.EqualEqual != 0
+		}
+	}
 	return equalMessage(mx, my)
 }
 
@@ -67,7 +76,7 @@ func equalMessage(mx, my pref.Message) bool {
 		return false
 	}
 
-	return equalUnknown(mx.GetUnknown(), my.GetUnknown())
+	return rawfields.Equal(mx.GetUnknown(), my.GetUnknown())
 }
 
 // equalField compares two fields.
@@ -140,28 +149,3 @@ func equalValue(fd pref.FieldDescriptor, x, y pref.Value) bool {
 		return x.Interface() == y.Interface()
 	}
 }
-
-// equalUnknown compares unknown fields by direct comparison on the raw bytes
-// of each individual field number.
-func equalUnknown(x, y pref.RawFields) bool {
-	if len(x) != len(y) {
-		return false
-	}
-	if bytes.Equal([]byte(x), []byte(y)) {
-		return true
-	}
-
-	mx := make(map[pref.FieldNumber]pref.RawFields)
-	my := make(map[pref.FieldNumber]pref.RawFields)
-	for len(x) > 0 {
-		fnum, _, n := protowire.ConsumeField(x)
-		mx[fnum] = append(mx[fnum], x[:n]...)
-		x = x[n:]
-	}
-	for len(y) > 0 {
-		fnum, _, n := protowire.ConsumeField(y)
-		my[fnum] = append(my[fnum], y[:n]...)
-		y = y[n:]
-	}
-	return reflect.DeepEqual(mx, my)
-}
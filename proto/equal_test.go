@@ -182,6 +182,48 @@ func TestEqual(t *testing.T) {
 			x:  &testpb.TestAllTypes{OptionalNestedEnum: testpb.TestAllTypes_FOO.Enum()},
 			y:  &testpb.TestAllTypes{OptionalNestedEnum: testpb.TestAllTypes_FOO.Enum()},
 			eq: true,
+		}, {
+			x:  &test3pb.TestAllTypes{SingularDouble: math.NaN()},
+			y:  &test3pb.TestAllTypes{SingularDouble: math.NaN()},
+			eq: true,
+		}, {
+			x:  &test3pb.TestAllTypes{SingularDouble: math.Copysign(0, -1)},
+			y:  &test3pb.TestAllTypes{SingularDouble: math.Copysign(0, -1)},
+			eq: true,
+		}, {
+			x:  &test3pb.TestAllTypes{RepeatedDouble: []float64{math.NaN()}},
+			y:  &test3pb.TestAllTypes{RepeatedDouble: []float64{math.NaN()}},
+			eq: true,
+		}, {
+			x:  &test3pb.TestAllTypes{MapInt32Double: map[int32]float64{1: math.NaN()}},
+			y:  &test3pb.TestAllTypes{MapInt32Double: map[int32]float64{1: math.NaN()}},
+			eq: true,
+		}, {
+			x:  &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofBytes{}},
+			y:  &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofBytes{OneofBytes: []byte{}}},
+			eq: true,
+		}, {
+			x:  &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofNestedMessage{}},
+			y:  &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofNestedMessage{OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{}}},
+			eq: true,
+		}, {
+			x: &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofNestedMessage{}},
+			y: &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofNestedMessage{OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+			}}},
+		}, {
+			x:  &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofNestedMessage{}},
+			y:  &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofNestedMessage{OneofNestedMessage: &test3pb.TestAllTypes_NestedMessage{}}},
+			eq: true,
+		}, {
+			x: &testpb.TestAllTypes{RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{}}},
+			y: &testpb.TestAllTypes{RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{
+				Corecursive: &testpb.TestAllTypes{},
+			}}},
+		}, {
+			x:  &test3pb.TestAllTypes{RepeatedNestedMessage: []*test3pb.TestAllTypes_NestedMessage{{A: 1}}},
+			y:  &test3pb.TestAllTypes{RepeatedNestedMessage: []*test3pb.TestAllTypes_NestedMessage{{A: 1}}},
+			eq: true,
 		},
 
 		// Proto2 presence.
@@ -286,6 +328,27 @@ func TestEqual(t *testing.T) {
 			y: &test3pb.TestAllTypes{OptionalNestedEnum: test3pb.TestAllTypes_FOO.Enum()},
 		},
 
+		// Negative zero is populated in proto3, while positive zero is not.
+		{
+			x: &test3pb.TestAllTypes{},
+			y: &test3pb.TestAllTypes{SingularDouble: math.Copysign(0, -1)},
+		}, {
+			x: &test3pb.TestAllTypes{SingularFloat: 0},
+			y: &test3pb.TestAllTypes{SingularFloat: float32(math.Copysign(0, -1))},
+		},
+
+		// Oneof fields.
+		{
+			x: &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofUint32{}},
+			y: &test3pb.TestAllTypes{},
+		}, {
+			x: &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofUint32{}},
+			y: &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofBool{}},
+		}, {
+			x: &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofString{OneofString: "a"}},
+			y: &test3pb.TestAllTypes{OneofField: &test3pb.TestAllTypes_OneofString{OneofString: "b"}},
+		},
+
 		// Proto2 default values are not considered by Equal, so the following are still unequal.
 		{
 			x: &testpb.TestAllTypes{DefaultInt32: proto.Int32(81)},
@@ -367,6 +430,9 @@ func TestEqual(t *testing.T) {
 		}, {
 			x: &test3pb.TestAllTypes{},
 			y: &test3pb.TestAllTypes{OptionalNestedMessage: &test3pb.TestAllTypes_NestedMessage{}},
+		}, {
+			x: &test3pb.TestAllTypes{},
+			y: &test3pb.TestAllTypes{SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{}},
 		},
 
 		// Lists.
@@ -562,3 +628,13 @@ func TestEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestEqualMessages(t *testing.T) {
+	for _, test := range testValidMessages {
+		for _, m := range test.decodeTo {
+			if !proto.Equal(m, proto.Clone(m)) {
+				t.Errorf("%v: Equal(m, Clone(m)) = false, want true\n%v", test.desc, prototext.Format(m))
+			}
+		}
+	}
+}
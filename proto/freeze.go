@@ -0,0 +1,68 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// Freeze makes m and the messages it contains read-only, and returns m.
+//
+// Methods of a frozen message, or of any list or map obtained from it,
+// that would modify it, such as Set, Clear, and Mutable, panic, as does
+// merging into it. Marshaling a frozen message does not record sizes in it.
+// Provided that m is no longer modified directly, it may therefore be shared
+// among goroutines that read, marshal, compare, and clone it concurrently.
+//
+// Freezing does not prevent modifying the Go struct fields of a generated
+// message, nor resetting it with its Reset method, which also makes it
+// modifiable again.
+// Messages that do not support freezing, such as those built with the
+// purego tag, are left unchanged.
+//
+// Since Freeze returns m itself, the result may be asserted to the concrete
+// type of m.
+func Freeze(m Message) Message {
+	if m == nil {
+		return nil
+	}
+	freezeMessage(m.ProtoReflect())
+	return m
+}
+
+func freezeMessage(m protoreflect.Message) {
+	if !m.IsValid() {
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					freezeMessage(list.Get(i).Message())
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					freezeMessage(v.Message())
+					return true
+				})
+			}
+		case fd.Message() != nil:
+			freezeMessage(v.Message())
+		}
+		return true
+	})
+	// Freezing has no reflective implementation, so the Freeze method is
+	// used even when other fast-path methods are disabled.
+	if methods := m.ProtoMethods(); methods != nil && methods.Freeze != nil {
+		methods.Freeze(protoiface.FreezeInput{
+			Message: m,
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoimpl"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	codecpb "google.golang.org/protobuf/internal/testprotos/codec"
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+func freezeMessages() []proto.Message {
+	m2 := &testpb.TestAllTypes{
+		OptionalInt32:         proto.Int32(1),
+		OptionalString:        proto.String("string"),
+		OptionalBytes:         []byte("bytes"),
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(2)},
+		Optionalgroup:         &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(3)},
+		RepeatedInt32:         []int32{4, 5},
+		RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+			{A: proto.Int32(6)},
+			{Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(7)}},
+		},
+		MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+			"a": {A: proto.Int32(8)},
+		},
+		OneofField: &testpb.TestAllTypes_OneofNestedMessage{
+			OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(9)},
+		},
+	}
+	m2.ProtoReflect().SetUnknown([]byte{0xf8, 0x1f, 0x01})
+
+	m3 := &test3pb.TestAllTypes{
+		SingularInt32:         1,
+		SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 2},
+		RepeatedNestedMessage: []*test3pb.TestAllTypes_NestedMessage{{A: 3}},
+		MapStringNestedMessage: map[string]*test3pb.TestAllTypes_NestedMessage{
+			"a": {A: 4},
+		},
+	}
+
+	codec := &codecpb.TestAllTypes{
+		OptionalInt32:          proto.Int32(1),
+		OptionalNestedMessage:  &codecpb.TestAllTypes_NestedMessage{A: proto.Int32(2)},
+		OptionalForeignMessage: &codecpb.ForeignMessage{C: proto.Int32(3)},
+		RepeatedNestedMessage:  []*codecpb.TestAllTypes_NestedMessage{{A: proto.Int32(4)}},
+	}
+
+	return []proto.Message{m2, m3, codec}
+}
+
+func skipFreezeUnsupported(t *testing.T) {
+	if !protoimpl.UnsafeEnabled {
+		t.Skip("messages accessed through reflection cannot be frozen")
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	skipFreezeUnsupported(t)
+	for _, m := range freezeMessages() {
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			want, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			fm := proto.Freeze(m)
+			if fm != m {
+				t.Errorf("Freeze returned %p, want the original message %p", fm, m)
+			}
+			if proto.Freeze(fm) != fm {
+				t.Errorf("Freeze of a frozen message returned a different message")
+			}
+			if got := fm.ProtoReflect().Interface(); got != m {
+				t.Errorf("Interface of frozen message returned %p, want the original message %p", got, m)
+			}
+
+			got, err := proto.MarshalOptions{Deterministic: true}.Marshal(fm)
+			if err != nil {
+				t.Fatalf("Marshal of frozen message error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Marshal of frozen message:\ngot  %x\nwant %x", got, want)
+			}
+			if got, want := proto.Size(fm), len(want); got != want {
+				t.Errorf("Size of frozen message = %v, want %v", got, want)
+			}
+			if !proto.Equal(fm, m) || !proto.Equal(fm, proto.Freeze(m)) {
+				t.Errorf("frozen message is not equal to the original")
+			}
+			c := proto.Clone(fm)
+			if !proto.Equal(c, m) {
+				t.Errorf("Clone of frozen message is not equal to the original")
+			}
+			proto.Reset(c) // the clone is mutable
+		})
+	}
+}
+
+func TestFreezeConcreteType(t *testing.T) {
+	skipFreezeUnsupported(t)
+	m, ok := proto.Freeze(&testpb.TestAllTypes{
+		OptionalInt32:         proto.Int32(1),
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(2)},
+	}).(*testpb.TestAllTypes)
+	if !ok {
+		t.Fatalf("Freeze did not return a *testpb.TestAllTypes")
+	}
+	if got, want := m.GetOptionalInt32(), int32(1); got != want {
+		t.Errorf("GetOptionalInt32() = %v, want %v", got, want)
+	}
+
+	// Messages obtained from the Go fields of a frozen message are frozen.
+	nm := m.GetOptionalNestedMessage().ProtoReflect()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Clear on message contained in frozen message did not panic")
+			}
+		}()
+		nm.Clear(nm.Descriptor().Fields().ByName("a"))
+	}()
+	if got, want := m.GetOptionalNestedMessage().GetA(), int32(2); got != want {
+		t.Errorf("GetOptionalNestedMessage().GetA() = %v, want %v", got, want)
+	}
+}
+
+func TestFreezeReset(t *testing.T) {
+	skipFreezeUnsupported(t)
+	m := proto.Freeze(&testpb.TestAllTypes{OptionalInt32: proto.Int32(1)}).(*testpb.TestAllTypes)
+	m.Reset()
+	fd := m.ProtoReflect().Descriptor().Fields().ByName("optional_int32")
+	m.ProtoReflect().Set(fd, protoreflect.ValueOfInt32(2)) // must not panic
+	if got, want := m.GetOptionalInt32(), int32(2); got != want {
+		t.Errorf("GetOptionalInt32() after Reset and Set = %v, want %v", got, want)
+	}
+}
+
+func TestFreezeUnsupported(t *testing.T) {
+	m := dynamicpb.NewMessage((&testpb.TestAllTypes{}).ProtoReflect().Descriptor())
+	if got := proto.Freeze(m); got != m {
+		t.Errorf("Freeze returned %p, want the original message %p", got, m)
+	}
+	fd := m.Descriptor().Fields().ByName("optional_int32")
+	m.Set(fd, protoreflect.ValueOfInt32(1)) // must not panic
+	if got, want := m.Get(fd).Int(), int64(1); got != want {
+		t.Errorf("Get after Set = %v, want %v", got, want)
+	}
+}
+
+func TestFreezeNil(t *testing.T) {
+	if got := proto.Freeze(nil); got != nil {
+		t.Errorf("Freeze(nil) = %v, want nil", got)
+	}
+	fm := proto.Freeze((*testpb.TestAllTypes)(nil))
+	if fm.ProtoReflect().IsValid() {
+		t.Errorf("Freeze of a nil message is valid")
+	}
+	if b, err := proto.Marshal(fm); err != nil || len(b) != 0 {
+		t.Errorf("Marshal of frozen nil message = %x, %v; want empty", b, err)
+	}
+}
+
+func TestFreezeMutationPanics(t *testing.T) {
+	skipFreezeUnsupported(t)
+	for _, m := range freezeMessages() {
+		b, err := proto.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		fm := proto.Freeze(m).ProtoReflect()
+		md := fm.Descriptor()
+		fields := md.Fields()
+		scalar := fields.Get(0)
+		var list, mapField, msgField protoreflect.FieldDescriptor
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			switch {
+			case fd.IsList() && fd.Message() != nil && fm.Has(fd) && list == nil:
+				list = fd
+			case fd.IsMap() && fm.Has(fd) && mapField == nil:
+				mapField = fd
+			case fd.Message() != nil && !fd.IsList() && !fd.IsMap() && fm.Has(fd) && msgField == nil:
+				msgField = fd
+			}
+		}
+
+		tests := map[string]func(){
+			"Set":        func() { fm.Set(scalar, fm.Get(scalar)) },
+			"Clear":      func() { fm.Clear(scalar) },
+			"Mutable":    func() { fm.Mutable(msgField) },
+			"SetUnknown": func() { fm.SetUnknown(nil) },
+			"Merge":      func() { proto.Merge(fm.Interface(), m) },
+			"Unmarshal":  func() { proto.UnmarshalOptions{Merge: true}.Unmarshal(b, fm.Interface()) },
+			"ResetOptions": func() {
+				proto.ResetOptions{RetainCapacity: true}.Reset(fm.Interface())
+			},
+			"nested Set": func() {
+				nm := fm.Get(msgField).Message()
+				nm.Set(nm.Descriptor().Fields().Get(0), nm.NewField(nm.Descriptor().Fields().Get(0)))
+			},
+			"list Set":           func() { l := fm.Get(list).List(); l.Set(0, l.Get(0)) },
+			"list Append":        func() { l := fm.Get(list).List(); l.Append(l.NewElement()) },
+			"list AppendMutable": func() { fm.Get(list).List().AppendMutable() },
+			"list Truncate":      func() { fm.Get(list).List().Truncate(0) },
+			"list element Clear": func() {
+				em := fm.Get(list).List().Get(0).Message()
+				em.Clear(em.Descriptor().Fields().Get(0))
+			},
+			"map Set":     func() { mp := fm.Get(mapField).Map(); mp.Set(protoreflect.ValueOfString("b").MapKey(), mp.NewValue()) },
+			"map Clear":   func() { fm.Get(mapField).Map().Clear(protoreflect.ValueOfString("a").MapKey()) },
+			"map Mutable": func() { fm.Get(mapField).Map().Mutable(protoreflect.ValueOfString("a").MapKey()) },
+			"map value Clear": func() {
+				vm := fm.Get(mapField).Map().Get(protoreflect.ValueOfString("a").MapKey()).Message()
+				vm.Clear(vm.Descriptor().Fields().Get(0))
+			},
+		}
+		if mapField == nil {
+			for name := range tests {
+				if len(name) > 4 && name[:4] == "map " {
+					delete(tests, name)
+				}
+			}
+		}
+		for name, f := range tests {
+			t.Run(fmt.Sprintf("%T/%v", m, name), func(t *testing.T) {
+				before, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+				func() {
+					defer func() {
+						if recover() == nil {
+							t.Errorf("%v on frozen message did not panic", name)
+						}
+					}()
+					f()
+				}()
+				after, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+				if !reflect.DeepEqual(before, after) {
+					t.Errorf("%v on frozen message modified the original", name)
+				}
+			})
+		}
+	}
+}
+
+// sizeCaches returns the size cache of every generated message in m.
+func sizeCaches(m protoreflect.Message) (sizes []int64) {
+	if v := reflect.ValueOf(m.Interface()); v.Kind() == reflect.Ptr {
+		if f := v.Elem().FieldByName("sizeCache"); f.IsValid() {
+			sizes = append(sizes, f.Int())
+		}
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i, l := 0, v.List(); i < l.Len(); i++ {
+				sizes = append(sizes, sizeCaches(l.Get(i).Message())...)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				sizes = append(sizes, sizeCaches(v.Message())...)
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			sizes = append(sizes, sizeCaches(v.Message())...)
+		}
+		return true
+	})
+	return sizes
+}
+
+func TestFreezeMarshalReadOnly(t *testing.T) {
+	skipFreezeUnsupported(t)
+	for _, m := range freezeMessages() {
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			before := sizeCaches(m.ProtoReflect())
+			fm := proto.Freeze(m)
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					proto.Size(fm)
+					proto.MarshalOptions{UseCachedSize: true}.Marshal(fm)
+					proto.Marshal(fm)
+				}()
+			}
+			wg.Wait()
+			if after := sizeCaches(m.ProtoReflect()); !reflect.DeepEqual(after, before) {
+				t.Errorf("marshaling frozen message changed size caches from %v to %v", before, after)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package proto_test
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"sync"
 	"testing"
@@ -316,6 +317,21 @@ var testMerges = []testMerge{{
 			},
 		},
 	},
+}, {
+	desc: "merge negative zero floats",
+	dst: protobuild.Message{
+		"singular_float":  1,
+		"singular_double": 1,
+	},
+	src: protobuild.Message{
+		"singular_float":  math.Copysign(0, -1),
+		"singular_double": math.Copysign(0, -1),
+	},
+	want: protobuild.Message{
+		"singular_float":  math.Copysign(0, -1),
+		"singular_double": math.Copysign(0, -1),
+	},
+	types: []proto.Message{&test3pb.TestAllTypes{}},
 }, {
 	desc: "merge list fields",
 	dst: protobuild.Message{
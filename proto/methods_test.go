@@ -16,8 +16,11 @@ import (
 	"google.golang.org/protobuf/internal/impl"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/runtime/protoimpl"
 
 	legacypb "google.golang.org/protobuf/internal/testprotos/legacy"
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
 )
 
 type selfMarshaler struct {
@@ -182,3 +185,181 @@ func TestLegacyMergeMethod(t *testing.T) {
 		t.Errorf("Merge(dst, src): want src.src = nil, got %v", got)
 	}
 }
+
+func TestUnmarshalRetainCapacityReuse(t *testing.T) {
+	b, err := proto.Marshal(&testpb.TestAllTypes{
+		RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+			{A: proto.Int32(1)},
+			{A: proto.Int32(2)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &testpb.TestAllTypes{}
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	nested := m.RepeatedNestedMessage[0]
+	if err := (proto.UnmarshalOptions{RetainCapacity: true}).Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	if m.RepeatedNestedMessage[0] != nested {
+		t.Errorf("Unmarshal with RetainCapacity did not reuse repeated message element")
+	}
+
+	// Elements are not reused unless requested.
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	if m.RepeatedNestedMessage[0] == nested {
+		t.Errorf("Unmarshal without RetainCapacity reused repeated message element")
+	}
+}
+
+func TestUnmarshalRetainCapacityReuseSingular(t *testing.T) {
+	full := &testpb.TestAllTypes{
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			A:           proto.Int32(1),
+			Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(2)},
+		},
+		OneofField: &testpb.TestAllTypes_OneofNestedMessage{
+			OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(3)},
+		},
+	}
+	b, err := proto.Marshal(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smaller := &testpb.TestAllTypes{
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(4)},
+	}
+	b2, err := proto.Marshal(smaller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := proto.UnmarshalOptions{RetainCapacity: true}
+	m := &testpb.TestAllTypes{}
+	if err := opts.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	nested := m.OptionalNestedMessage
+	oneof := m.OneofField.(*testpb.TestAllTypes_OneofNestedMessage).OneofNestedMessage
+	if err := opts.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	if m.OptionalNestedMessage != nested {
+		t.Errorf("Unmarshal with RetainCapacity did not reuse singular message field")
+	}
+	if o, ok := m.OneofField.(*testpb.TestAllTypes_OneofNestedMessage); !ok || o.OneofNestedMessage != oneof {
+		t.Errorf("Unmarshal with RetainCapacity did not reuse oneof message field")
+	}
+
+	// Fields that the input does not populate are left unpopulated.
+	if err := opts.Unmarshal(b2, m); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(m, smaller) {
+		t.Errorf("Unmarshal with RetainCapacity:\ngot:  %v\nwant: %v", m, smaller)
+	}
+	if m.OptionalNestedMessage.Corecursive != nil || m.OneofField != nil {
+		t.Errorf("Unmarshal with RetainCapacity left retained message fields populated")
+	}
+}
+
+func TestRetainCapacityCachedSize(t *testing.T) {
+	newMessage := func() *testpb.TestAllTypes {
+		return &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A:           proto.Int32(1),
+				Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(2)},
+			},
+			OneofField: &testpb.TestAllTypes_OneofNestedMessage{
+				OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(3)},
+			},
+		}
+	}
+	b, err := proto.Marshal(&testpb.TestAllTypes{OptionalInt32: proto.Int32(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		desc  string
+		reset func(*testpb.TestAllTypes) error
+	}{{
+		desc: "Reset",
+		reset: func(m *testpb.TestAllTypes) error {
+			proto.ResetOptions{RetainCapacity: true}.Reset(m)
+			return nil
+		},
+	}, {
+		desc: "Unmarshal",
+		reset: func(m *testpb.TestAllTypes) error {
+			return proto.UnmarshalOptions{RetainCapacity: true}.Unmarshal(b, m)
+		},
+	}} {
+		t.Run(test.desc, func(t *testing.T) {
+			m := newMessage()
+			proto.Size(m) // populate the size caches
+			nested := m.OptionalNestedMessage
+			oneof := m.OneofField.(*testpb.TestAllTypes_OneofNestedMessage).OneofNestedMessage
+			if err := test.reset(m); err != nil {
+				t.Fatal(err)
+			}
+
+			// The messages that were kept for reuse but not unmarshaled
+			// into must not carry any marker in their size caches.
+			for _, m := range []proto.Message{m, nested, oneof} {
+				for _, size := range sizeCaches(m.ProtoReflect()) {
+					if size < 0 {
+						t.Errorf("%T has size cache %v after %v", m, size, test.desc)
+					}
+				}
+				want, err := proto.Marshal(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := proto.MarshalOptions{UseCachedSize: true}.Marshal(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("Marshal of %T with UseCachedSize after %v:\ngot  %x\nwant %x", m, test.desc, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalRetainCapacityAllocs(t *testing.T) {
+	if !protoimpl.UnsafeEnabled {
+		t.Skip("reflection-based message access allocates")
+	}
+	b, err := proto.Marshal(&test3pb.TestAllTypes{
+		SingularInt32:         1,
+		RepeatedInt32:         []int32{1, 2, 3, 4, 5},
+		SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 4},
+		RepeatedNestedMessage: []*test3pb.TestAllTypes_NestedMessage{
+			{A: 1},
+			{A: 2},
+			{A: 3},
+		},
+		OneofField: &test3pb.TestAllTypes_OneofNestedMessage{
+			OneofNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 5},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &test3pb.TestAllTypes{}
+	opts := proto.UnmarshalOptions{RetainCapacity: true}
+	if err := opts.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		opts.Unmarshal(b, m)
+	}); n > 0 {
+		t.Errorf("steady-state Unmarshal allocated %v times, want 0", n)
+	}
+}
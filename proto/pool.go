@@ -0,0 +1,53 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A Pool is a set of messages that may be reused, grouped by message type.
+// Messages returned to the pool are reset with their memory retained, so
+// that a loop which unmarshals into messages obtained from the pool with
+// UnmarshalOptions.RetainCapacity set performs few allocations once it
+// reaches a steady state.
+//
+// A Pool is safe for concurrent use. The zero value is an empty pool.
+// Like a sync.Pool, a Pool may release the messages it holds at any time.
+type Pool struct {
+	pools sync.Map // map[protoreflect.MessageType]*sync.Pool
+}
+
+// Get returns an empty message of type mt, which may be one previously
+// given to Put.
+func (p *Pool) Get(mt protoreflect.MessageType) Message {
+	return p.pool(mt).Get().(Message)
+}
+
+// Put resets m and adds it to the pool.
+// The caller must not use m or any values obtained from it afterwards.
+func (p *Pool) Put(m Message) {
+	if m == nil {
+		return
+	}
+	mr := m.ProtoReflect()
+	if !mr.IsValid() {
+		return
+	}
+	resetMessageRetain(mr)
+	p.pool(mr.Type()).Put(m)
+}
+
+func (p *Pool) pool(mt protoreflect.MessageType) *sync.Pool {
+	if v, ok := p.pools.Load(mt); ok {
+		return v.(*sync.Pool)
+	}
+	v, _ := p.pools.LoadOrStore(mt, &sync.Pool{
+		New: func() interface{} { return mt.New().Interface() },
+	})
+	return v.(*sync.Pool)
+}
@@ -33,6 +33,42 @@ func init() {
 	Error = errors.Error
 }
 
+// DecodeError is the type of all errors returned by Unmarshal and by the
+// unmarshalers in the protojson and prototext packages.
+// It reports the path of the field being parsed, the position in the input,
+// and the category of the failure. Use errors.As to access it:
+//
+//   var derr *proto.DecodeError
+//   if errors.As(err, &derr) {
+//       log.Printf("%v at %v (offset %d)", derr.Kind, derr.Path, derr.Offset)
+//   }
+type DecodeError = errors.DecodeError
+
+// DecodeErrorKind classifies the cause of a DecodeError.
+type DecodeErrorKind = errors.DecodeErrorKind
+
+const (
+	// DecodeErrorOther is any failure not covered by another kind.
+	DecodeErrorOther = errors.DecodeErrorOther
+	// DecodeErrorWireFormat is malformed wire-format data.
+	DecodeErrorWireFormat = errors.DecodeErrorWireFormat
+	// DecodeErrorSyntax is malformed JSON or text syntax.
+	DecodeErrorSyntax = errors.DecodeErrorSyntax
+	// DecodeErrorInvalidValue is a well-formed value that is not valid for
+	// the field it is assigned to.
+	DecodeErrorInvalidValue = errors.DecodeErrorInvalidValue
+	// DecodeErrorUnknownField is a field name not known to the message.
+	DecodeErrorUnknownField = errors.DecodeErrorUnknownField
+	// DecodeErrorDuplicateField is a field set more than once where that
+	// is not permitted.
+	DecodeErrorDuplicateField = errors.DecodeErrorDuplicateField
+	// DecodeErrorUnresolvedType is an extension or Any type that could
+	// not be resolved.
+	DecodeErrorUnresolvedType = errors.DecodeErrorUnresolvedType
+	// DecodeErrorRequiredNotSet is a required field that is not populated.
+	DecodeErrorRequiredNotSet = errors.DecodeErrorRequiredNotSet
+)
+
 // MessageName returns the full name of m.
 // If m is nil, it returns an empty string.
 func MessageName(m Message) protoreflect.FullName {
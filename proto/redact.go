@@ -0,0 +1,109 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/redact"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RedactOptions configures the redaction of sensitive fields.
+type RedactOptions struct {
+	// RedactField reports whether a field is sensitive in addition to
+	// fields with the debug_redact option.
+	RedactField func(protoreflect.FieldDescriptor) bool
+
+	// Resolver is used for looking up the types of google.protobuf.Any
+	// messages and of the extensions within them.
+	// If nil, this defaults to using protoregistry.GlobalTypes.
+	Resolver interface {
+		protoregistry.ExtensionTypeResolver
+		protoregistry.MessageTypeResolver
+	}
+}
+
+// Redact clears every sensitive field in m and in all messages it contains,
+// where a field is sensitive if it has the debug_redact field option set.
+// It is equivalent to RedactOptions{}.Redact(m).
+func Redact(m Message) {
+	RedactOptions{}.Redact(m)
+}
+
+// Redact clears every sensitive field in m and in all messages it contains,
+// including map values, extensions, and the contents of google.protobuf.Any
+// messages. The contents of an Any message are left unmodified if its type
+// cannot be resolved or its value cannot be unmarshaled.
+// Unknown fields are retained since their sensitivity cannot be determined.
+func (o RedactOptions) Redact(m Message) {
+	if m == nil {
+		return
+	}
+	if o.Resolver == nil {
+		o.Resolver = protoregistry.GlobalTypes
+	}
+	o.redactMessage(m.ProtoReflect())
+}
+
+func (o RedactOptions) redactMessage(m protoreflect.Message) {
+	if !m.IsValid() {
+		return
+	}
+	if m.Descriptor().FullName() == genid.Any_message_fullname {
+		o.redactAny(m)
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case redact.IsRedacted(fd, o.RedactField):
+			m.Clear(fd)
+		case fd.IsList() && fd.Message() != nil:
+			for i, list := 0, v.List(); i < list.Len(); i++ {
+				o.redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				o.redactMessage(v.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			o.redactMessage(v.Message())
+		}
+		return true
+	})
+}
+
+// redactAny redacts the message contained in the Any message m,
+// replacing its value with the wire encoding of the redacted message.
+func (o RedactOptions) redactAny(m protoreflect.Message) {
+	fds := m.Descriptor().Fields()
+	fdType := fds.ByNumber(genid.Any_TypeUrl_field_number)
+	fdValue := fds.ByNumber(genid.Any_Value_field_number)
+	if fdType == nil || fdValue == nil {
+		return
+	}
+	typeURL := m.Get(fdType).String()
+	if typeURL == "" {
+		return
+	}
+	mt, err := o.Resolver.FindMessageByURL(typeURL)
+	if err != nil {
+		return
+	}
+	m2 := mt.New()
+	if err := (UnmarshalOptions{
+		AllowPartial: true,
+		Resolver:     o.Resolver,
+	}).Unmarshal(m.Get(fdValue).Bytes(), m2.Interface()); err != nil {
+		return
+	}
+	o.redactMessage(m2)
+	b, err := MarshalOptions{AllowPartial: true}.Marshal(m2.Interface())
+	if err != nil {
+		return
+	}
+	m.Set(fdValue, protoreflect.ValueOfBytes(b))
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// newRedactTypes returns the types of a file declaring the message test.M,
+// in which the field "secret" and the extension "secret_ext"
+// are marked with the debug_redact option.
+func newRedactTypes(t *testing.T) *dynamicpb.Types {
+	t.Helper()
+	fdp := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(`
+		name: "redact.proto"
+		package: "test"
+		dependency: "google/protobuf/any.proto"
+		message_type: [{
+			name: "M"
+			field: [
+				{name:"secret" number:1 label:LABEL_OPTIONAL type:TYPE_STRING options:{}},
+				{name:"id" number:2 label:LABEL_OPTIONAL type:TYPE_INT32},
+				{name:"child" number:3 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".test.M"},
+				{name:"list" number:4 label:LABEL_REPEATED type:TYPE_MESSAGE type_name:".test.M"},
+				{name:"map" number:5 label:LABEL_REPEATED type:TYPE_MESSAGE type_name:".test.M.MapEntry"},
+				{name:"any" number:6 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".google.protobuf.Any"}
+			]
+			nested_type: [{
+				name: "MapEntry"
+				field: [
+					{name:"key" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+					{name:"value" number:2 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".test.M"}
+				]
+				options: {map_entry:true}
+			}]
+			extension_range: [{start:100 end:200}]
+		}]
+		extension: [
+			{name:"secret_ext" number:100 label:LABEL_OPTIONAL type:TYPE_STRING extendee:".test.M" options:{}}
+		]
+	`), fdp); err != nil {
+		t.Fatal(err)
+	}
+
+	// The descriptor.proto in this module predates the debug_redact option,
+	// so it is set as an unknown field.
+	debugRedact := protowire.AppendVarint(protowire.AppendTag(nil, 16, protowire.VarintType), 1)
+	fdp.MessageType[0].Field[0].Options.ProtoReflect().SetUnknown(debugRedact)
+	fdp.Extension[0].Options.ProtoReflect().SetUnknown(debugRedact)
+
+	files := new(protoregistry.Files)
+	files.RegisterFile(anypb.File_google_protobuf_any_proto)
+	fd, err := protodesc.NewFile(fdp, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.RegisterFile(fd)
+	return dynamicpb.NewTypes(files)
+}
+
+func newRedactMessage(t *testing.T, types *dynamicpb.Types, s string) proto.Message {
+	t.Helper()
+	mt, err := types.FindMessageByName("test.M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mt.New().Interface()
+	if err := (prototext.UnmarshalOptions{Resolver: types}).Unmarshal([]byte(s), m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+const redactInput = `
+	secret: "s1"
+	id: 1
+	child: {secret: "s2" id: 2}
+	list: [{secret: "s3" id: 3}]
+	map: [{key: "k" value: {secret: "s4" id: 4}}]
+	any: {[type.googleapis.com/test.M]: {secret: "s5" id: 5}}
+	[test.secret_ext]: "s6"
+`
+
+func TestRedact(t *testing.T) {
+	types := newRedactTypes(t)
+	got := newRedactMessage(t, types, redactInput)
+	proto.RedactOptions{Resolver: types}.Redact(got)
+
+	want := newRedactMessage(t, types, `
+		id: 1
+		child: {id: 2}
+		list: [{id: 3}]
+		map: [{key: "k" value: {id: 4}}]
+		any: {[type.googleapis.com/test.M]: {id: 5}}
+	`)
+	if !proto.Equal(got, want) {
+		t.Errorf("Redact() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(got), prototext.Format(want))
+	}
+}
+
+func TestRedactField(t *testing.T) {
+	types := newRedactTypes(t)
+	got := newRedactMessage(t, types, `secret: "s1" id: 1 child: {id: 2}`)
+	proto.RedactOptions{
+		RedactField: func(fd protoreflect.FieldDescriptor) bool {
+			return fd.Name() == "id"
+		},
+	}.Redact(got)
+
+	want := newRedactMessage(t, types, `child: {}`)
+	if !proto.Equal(got, want) {
+		t.Errorf("Redact() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(got), prototext.Format(want))
+	}
+}
+
+func TestRedactFormat(t *testing.T) {
+	types := newRedactTypes(t)
+	m := newRedactMessage(t, types, redactInput)
+
+	for _, s := range []string{
+		prototext.MarshalOptions{Resolver: types}.Format(m),
+		protojson.MarshalOptions{Resolver: types}.Format(m),
+	} {
+		for _, secret := range []string{"s1", "s2", "s3", "s4", "s5", "s6"} {
+			if strings.Contains(s, `"`+secret+`"`) {
+				t.Errorf("Format() output contains sensitive value %q:\n%s", secret, s)
+			}
+		}
+		if n := strings.Count(s, "[REDACTED]"); n != 6 {
+			t.Errorf("Format() output contains %d redacted values, want 6:\n%s", n, s)
+		}
+	}
+
+	// Marshal does not redact unless requested.
+	b, err := prototext.MarshalOptions{Resolver: types}.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"s1"`) {
+		t.Errorf("Marshal() output unexpectedly redacted:\n%s", b)
+	}
+
+	got := prototext.MarshalOptions{
+		Redact: true,
+		RedactField: func(fd protoreflect.FieldDescriptor) bool {
+			return fd.Name() == "id"
+		},
+	}.Format(newRedactMessage(t, types, `secret: "s1" id: 1`))
+	if n := strings.Count(got, "[REDACTED]"); n != 2 {
+		t.Errorf("Format() = %q, want 2 redacted values", got)
+	}
+}
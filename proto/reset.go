@@ -7,7 +7,9 @@ package proto
 import (
 	"fmt"
 
+	"google.golang.org/protobuf/internal/pragma"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
 )
 
 // Reset clears every field in the message.
@@ -41,3 +43,77 @@ func resetMessage(m protoreflect.Message) {
 	// Clear unknown fields.
 	m.SetUnknown(nil)
 }
+
+// ResetOptions configures the resetter.
+//
+// Example usage:
+//   ResetOptions{RetainCapacity: true}.Reset(m)
+type ResetOptions struct {
+	pragma.NoUnkeyedLiterals
+
+	// RetainCapacity retains the memory allocated for the fields of the
+	// message so that it can be reused, such as when unmarshaling into the
+	// message again with UnmarshalOptions.RetainCapacity set.
+	// Repeated fields are truncated to zero length, map fields and unknown
+	// fields are emptied in place, and the elements of repeated message
+	// fields are kept in the spare capacity of their lists.
+	//
+	// The resulting message may share memory with its previous state,
+	// so the caller must not use values obtained from it before the reset.
+	RetainCapacity bool
+}
+
+// Reset clears every field in the message.
+func (o ResetOptions) Reset(m Message) {
+	if !o.RetainCapacity {
+		Reset(m)
+		return
+	}
+	resetMessageRetain(m.ProtoReflect())
+}
+
+// resetMessageRetain clears every field in m, retaining allocated memory.
+func resetMessageRetain(m protoreflect.Message) {
+	if methods := protoMethods(m); methods != nil && methods.Reset != nil {
+		out := methods.Reset(protoiface.ResetInput{
+			Message: m,
+			Flags:   protoiface.ResetRetainCapacity,
+		})
+		if out.Flags&protoiface.ResetComplete != 0 {
+			return
+		}
+	}
+	if !m.IsValid() {
+		panic(fmt.Sprintf("cannot reset invalid %v message", m.Descriptor().FullName()))
+	}
+
+	// Truncate lists and empty maps, and clear all other known fields.
+	fds := m.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		switch {
+		case !m.Has(fd):
+		case fd.IsList():
+			m.Mutable(fd).List().Truncate(0)
+		case fd.IsMap():
+			mapv := m.Mutable(fd).Map()
+			mapv.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				mapv.Clear(k)
+				return true
+			})
+		default:
+			m.Clear(fd)
+		}
+	}
+
+	// Clear extension fields.
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		m.Clear(fd)
+		return true
+	})
+
+	// Empty unknown fields.
+	if u := m.GetUnknown(); len(u) > 0 {
+		m.SetUnknown(u[:0])
+	}
+}
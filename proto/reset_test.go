@@ -5,9 +5,12 @@
 package proto_test
 
 import (
+	"fmt"
 	"testing"
 
+	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
 )
@@ -56,3 +59,131 @@ func TestReset(t *testing.T) {
 		t.Errorf("m.ProtoReflect().GetUnknown() = %d, want nil", got)
 	}
 }
+
+func TestResetRetainCapacity(t *testing.T) {
+	full := &testpb.TestAllTypes{
+		OptionalInt32:  proto.Int32(1),
+		OptionalString: proto.String("string"),
+		OptionalBytes:  []byte("bytes"),
+		RepeatedInt32:  []int32{1, 2, 3},
+		RepeatedString: []string{"a", "b"},
+		RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+			{A: proto.Int32(1), Corecursive: &testpb.TestAllTypes{RepeatedInt32: []int32{4, 5}}},
+			{A: proto.Int32(2)},
+		},
+		Repeatedgroup:         []*testpb.TestAllTypes_RepeatedGroup{{A: proto.Int32(3)}},
+		MapStringString:       map[string]string{"k": "v"},
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(4)},
+		OneofField:            &testpb.TestAllTypes_OneofUint32{OneofUint32: 5},
+	}
+	full.ProtoReflect().SetUnknown([]byte{0xf8, 0x3f, 0x01}) // field 1023, varint 1
+	b, err := proto.Marshal(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smaller := &testpb.TestAllTypes{
+		RepeatedInt32: []int32{7},
+		RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+			{Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(8)}},
+		},
+	}
+	b2, err := proto.Marshal(smaller)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, newMessage := range []func() proto.Message{
+		func() proto.Message { return &testpb.TestAllTypes{} },
+		func() proto.Message { return dynamicpb.NewMessage(full.ProtoReflect().Descriptor()) },
+	} {
+		t.Run(fmt.Sprintf("%T", newMessage()), func(t *testing.T) {
+			opts := proto.UnmarshalOptions{RetainCapacity: true}
+			m := newMessage()
+			if err := opts.Unmarshal(b, m); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			proto.ResetOptions{RetainCapacity: true}.Reset(m)
+			if !proto.Equal(m, &testpb.TestAllTypes{}) {
+				t.Errorf("after Reset with RetainCapacity:\ngot:  %v\nwant: empty", prototext.Format(m))
+			}
+			if n := proto.Size(m); n != 0 {
+				t.Errorf("after Reset with RetainCapacity: Size = %v, want 0", n)
+			}
+
+			// Unmarshaling again produces the same message as a fresh unmarshal,
+			// whether or not the message was reset first.
+			for _, in := range []struct {
+				b    []byte
+				want proto.Message
+			}{{b, full}, {b2, smaller}, {b, full}, {b2, smaller}} {
+				if err := opts.Unmarshal(in.b, m); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				if !proto.Equal(m, in.want) {
+					t.Errorf("Unmarshal with RetainCapacity:\ngot:  %v\nwant: %v", prototext.Format(m), prototext.Format(in.want))
+				}
+			}
+		})
+	}
+
+	// Memory is retained for generated messages.
+	m := &testpb.TestAllTypes{}
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	ints, mapv := m.RepeatedInt32, m.MapStringString
+	proto.ResetOptions{RetainCapacity: true}.Reset(m)
+	if len(m.RepeatedInt32) != 0 || cap(m.RepeatedInt32) != cap(ints) {
+		t.Errorf("m.RepeatedInt32 has len %v cap %v, want len 0 cap %v", len(m.RepeatedInt32), cap(m.RepeatedInt32), cap(ints))
+	}
+	if m.MapStringString == nil || len(m.MapStringString) != 0 {
+		t.Errorf("m.MapStringString = %v, want empty non-nil map", m.MapStringString)
+	}
+	mapv["x"] = "y"
+	if len(m.MapStringString) != 1 {
+		t.Errorf("m.MapStringString was replaced by Reset with RetainCapacity")
+	}
+	if err := (proto.UnmarshalOptions{RetainCapacity: true}).Unmarshal(b2, m); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(m, smaller) {
+		t.Errorf("Unmarshal with RetainCapacity:\ngot:  %v\nwant: %v", prototext.Format(m), prototext.Format(smaller))
+	}
+}
+
+func TestResetRetainCapacityExtensions(t *testing.T) {
+	m := &testpb.TestAllExtensions{}
+	proto.SetExtension(m, testpb.E_OptionalInt32, int32(1))
+	proto.SetExtension(m, testpb.E_RepeatedInt32, []int32{2, 3})
+	proto.ResetOptions{RetainCapacity: true}.Reset(m)
+	if !proto.Equal(m, &testpb.TestAllExtensions{}) {
+		t.Errorf("after Reset with RetainCapacity:\ngot:  %v\nwant: empty", prototext.Format(m))
+	}
+}
+
+func TestPool(t *testing.T) {
+	var pool proto.Pool
+	mt := (&testpb.TestAllTypes{}).ProtoReflect().Type()
+	m := pool.Get(mt).(*testpb.TestAllTypes)
+	m.OptionalInt32 = proto.Int32(1)
+	m.RepeatedInt32 = []int32{1}
+	pool.Put(m)
+	pool.Put(nil)
+	pool.Put((*testpb.TestAllTypes)(nil))
+
+	for i := 0; i < 2; i++ {
+		got := pool.Get(mt)
+		if got.ProtoReflect().Type() != mt {
+			t.Errorf("Get returned message of type %v, want %v", got.ProtoReflect().Descriptor().FullName(), mt.Descriptor().FullName())
+		}
+		if !proto.Equal(got, &testpb.TestAllTypes{}) {
+			t.Errorf("Get returned non-empty message: %v", prototext.Format(got))
+		}
+	}
+
+	dmt := dynamicpb.NewMessageType(mt.Descriptor())
+	dm := pool.Get(dmt)
+	if dm.ProtoReflect().Type() != dmt {
+		t.Errorf("Get returned message of type %T, want dynamic message", dm)
+	}
+}
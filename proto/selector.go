@@ -0,0 +1,83 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A FieldSelector selects a subset of the fields of a message type.
+// It is used with UnmarshalOptions.Fields to parse only part of a message.
+//
+// A FieldSelector is safe for concurrent use.
+type FieldSelector struct {
+	// fields maps the number of each selected field to the selector for the
+	// fields of its message value, or to nil if the entire value is selected.
+	fields map[protoreflect.FieldNumber]*FieldSelector
+}
+
+// NewFieldSelector returns a selector for the fields of md named by paths.
+//
+// Each path is a sequence of field names separated by dots, as in the paths
+// of a google.protobuf.FieldMask. Every field in a path except the last must
+// be a message or group field, which may be repeated but not a map.
+// A path that passes through a repeated field selects the named subfields
+// in every element. Selecting a field selects all of its subfields.
+// Extension fields cannot be selected.
+func NewFieldSelector(md protoreflect.MessageDescriptor, paths ...string) (*FieldSelector, error) {
+	s := &FieldSelector{fields: make(map[protoreflect.FieldNumber]*FieldSelector)}
+	for _, path := range paths {
+		if err := s.add(md, path); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *FieldSelector) add(md protoreflect.MessageDescriptor, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return errors.New("invalid field path %q: %v has no field named %q", path, md.FullName(), name)
+		}
+		num := fd.Number()
+		if i == len(names)-1 {
+			// Selecting the entire field overrides any subfield selections.
+			s.fields[num] = nil
+			return nil
+		}
+		if fd.Message() == nil || fd.IsMap() {
+			return errors.New("invalid field path %q: field %v is not a message", path, fd.FullName())
+		}
+		sub, ok := s.fields[num]
+		if ok && sub == nil {
+			// The entire field is already selected.
+			return nil
+		}
+		if !ok {
+			sub = &FieldSelector{fields: make(map[protoreflect.FieldNumber]*FieldSelector)}
+			s.fields[num] = sub
+		}
+		s, md = sub, fd.Message()
+	}
+	return nil
+}
+
+// SelectField reports whether the field numbered n is selected.
+// For a selected field, sub is the *FieldSelector for the fields of its
+// message value, or nil if the entire value is selected.
+//
+// It implements the FieldSelector of protoiface.UnmarshalInput.
+func (s *FieldSelector) SelectField(n protoreflect.FieldNumber) (sub interface{}, ok bool) {
+	fs, ok := s.fields[n]
+	if fs == nil {
+		return nil, ok
+	}
+	return fs, ok
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestUnmarshalFieldSelector(t *testing.T) {
+	full := &testpb.TestAllTypes{
+		OptionalInt32:  proto.Int32(1),
+		OptionalString: proto.String("string"),
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			A: proto.Int32(2),
+			Corecursive: &testpb.TestAllTypes{
+				OptionalInt32:  proto.Int32(3),
+				OptionalString: proto.String("nested"),
+			},
+		},
+		RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+			{A: proto.Int32(4), Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(5)}},
+			{A: proto.Int32(6)},
+		},
+		Optionalgroup:   &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(7)},
+		MapStringString: map[string]string{"key": "value"},
+		OneofField:      &testpb.TestAllTypes_OneofUint32{OneofUint32: 8},
+	}
+	b, err := proto.Marshal(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		paths []string
+		want  *testpb.TestAllTypes
+	}{{
+		paths: nil,
+		want:  &testpb.TestAllTypes{},
+	}, {
+		paths: []string{"optional_int32"},
+		want:  &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)},
+	}, {
+		paths: []string{"optional_nested_message.corecursive.optional_string"},
+		want: &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				Corecursive: &testpb.TestAllTypes{OptionalString: proto.String("nested")},
+			},
+		},
+	}, {
+		paths: []string{"optional_nested_message.a", "optional_nested_message"},
+		want:  &testpb.TestAllTypes{OptionalNestedMessage: full.OptionalNestedMessage},
+	}, {
+		paths: []string{"repeated_nested_message.a", "optionalgroup.a"},
+		want: &testpb.TestAllTypes{
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(4)},
+				{A: proto.Int32(6)},
+			},
+			Optionalgroup: &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(7)},
+		},
+	}, {
+		paths: []string{"map_string_string", "oneof_uint32"},
+		want: &testpb.TestAllTypes{
+			MapStringString: map[string]string{"key": "value"},
+			OneofField:      &testpb.TestAllTypes_OneofUint32{OneofUint32: 8},
+		},
+	}}
+	for _, test := range tests {
+		fs, err := proto.NewFieldSelector(full.ProtoReflect().Descriptor(), test.paths...)
+		if err != nil {
+			t.Fatalf("NewFieldSelector(%q) error: %v", test.paths, err)
+		}
+		for _, newMessage := range []func() proto.Message{
+			func() proto.Message { return &testpb.TestAllTypes{} },
+			func() proto.Message { return dynamicpb.NewMessage(full.ProtoReflect().Descriptor()) },
+		} {
+			t.Run(fmt.Sprintf("%T/%v", newMessage(), strings.Join(test.paths, ",")), func(t *testing.T) {
+				got := newMessage()
+				opts := proto.UnmarshalOptions{Fields: fs, DiscardUnknown: true}
+				if err := opts.Unmarshal(b, got); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				if !proto.Equal(got, test.want) {
+					t.Errorf("Unmarshal with DiscardUnknown:\ngot:  %v\nwant: %v", prototext.Format(got), prototext.Format(test.want))
+				}
+
+				// Fields that are not selected are retained as unknown fields.
+				got = newMessage()
+				opts = proto.UnmarshalOptions{Fields: fs}
+				if err := opts.Unmarshal(b, got); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				b2, err := proto.Marshal(got)
+				if err != nil {
+					t.Fatalf("Marshal error: %v", err)
+				}
+				got2 := &testpb.TestAllTypes{}
+				if err := proto.Unmarshal(b2, got2); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				if !proto.Equal(got2, full) {
+					t.Errorf("round trip through unknown fields:\ngot:  %v\nwant: %v", prototext.Format(got2), prototext.Format(full))
+				}
+			})
+		}
+	}
+}
+
+func TestUnmarshalFieldSelectorRequired(t *testing.T) {
+	b, err := proto.Marshal(&testpb.TestRequired{RequiredField: proto.Int32(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := proto.NewFieldSelector((&testpb.TestRequired{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (proto.UnmarshalOptions{Fields: fs}).Unmarshal(b, &testpb.TestRequired{}); err == nil {
+		t.Errorf("Unmarshal without required field selected succeeded, want error")
+	}
+	if err := (proto.UnmarshalOptions{Fields: fs, AllowPartial: true}).Unmarshal(b, &testpb.TestRequired{}); err != nil {
+		t.Errorf("Unmarshal with AllowPartial error: %v", err)
+	}
+}
+
+func TestNewFieldSelectorInvalid(t *testing.T) {
+	md := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor()
+	for _, path := range []string{
+		"",
+		"no_such_field",
+		"optional_int32.a",
+		"map_string_nested_message.a",
+		"optional_nested_message.no_such_field",
+	} {
+		if _, err := proto.NewFieldSelector(md, path); err == nil {
+			t.Errorf("NewFieldSelector(%q) succeeded, want error", path)
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package proto
 
 import (
+	"reflect"
+
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/internal/encoding/messageset"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -45,9 +47,30 @@ func (o MarshalOptions) size(m protoreflect.Message) (size int) {
 		})
 		return len(out.Buf)
 	}
+	if o.tracksSize(m) {
+		if size, ok := o.sizes.Load(m); ok {
+			return size
+		}
+		size = o.sizeMessageSlow(m)
+		o.sizes.Store(m, size)
+		return size
+	}
 	return o.sizeMessageSlow(m)
 }
 
+// tracksSize reports whether o records the size of m.
+// Only messages sized by sizeMessageSlow are recorded, and only if
+// they may be used as map keys.
+func (o MarshalOptions) tracksSize(m protoreflect.Message) bool {
+	if o.sizes == nil {
+		return false
+	}
+	if methods := protoMethods(m); methods != nil && (methods.Size != nil || methods.Marshal != nil) {
+		return false
+	}
+	return reflect.TypeOf(m).Comparable()
+}
+
 func (o MarshalOptions) sizeMessageSlow(m protoreflect.Message) (size int) {
 	if messageset.IsMessageSet(m.Descriptor()) {
 		return o.sizeMessageSet(m)
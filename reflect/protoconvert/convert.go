@@ -0,0 +1,674 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoconvert converts messages between different versions of
+// a message descriptor.
+//
+// A typical use is a schema rollout, where a message built against an old
+// descriptor (often a dynamicpb.Message) must be converted to a message of the
+// new descriptor. Fields are matched by number or by name, and values are
+// converted between field kinds where the value can be represented exactly.
+// Data that cannot be converted is preserved as unknown fields of the
+// destination message and reported as a Loss.
+package protoconvert
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Loss describes data in the source message that could not be converted
+// exactly to the destination message.
+type Loss struct {
+	// Path is the path to the value in the source message,
+	// such as "foo.bar[2].baz" or `foo.map_field["key"]`.
+	Path string
+
+	// Reason describes why the value could not be converted.
+	Reason string
+
+	// Preserved reports whether the original value is still present in the
+	// destination message, either in the converted field or as unknown fields.
+	Preserved bool
+}
+
+func (l Loss) String() string {
+	s := l.Path + ": " + l.Reason
+	if l.Preserved {
+		s += " (preserved)"
+	}
+	return s
+}
+
+// Options configures the conversion.
+type Options struct {
+	// ByName specifies that fields are matched by name rather than by number.
+	// When matching by name, the unknown fields of the source message are
+	// copied as is, since their field numbers may have a different meaning
+	// in the destination message. For the same reason, no value is preserved
+	// under a number that the destination message declares with another name.
+	ByName bool
+}
+
+// Convert converts src into dst, matching fields by number.
+// It is equivalent to Options{}.Convert(dst, src).
+func Convert(dst, src protoreflect.Message) []Loss {
+	return Options{}.Convert(dst, src)
+}
+
+// Convert converts src into dst, which are messages of possibly different
+// descriptors, and returns the list of values that could not be converted
+// exactly. Populated fields in src are merged into dst as with proto.Merge.
+//
+// A value is converted if the destination field has a compatible kind and
+// cardinality and the value can be represented exactly, such as an int32
+// converted to an int64, an integral double converted to an int32, a string
+// converted to bytes, or a message converted to a message of a different
+// descriptor. A message may also be converted to and from bytes using the
+// wire format. A singular value is converted to a list with one element,
+// while a list is converted to a singular field by keeping its last element.
+//
+// Values that cannot be converted, such as those of fields not present in
+// dst, fields of incompatible kinds, or enum values not declared by a closed
+// destination enum, are preserved as unknown fields of dst using the field
+// number of the source field. Extension fields are only converted if dst has
+// the same descriptor as the message they extend, and are otherwise also
+// preserved as unknown fields. A value is not preserved if dst declares
+// a field with the same number that cannot parse it, such as one with
+// a different wire type, since that would make dst invalid.
+//
+// The unknown fields of src are parsed as fields of dst when matching by number,
+// and are otherwise appended to the unknown fields of dst as is, except for
+// those whose numbers dst declares.
+// Unknown fields that dst cannot parse are discarded.
+func (o Options) Convert(dst, src protoreflect.Message) []Loss {
+	c := converter{opts: o}
+	c.convertMessage(dst, src, "")
+	return c.losses
+}
+
+type converter struct {
+	opts   Options
+	losses []Loss
+}
+
+func (c *converter) lose(path, reason string, preserved bool) {
+	c.losses = append(c.losses, Loss{Path: path, Reason: reason, Preserved: preserved})
+}
+
+// loseValue reports a value that could not be converted,
+// where err is the result of preserving it as unknown fields of dst.
+func (c *converter) loseValue(path, reason string, err error) {
+	if err != nil {
+		reason += "; not preserved: " + err.Error()
+	}
+	c.lose(path, reason, err == nil)
+}
+
+func (c *converter) convertMessage(dst, src protoreflect.Message, path string) {
+	// Convert fields in order of field number so that the losses
+	// and the unknown fields of dst are deterministic.
+	var fds []protoreflect.FieldDescriptor
+	src.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fds = append(fds, fd)
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Number() < fds[j].Number() })
+	for _, fd := range fds {
+		c.convertField(dst, src, fd, src.Get(fd), joinPath(path, fieldName(fd)))
+	}
+
+	raw := src.GetUnknown()
+	if len(raw) == 0 {
+		return
+	}
+	// Parse each field separately so that a field that dst cannot parse
+	// does not prevent the others from being converted.
+	for len(raw) > 0 {
+		num, _, n := protowire.ConsumeField(raw)
+		if n < 0 {
+			c.lose(path, "unknown fields are malformed", false)
+			return
+		}
+		if c.opts.ByName {
+			// The field numbers of src and dst are unrelated, so the field
+			// is kept as unknown unless dst declares its number.
+			if err := c.checkNumber(dst, num, ""); err != nil {
+				c.lose(path, fmt.Sprintf("unknown field %d not preserved: %v", num, err), false)
+			} else {
+				dst.SetUnknown(append(dst.GetUnknown(), raw[:n]...))
+			}
+			raw = raw[n:]
+			continue
+		}
+		m := dst.New()
+		if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(raw[:n], m.Interface()); err != nil {
+			c.lose(path, fmt.Sprintf("unknown field %d cannot be parsed: %v", num, err), false)
+		} else {
+			proto.Merge(dst.Interface(), m.Interface())
+		}
+		raw = raw[n:]
+	}
+}
+
+// findField returns the field in md that corresponds to fd, or nil if none.
+func (c *converter) findField(md protoreflect.MessageDescriptor, fd protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	if fd.IsExtension() {
+		if fd.ContainingMessage() == md {
+			return fd
+		}
+		return nil
+	}
+	if c.opts.ByName {
+		return md.Fields().ByName(fd.Name())
+	}
+	return md.Fields().ByNumber(fd.Number())
+}
+
+func (c *converter) convertField(dst, src protoreflect.Message, fd1 protoreflect.FieldDescriptor, v protoreflect.Value, path string) {
+	fd2 := c.findField(dst.Descriptor(), fd1)
+	switch {
+	case fd2 == nil && fd1.IsExtension():
+		err := c.preserveField(dst, src, fd1, v)
+		c.loseValue(path, fmt.Sprintf("extension does not extend %v", dst.Descriptor().FullName()), err)
+	case fd2 == nil:
+		err := c.preserveField(dst, src, fd1, v)
+		c.loseValue(path, fmt.Sprintf("field not present in %v", dst.Descriptor().FullName()), err)
+	case fd1.IsMap() && fd2.IsMap():
+		c.convertMap(dst, src, fd1, fd2, v.Map(), path)
+	case fd1.IsMap() || fd2.IsMap():
+		err := c.preserveField(dst, src, fd1, v)
+		c.loseValue(path, fmt.Sprintf("cannot convert %v to %v", cardinalityName(fd1), cardinalityName(fd2)), err)
+	case fd1.IsList() && fd2.IsList():
+		list1, list2 := v.List(), dst.Mutable(fd2).List()
+		for i := 0; i < list1.Len(); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			if v2, reason := c.convertValue(fd1, fd2, list1.Get(i), list2.NewElement, p); v2.IsValid() {
+				list2.Append(v2)
+			} else {
+				c.loseValue(p, reason, c.preserveElement(dst, src, fd1, list1.Get(i)))
+			}
+		}
+	case fd1.IsList():
+		// As with the wire format, the last element of the list is used.
+		list1 := v.List()
+		var err error
+		for i := 0; i < list1.Len()-1; i++ {
+			if err1 := c.preserveElement(dst, src, fd1, list1.Get(i)); err == nil {
+				err = err1
+			}
+		}
+		if n := list1.Len(); n > 1 {
+			c.loseValue(path, fmt.Sprintf("only the last of %d elements converted to singular field", n), err)
+		}
+		p := fmt.Sprintf("%s[%d]", path, list1.Len()-1)
+		c.convertSingular(dst, src, fd1, fd2, list1.Get(list1.Len()-1), p, true)
+	default:
+		c.convertSingular(dst, src, fd1, fd2, v, path, false)
+	}
+}
+
+// convertSingular converts v to the value of fd2 in dst, where fd2 may be
+// a list. If elem is set, v is an element of the list field fd1.
+func (c *converter) convertSingular(dst, src protoreflect.Message, fd1, fd2 protoreflect.FieldDescriptor, v protoreflect.Value, path string, elem bool) {
+	var newValue func() protoreflect.Value
+	switch {
+	case fd2.IsList():
+		newValue = dst.Mutable(fd2).List().NewElement
+	default:
+		newValue = func() protoreflect.Value { return dst.NewField(fd2) }
+	}
+	v2, reason := c.convertValue(fd1, fd2, v, newValue, path)
+	if !v2.IsValid() {
+		if elem {
+			c.loseValue(path, reason, c.preserveElement(dst, src, fd1, v))
+		} else {
+			c.loseValue(path, reason, c.preserveField(dst, src, fd1, v))
+		}
+		return
+	}
+	if fd2.IsList() {
+		dst.Mutable(fd2).List().Append(v2)
+		return
+	}
+	if od := fd2.ContainingOneof(); od != nil {
+		if fd := dst.WhichOneof(od); fd != nil && fd != fd2 {
+			c.lose(path, fmt.Sprintf("overwrites %v in oneof %v", fd.Name(), od.Name()), false)
+		}
+	}
+	if fd2.Message() != nil && dst.Has(fd2) {
+		proto.Merge(dst.Mutable(fd2).Message().Interface(), v2.Message().Interface())
+		return
+	}
+	dst.Set(fd2, v2)
+}
+
+func (c *converter) convertMap(dst, src protoreflect.Message, fd1, fd2 protoreflect.FieldDescriptor, map1 protoreflect.Map, path string) {
+	map2 := dst.Mutable(fd2).Map()
+	map1.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		p := fmt.Sprintf("%s[%s]", path, formatKey(k))
+		k2, reason := c.convertValue(fd1.MapKey(), fd2.MapKey(), k.Value(), nil, p)
+		if !k2.IsValid() {
+			c.loseValue(p, reason, c.preserveEntry(dst, src, fd1, k, v))
+			return true
+		}
+		v2, reason := c.convertValue(fd1.MapValue(), fd2.MapValue(), v, map2.NewValue, p)
+		if !v2.IsValid() {
+			c.loseValue(p, reason, c.preserveEntry(dst, src, fd1, k, v))
+			return true
+		}
+		map2.Set(k2.MapKey(), v2)
+		return true
+	})
+}
+
+// convertValue converts a singular value of fd1 to a singular value of fd2,
+// where newValue returns a new mutable message value for fd2.
+// If the value cannot be converted, it returns an invalid value and the reason,
+// in which case the caller must preserve the value and report the loss.
+func (c *converter) convertValue(fd1, fd2 protoreflect.FieldDescriptor, v protoreflect.Value, newValue func() protoreflect.Value, path string) (protoreflect.Value, string) {
+	k1, k2 := fd1.Kind(), fd2.Kind()
+	incompatible := func() (protoreflect.Value, string) {
+		return protoreflect.Value{}, fmt.Sprintf("cannot convert %v to %v", k1, k2)
+	}
+	inexact := func() (protoreflect.Value, string) {
+		return protoreflect.Value{}, fmt.Sprintf("value %v cannot be represented as %v", formatValue(fd1, v), k2)
+	}
+
+	switch {
+	case isMessage(k1) && isMessage(k2):
+		v2 := newValue()
+		c.convertMessage(v2.Message(), v.Message(), path)
+		return v2, ""
+	case isMessage(k1) && isBytes(k2):
+		b, err := proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(v.Message().Interface())
+		if err != nil {
+			return protoreflect.Value{}, fmt.Sprintf("cannot marshal message: %v", err)
+		}
+		return c.convertValue(bytesField{fd1}, fd2, protoreflect.ValueOfBytes(b), newValue, path)
+	case isBytes(k1) && isMessage(k2):
+		v2 := newValue()
+		if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(valueBytes(v), v2.Message().Interface()); err != nil {
+			return protoreflect.Value{}, fmt.Sprintf("cannot unmarshal %v as %v: %v", k1, fd2.Message().FullName(), err)
+		}
+		return v2, ""
+	case isBytes(k1) && isBytes(k2):
+		b := valueBytes(v)
+		if k2 == protoreflect.BytesKind {
+			return protoreflect.ValueOfBytes(append([]byte(nil), b...)), ""
+		}
+		if strs.EnforceUTF8(fd2) && !utf8.Valid(b) {
+			return protoreflect.Value{}, "invalid UTF-8 for string"
+		}
+		return protoreflect.ValueOfString(string(b)), ""
+	case isNumber(k1) && isNumber(k2):
+		if k1 == k2 && k1 != protoreflect.EnumKind {
+			return v, ""
+		}
+		n, ok := numberOf(k1, v)
+		if !ok {
+			return inexact()
+		}
+		v2, ok := n.value(k2)
+		if !ok {
+			return inexact()
+		}
+		if k2 == protoreflect.EnumKind {
+			ed := fd2.Enum()
+			if ed.Values().ByNumber(v2.Enum()) == nil {
+				reason := fmt.Sprintf("enum value %d not present in %v", v2.Enum(), ed.FullName())
+				if ed.ParentFile().Syntax() == protoreflect.Proto2 {
+					return protoreflect.Value{}, reason
+				}
+				c.lose(path, reason, true)
+			}
+		}
+		return v2, ""
+	default:
+		return incompatible()
+	}
+}
+
+// number is an integer or floating-point value.
+type number struct {
+	isFloat bool
+	f       float64
+
+	// For integers, i is the value if signed, or u is the value if unsigned.
+	signed bool
+	i      int64
+	u      uint64
+}
+
+func numberOf(k protoreflect.Kind, v protoreflect.Value) (number, bool) {
+	switch k {
+	case protoreflect.BoolKind:
+		if v.Bool() {
+			return number{u: 1}, true
+		}
+		return number{}, true
+	case protoreflect.EnumKind:
+		return number{signed: true, i: int64(v.Enum())}, true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return number{signed: true, i: v.Int()}, true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return number{u: v.Uint()}, true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return number{isFloat: true, f: v.Float()}, true
+	}
+	return number{}, false
+}
+
+// integer returns n as an integer, reporting false if it is not integral.
+func (n number) integer() (number, bool) {
+	if !n.isFloat {
+		return n, true
+	}
+	f := n.f
+	switch {
+	case f != math.Trunc(f) || math.IsInf(f, 0) || math.IsNaN(f):
+		return number{}, false
+	case f < 0 && f >= math.MinInt64:
+		return number{signed: true, i: int64(f)}, true
+	case f >= 0 && f < (1<<64):
+		return number{u: uint64(f)}, true
+	}
+	return number{}, false
+}
+
+// fitsInt reports whether the integer n fits in a signed integer of the
+// given bit size.
+func (n number) fitsInt(bits uint) bool {
+	min, max := int64(-1)<<(bits-1), uint64(1)<<(bits-1)-1
+	if n.signed {
+		return n.i >= min && (n.i < 0 || uint64(n.i) <= max)
+	}
+	return n.u <= max
+}
+
+// fitsUint reports whether the integer n fits in an unsigned integer of the
+// given bit size.
+func (n number) fitsUint(bits uint) bool {
+	if n.signed {
+		return n.i >= 0 && (bits == 64 || uint64(n.i) < 1<<bits)
+	}
+	return bits == 64 || n.u < 1<<bits
+}
+
+// int64 returns the integer n as an int64, which must fit.
+func (n number) int64() int64 {
+	if n.signed {
+		return n.i
+	}
+	return int64(n.u)
+}
+
+// uint64 returns the integer n as a uint64, which must fit.
+func (n number) uint64() uint64 {
+	if n.signed {
+		return uint64(n.i)
+	}
+	return n.u
+}
+
+// value returns n as a value of kind k,
+// reporting false if it cannot be represented exactly.
+func (n number) value(k protoreflect.Kind) (protoreflect.Value, bool) {
+	if k == protoreflect.FloatKind || k == protoreflect.DoubleKind {
+		f := n.f
+		if !n.isFloat {
+			// The conversion to float64 rounds to the nearest value, which may
+			// be out of range of the original type if it was inexact.
+			if n.signed {
+				f = float64(n.i)
+				if f >= (1<<63) || int64(f) != n.i {
+					return protoreflect.Value{}, false
+				}
+			} else {
+				f = float64(n.u)
+				if f >= (1<<64) || uint64(f) != n.u {
+					return protoreflect.Value{}, false
+				}
+			}
+		}
+		if k == protoreflect.FloatKind {
+			if f32 := float32(f); float64(f32) != f && !math.IsNaN(f) {
+				return protoreflect.Value{}, false
+			}
+			return protoreflect.ValueOfFloat32(float32(f)), true
+		}
+		return protoreflect.ValueOfFloat64(f), true
+	}
+
+	n, ok := n.integer()
+	if !ok {
+		return protoreflect.Value{}, false
+	}
+	switch k {
+	case protoreflect.BoolKind:
+		if !n.fitsUint(1) {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfBool(n.uint64() == 1), true
+	case protoreflect.EnumKind:
+		if !n.fitsInt(32) {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n.int64())), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if !n.fitsInt(32) {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfInt32(int32(n.int64())), true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if !n.fitsInt(64) {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfInt64(n.int64()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if !n.fitsUint(32) {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfUint32(uint32(n.uint64())), true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if !n.fitsUint(64) {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfUint64(n.uint64()), true
+	}
+	return protoreflect.Value{}, false
+}
+
+// preserveField appends the field fd of src with value v
+// to the unknown fields of dst.
+func (c *converter) preserveField(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	var err error
+	switch {
+	case fd.IsList():
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			if err1 := c.preserveElement(dst, src, fd, list.Get(i)); err == nil {
+				err = err1
+			}
+		}
+	case fd.IsMap():
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			if err1 := c.preserveEntry(dst, src, fd, k, v); err == nil {
+				err = err1
+			}
+			return true
+		})
+	default:
+		m := src.New()
+		m.Set(fd, v)
+		err = c.appendUnknown(dst, fd, m)
+	}
+	return err
+}
+
+// preserveElement appends an element v of the list field fd of src
+// to the unknown fields of dst.
+func (c *converter) preserveElement(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	m := src.New()
+	m.Mutable(fd).List().Append(v)
+	return c.appendUnknown(dst, fd, m)
+}
+
+// preserveEntry appends an entry of the map field fd of src
+// to the unknown fields of dst.
+func (c *converter) preserveEntry(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor, k protoreflect.MapKey, v protoreflect.Value) error {
+	m := src.New()
+	m.Mutable(fd).Map().Set(k, v)
+	return c.appendUnknown(dst, fd, m)
+}
+
+// appendUnknown appends the marshaled field fd of m to the unknown fields
+// of dst. If dst declares a field with the same number, the data must be
+// parsable as that field, since it would otherwise make dst invalid.
+func (c *converter) appendUnknown(dst protoreflect.Message, fd protoreflect.FieldDescriptor, m protoreflect.Message) error {
+	b, err := proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(m.Interface())
+	if err != nil {
+		return err
+	}
+	if err := c.checkNumber(dst, fd.Number(), fd.Name()); err != nil {
+		return err
+	}
+	if fd2 := dst.Descriptor().Fields().ByNumber(fd.Number()); fd2 != nil {
+		if !wireCompatible(fd, fd2, b) {
+			return errors.New("field %d of %v has a different wire type", fd2.Number(), dst.Descriptor().FullName())
+		}
+		if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(b, dst.New().Interface()); err != nil {
+			return err
+		}
+	}
+	dst.SetUnknown(append(dst.GetUnknown(), b...))
+	return nil
+}
+
+// checkNumber returns an error if fields are matched by name and dst
+// declares a field numbered num with a name other than name, in which case
+// data stored under that number would be read back as the wrong field.
+func (c *converter) checkNumber(dst protoreflect.Message, num protoreflect.FieldNumber, name protoreflect.Name) error {
+	if !c.opts.ByName {
+		return nil
+	}
+	fd2 := dst.Descriptor().Fields().ByNumber(num)
+	if fd2 == nil || fd2.Name() == name {
+		return nil
+	}
+	return errors.New("field %d of %v is %v", num, dst.Descriptor().FullName(), fd2.Name())
+}
+
+// wireCompatible reports whether the field fd2 parses b, the encoding of
+// values of the field fd1 with the same number, as values of the same
+// wire type. A repeated field parses both packed and unpacked values.
+func wireCompatible(fd1, fd2 protoreflect.FieldDescriptor, b []byte) bool {
+	typ2 := wireType(fd2)
+	if wireType(fd1) != typ2 {
+		return false
+	}
+	for len(b) > 0 {
+		_, typ, n := protowire.ConsumeField(b)
+		if n < 0 {
+			return false
+		}
+		if typ != typ2 && !(typ == protowire.BytesType && fd2.IsList()) {
+			return false
+		}
+		b = b[n:]
+	}
+	return true
+}
+
+// wireType returns the wire type of a single value of the field fd.
+func wireType(fd protoreflect.FieldDescriptor) protowire.Type {
+	switch fd.Kind() {
+	case protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind:
+		return protowire.BytesType
+	case protoreflect.GroupKind:
+		return protowire.StartGroupType
+	default:
+		return protowire.VarintType
+	}
+}
+
+// bytesField overrides the kind of a field descriptor as BytesKind.
+type bytesField struct {
+	protoreflect.FieldDescriptor
+}
+
+func (bytesField) Kind() protoreflect.Kind { return protoreflect.BytesKind }
+
+func isMessage(k protoreflect.Kind) bool {
+	return k == protoreflect.MessageKind || k == protoreflect.GroupKind
+}
+
+func isBytes(k protoreflect.Kind) bool {
+	return k == protoreflect.StringKind || k == protoreflect.BytesKind
+}
+
+func isNumber(k protoreflect.Kind) bool {
+	return !isMessage(k) && !isBytes(k)
+}
+
+func valueBytes(v protoreflect.Value) []byte {
+	if s, ok := v.Interface().(string); ok {
+		return []byte(s)
+	}
+	return v.Bytes()
+}
+
+func fieldName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsExtension() {
+		return "[" + string(fd.FullName()) + "]"
+	}
+	return string(fd.Name())
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatKey(k protoreflect.MapKey) string {
+	if s, ok := k.Interface().(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(k.Interface())
+}
+
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Kind() == protoreflect.EnumKind {
+		return fmt.Sprint(int32(v.Enum()))
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func cardinalityName(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return "map"
+	case fd.IsList():
+		return "list"
+	default:
+		return strings.ToLower(fd.Kind().String())
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoconvert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoconvert"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// mustMessage returns the descriptor of the message named "M"
+// in the file described by s.
+func mustMessage(t *testing.T, s string) protoreflect.MessageDescriptor {
+	t.Helper()
+	fdp := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(s), fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().ByName("M")
+}
+
+// mustParse parses s as a text message of the descriptor md.
+func mustParse(t *testing.T, md protoreflect.MessageDescriptor, s string) *dynamicpb.Message {
+	t.Helper()
+	m := dynamicpb.NewMessage(md)
+	if err := prototext.Unmarshal([]byte(s), m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// trimReasons removes error messages from the reasons of losses,
+// since error messages are unstable.
+func trimReasons(losses []protoconvert.Loss) []protoconvert.Loss {
+	var out []protoconvert.Loss
+	for _, l := range losses {
+		if i := strings.Index(l.Reason, "; not preserved:"); i >= 0 {
+			l.Reason = l.Reason[:i]
+		}
+		if i := strings.Index(l.Reason, ": proto:"); i >= 0 {
+			l.Reason = l.Reason[:i]
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+const v1File = `
+	name: "v1.proto"
+	package: "v1"
+	syntax: "proto3"
+	message_type: [{
+		name: "M"
+		field: [
+			{name:"a" number:1 label:LABEL_OPTIONAL type:TYPE_INT32},
+			{name:"b" number:2 label:LABEL_OPTIONAL type:TYPE_INT64},
+			{name:"c" number:3 label:LABEL_OPTIONAL type:TYPE_STRING},
+			{name:"d" number:4 label:LABEL_REPEATED type:TYPE_INT32},
+			{name:"sub" number:5 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".v1.M.Sub"},
+			{name:"m" number:6 label:LABEL_REPEATED type:TYPE_MESSAGE type_name:".v1.M.MEntry"},
+			{name:"e" number:7 label:LABEL_OPTIONAL type:TYPE_ENUM type_name:".v1.E"},
+			{name:"raw" number:8 label:LABEL_OPTIONAL type:TYPE_BYTES},
+			{name:"f" number:9 label:LABEL_REPEATED type:TYPE_DOUBLE},
+			{name:"removed" number:10 label:LABEL_OPTIONAL type:TYPE_INT32},
+			{name:"renamed" number:11 label:LABEL_OPTIONAL type:TYPE_STRING}
+		]
+		nested_type: [{
+			name: "Sub"
+			field: [
+				{name:"x" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+				{name:"y" number:2 label:LABEL_OPTIONAL type:TYPE_UINT64}
+			]
+		}, {
+			name: "MEntry"
+			field: [
+				{name:"key" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+				{name:"value" number:2 label:LABEL_OPTIONAL type:TYPE_INT32}
+			]
+			options: {map_entry:true}
+		}]
+	}]
+	enum_type: [{
+		name: "E"
+		value: [{name:"E_ZERO" number:0}, {name:"E_ONE" number:1}, {name:"E_TWO" number:2}]
+	}]
+`
+
+const v2File = `
+	name: "v2.proto"
+	package: "v2"
+	syntax: "proto3"
+	message_type: [{
+		name: "M"
+		field: [
+			{name:"a" number:1 label:LABEL_OPTIONAL type:TYPE_INT64},
+			{name:"b" number:2 label:LABEL_OPTIONAL type:TYPE_INT32},
+			{name:"c" number:3 label:LABEL_OPTIONAL type:TYPE_BYTES},
+			{name:"d" number:4 label:LABEL_OPTIONAL type:TYPE_INT32},
+			{name:"sub" number:5 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".v2.M.Sub"},
+			{name:"m" number:6 label:LABEL_REPEATED type:TYPE_MESSAGE type_name:".v2.M.MEntry"},
+			{name:"e" number:7 label:LABEL_OPTIONAL type:TYPE_ENUM type_name:".v2.E"},
+			{name:"raw" number:8 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".v2.M.Sub"},
+			{name:"f" number:9 label:LABEL_REPEATED type:TYPE_SINT32},
+			{name:"renamed_field" number:11 label:LABEL_OPTIONAL type:TYPE_STRING},
+			{name:"added" number:12 label:LABEL_OPTIONAL type:TYPE_BOOL}
+		]
+		nested_type: [{
+			name: "Sub"
+			field: [
+				{name:"x" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+				{name:"y" number:2 label:LABEL_OPTIONAL type:TYPE_STRING}
+			]
+		}, {
+			name: "MEntry"
+			field: [
+				{name:"key" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+				{name:"value" number:2 label:LABEL_OPTIONAL type:TYPE_INT64}
+			]
+			options: {map_entry:true}
+		}]
+	}]
+	enum_type: [{
+		name: "E"
+		value: [{name:"E_ZERO" number:0}, {name:"E_ONE" number:1}]
+	}]
+`
+
+func TestConvert(t *testing.T) {
+	v1 := mustMessage(t, v1File)
+	v2 := mustMessage(t, v2File)
+
+	src := mustParse(t, v1, `
+		a: 1
+		b: 10000000000
+		c: "hello"
+		d: [1, 2, 3]
+		sub: {x: "x" y: 5}
+		m: [{key: "k" value: 7}]
+		e: E_TWO
+		raw: "\x0a\x01z"
+		f: [1, 2.5]
+		removed: 4
+		renamed: "r"
+	`)
+	src.SetUnknown(protopack.Message{
+		protopack.Tag{12, protopack.VarintType}, protopack.Bool(true),
+	}.Marshal())
+
+	dst := dynamicpb.NewMessage(v2)
+	losses := protoconvert.Convert(dst, src)
+
+	want := mustParse(t, v2, `
+		a: 1
+		c: "hello"
+		d: 3
+		sub: {x: "x"}
+		m: [{key: "k" value: 7}]
+		e: 2
+		raw: {x: "z"}
+		f: [1]
+		renamed_field: "r"
+		added: true
+	`)
+	want.SetUnknown(protopack.Message{
+		protopack.Tag{2, protopack.VarintType}, protopack.Varint(10000000000),
+		protopack.Tag{10, protopack.VarintType}, protopack.Varint(4),
+	}.Marshal())
+	if !proto.Equal(dst, want) {
+		t.Errorf("Convert() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(dst), prototext.Format(want))
+	}
+
+	wantLosses := []protoconvert.Loss{
+		{Path: "b", Reason: "value 10000000000 cannot be represented as int32", Preserved: true},
+		{Path: "d", Reason: "only the last of 3 elements converted to singular field"},
+		{Path: "sub.y", Reason: "cannot convert uint64 to string"},
+		{Path: "e", Reason: "enum value 2 not present in v2.E", Preserved: true},
+		{Path: "f[1]", Reason: "value 2.5 cannot be represented as sint32"},
+		{Path: "removed", Reason: "field not present in v2.M", Preserved: true},
+	}
+	if diff := cmp.Diff(wantLosses, trimReasons(losses)); diff != "" {
+		t.Errorf("Convert() losses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertByName(t *testing.T) {
+	v1 := mustMessage(t, v1File)
+	v2 := mustMessage(t, v2File)
+
+	src := mustParse(t, v1, `a: 1 renamed: "r"`)
+	unknown := protopack.Message{
+		protopack.Tag{100, protopack.VarintType}, protopack.Varint(1),
+	}.Marshal()
+	src.SetUnknown(unknown)
+
+	dst := dynamicpb.NewMessage(v2)
+	losses := protoconvert.Options{ByName: true}.Convert(dst, src)
+
+	// The value of renamed is not preserved, since dst declares its number
+	// as renamed_field.
+	want := mustParse(t, v2, `a: 1`)
+	want.SetUnknown(unknown)
+	if !proto.Equal(dst, want) {
+		t.Errorf("Convert() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(dst), prototext.Format(want))
+	}
+	wantLosses := []protoconvert.Loss{
+		{Path: "renamed", Reason: "field not present in v2.M"},
+	}
+	if diff := cmp.Diff(wantLosses, trimReasons(losses)); diff != "" {
+		t.Errorf("Convert() losses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertByNameNumberCollision(t *testing.T) {
+	v1 := mustMessage(t, `
+		name: "v1.proto"
+		package: "v1"
+		syntax: "proto3"
+		message_type: [{
+			name: "M"
+			field: [
+				{name:"a" number:1 label:LABEL_OPTIONAL type:TYPE_STRING}
+			]
+		}]
+	`)
+	v2 := mustMessage(t, `
+		name: "v2.proto"
+		package: "v2"
+		syntax: "proto3"
+		message_type: [{
+			name: "M"
+			field: [
+				{name:"b" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+				{name:"a" number:5 label:LABEL_OPTIONAL type:TYPE_INT32}
+			]
+		}]
+	`)
+	src := mustParse(t, v1, `a: "secret"`)
+	unknown := protopack.Message{
+		protopack.Tag{1, protopack.BytesType}, protopack.String("unknown"),
+		protopack.Tag{100, protopack.VarintType}, protopack.Varint(1),
+	}.Marshal()
+	src.SetUnknown(unknown)
+
+	dst := dynamicpb.NewMessage(v2)
+	losses := protoconvert.Options{ByName: true}.Convert(dst, src)
+
+	// Neither the value of a nor the unknown field numbered 1 is stored
+	// under number 1, where dst would read them back as b.
+	want := dynamicpb.NewMessage(v2)
+	want.SetUnknown(protopack.Message{
+		protopack.Tag{100, protopack.VarintType}, protopack.Varint(1),
+	}.Marshal())
+	if !proto.Equal(dst, want) {
+		t.Errorf("Convert() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(dst), prototext.Format(want))
+	}
+	b, err := proto.Marshal(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := dynamicpb.NewMessage(v2)
+	if err := proto.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Has(v2.Fields().ByName("b")) {
+		t.Errorf("after round trip, b = %v, want unset", got.Get(v2.Fields().ByName("b")))
+	}
+	wantLosses := []protoconvert.Loss{
+		{Path: "a", Reason: "cannot convert string to int32"},
+		{Path: "", Reason: "unknown field 1 not preserved"},
+	}
+	if diff := cmp.Diff(wantLosses, trimReasons(losses)); diff != "" {
+		t.Errorf("Convert() losses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertClosedEnum(t *testing.T) {
+	v1 := mustMessage(t, `
+		name: "v1.proto"
+		package: "v1"
+		message_type: [{
+			name: "M"
+			field: [{name:"e" number:1 label:LABEL_REPEATED type:TYPE_INT32}]
+		}]
+	`)
+	v2 := mustMessage(t, `
+		name: "v2.proto"
+		package: "v2"
+		message_type: [{
+			name: "M"
+			field: [{name:"e" number:1 label:LABEL_REPEATED type:TYPE_ENUM type_name:".v2.E"}]
+		}]
+		enum_type: [{name:"E" value:[{name:"ONE" number:1}]}]
+	`)
+	src := mustParse(t, v1, `e: [1, 2]`)
+	dst := dynamicpb.NewMessage(v2)
+	losses := protoconvert.Convert(dst, src)
+
+	want := mustParse(t, v2, `e: [ONE]`)
+	want.SetUnknown(protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(2),
+	}.Marshal())
+	if !proto.Equal(dst, want) {
+		t.Errorf("Convert() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(dst), prototext.Format(want))
+	}
+	wantLosses := []protoconvert.Loss{
+		{Path: "e[1]", Reason: "enum value 2 not present in v2.E", Preserved: true},
+	}
+	if diff := cmp.Diff(wantLosses, losses); diff != "" {
+		t.Errorf("Convert() losses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertNotPreserved(t *testing.T) {
+	v1 := mustMessage(t, `
+		name: "v1.proto"
+		package: "v1"
+		syntax: "proto3"
+		message_type: [{
+			name: "M"
+			field: [
+				{name:"s" number:1 label:LABEL_OPTIONAL type:TYPE_STRING},
+				{name:"b" number:2 label:LABEL_OPTIONAL type:TYPE_BYTES},
+				{name:"n" number:3 label:LABEL_REPEATED type:TYPE_INT64}
+			]
+		}]
+	`)
+	v2 := mustMessage(t, `
+		name: "v2.proto"
+		package: "v2"
+		syntax: "proto3"
+		message_type: [{
+			name: "M"
+			field: [
+				{name:"b" number:2 label:LABEL_OPTIONAL type:TYPE_MESSAGE type_name:".v2.M"},
+				{name:"n" number:3 label:LABEL_REPEATED type:TYPE_INT32}
+			]
+		}]
+	`)
+	src := mustParse(t, v1, `b: "\xff" n: [1, 10000000000]`)
+	src.Set(v1.Fields().ByName("s"), protoreflect.ValueOfString("\xff"))
+	unknown := protopack.Message{
+		protopack.Tag{2, protopack.BytesType}, protopack.Bytes("\xff"),
+		protopack.Tag{100, protopack.VarintType}, protopack.Varint(1),
+	}.Marshal()
+	src.SetUnknown(unknown)
+
+	dst := dynamicpb.NewMessage(v2)
+	losses := protoconvert.Convert(dst, src)
+
+	// The element of n that does not fit in an int32 is preserved,
+	// since int32 and int64 have the same wire type.
+	want := mustParse(t, v2, `n: [1]`)
+	want.SetUnknown(protopack.Message{
+		protopack.Tag{3, protopack.BytesType}, protopack.LengthPrefix{protopack.Varint(10000000000)},
+		protopack.Tag{100, protopack.VarintType}, protopack.Varint(1),
+	}.Marshal())
+	if !proto.Equal(dst, want) {
+		t.Errorf("Convert() mismatch:\ngot:\n%v\nwant:\n%v", prototext.Format(dst), prototext.Format(want))
+	}
+	wantLosses := []protoconvert.Loss{
+		{Path: "s", Reason: "field not present in v2.M"},
+		{Path: "b", Reason: "cannot unmarshal bytes as v2.M"},
+		{Path: "n[1]", Reason: "value 10000000000 cannot be represented as int32", Preserved: true},
+		{Path: "", Reason: "unknown field 2 cannot be parsed"},
+	}
+	if diff := cmp.Diff(wantLosses, trimReasons(losses)); diff != "" {
+		t.Errorf("Convert() losses mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -22,7 +22,10 @@ type (
 		Marshal          func(marshalInput) (marshalOutput, error)
 		Unmarshal        func(unmarshalInput) (unmarshalOutput, error)
 		Merge            func(mergeInput) mergeOutput
+		Equal            func(equalInput) equalOutput
+		Reset            func(resetInput) resetOutput
 		CheckInitialized func(checkInitializedInput) (checkInitializedOutput, error)
+		Freeze           func(freezeInput) freezeOutput
 	}
 	supportFlags = uint64
 	sizeInput    = struct {
@@ -53,6 +56,9 @@ type (
 			FindExtensionByName(field FullName) (ExtensionType, error)
 			FindExtensionByNumber(message FullName, field FieldNumber) (ExtensionType, error)
 		}
+		FieldSelector interface {
+			SelectField(n FieldNumber) (sub interface{}, ok bool)
+		}
 	}
 	unmarshalOutput = struct {
 		pragma.NoUnkeyedLiterals
@@ -67,6 +73,24 @@ type (
 		pragma.NoUnkeyedLiterals
 		Flags uint8
 	}
+	equalInput = struct {
+		pragma.NoUnkeyedLiterals
+		MessageA Message
+		MessageB Message
+	}
+	equalOutput = struct {
+		pragma.NoUnkeyedLiterals
+		Flags uint8
+	}
+	resetInput = struct {
+		pragma.NoUnkeyedLiterals
+		Message Message
+		Flags   uint8
+	}
+	resetOutput = struct {
+		pragma.NoUnkeyedLiterals
+		Flags uint8
+	}
 	checkInitializedInput = struct {
 		pragma.NoUnkeyedLiterals
 		Message Message
@@ -74,4 +98,12 @@ type (
 	checkInitializedOutput = struct {
 		pragma.NoUnkeyedLiterals
 	}
+	freezeInput = struct {
+		pragma.NoUnkeyedLiterals
+		Message Message
+	}
+	freezeOutput = struct {
+		pragma.NoUnkeyedLiterals
+		Flags uint8
+	}
 )
@@ -37,6 +37,10 @@ type Enum interface {
 // Each field Value can be a scalar or a composite type (Message, List, or Map).
 // See Value for the Go types associated with a FieldDescriptor.
 // Providing a Value that is invalid or of an incorrect type panics.
+//
+// A message may be frozen by "google.golang.org/protobuf/proto".Freeze.
+// Methods that modify a frozen message, or any composite value obtained
+// from it, panic.
 type Message interface {
 	// Descriptor returns message descriptor, which contains only the protobuf
 	// type information for the message.
@@ -0,0 +1,279 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protostruct
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageFromGo copies src into the message m.
+func (c *converter) messageFromGo(m protoreflect.Message, src reflect.Value, path string) error {
+	md := m.Descriptor()
+	for src.IsValid() && src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+	if src.IsValid() && isMessage(src, md) {
+		if !(src.Kind() == reflect.Ptr && src.IsNil()) {
+			proto.Merge(m.Interface(), src.Interface().(proto.Message))
+		}
+		return nil
+	}
+	if md.FullName() == genid.Value_message_fullname {
+		return c.valueFromGo(m, src, path)
+	}
+	src = indirect(src)
+	if !src.IsValid() {
+		return nil
+	}
+	if ok, err := c.wellKnownFromGo(m, src, path); ok || err != nil {
+		return err
+	}
+	switch {
+	case src.Kind() == reflect.Struct:
+		for _, f := range c.structFields(src.Type()) {
+			fieldPath := joinPath(path, f.name)
+			fd := findField(md, f.name)
+			if fd == nil {
+				c.unmap(fieldPath)
+				continue
+			}
+			if err := c.fieldFromGo(m, fd, src.Field(f.index), fieldPath); err != nil {
+				return err
+			}
+		}
+	case src.Kind() == reflect.Map && src.Type().Key().Kind() == reflect.String:
+		keys := src.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			fieldPath := joinPath(path, k.String())
+			fd := findField(md, k.String())
+			if fd == nil {
+				c.unmap(fieldPath)
+				continue
+			}
+			if err := c.fieldFromGo(m, fd, src.MapIndex(k), fieldPath); err != nil {
+				return err
+			}
+		}
+	default:
+		return newError(path, "cannot copy %v to message %v", src.Type(), md.FullName())
+	}
+	return nil
+}
+
+// fieldFromGo copies src into the field fd of m.
+func (c *converter) fieldFromGo(m protoreflect.Message, fd protoreflect.FieldDescriptor, src reflect.Value, path string) error {
+	switch {
+	case fd.IsList():
+		src = indirect(src)
+		if !src.IsValid() {
+			return nil
+		}
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return newError(path, "cannot copy %v to repeated field", src.Type())
+		}
+		if src.Len() == 0 {
+			return nil
+		}
+		list := m.Mutable(fd).List()
+		for i := 0; i < src.Len(); i++ {
+			v, err := c.singularFromGo(fd, src.Index(i), list.NewElement, path)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+	case fd.IsMap():
+		src = indirect(src)
+		if !src.IsValid() {
+			return nil
+		}
+		if src.Kind() != reflect.Map {
+			return newError(path, "cannot copy %v to map field", src.Type())
+		}
+		if src.Len() == 0 {
+			return nil
+		}
+		mp := m.Mutable(fd).Map()
+		for _, k := range src.MapKeys() {
+			kv, err := c.mapKeyFromGo(fd.MapKey(), k, path)
+			if err != nil {
+				return err
+			}
+			v, err := c.singularFromGo(fd.MapValue(), src.MapIndex(k), mp.NewValue, path)
+			if err != nil {
+				return err
+			}
+			mp.Set(kv.MapKey(), v)
+		}
+	default:
+		switch src.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			if src.IsNil() {
+				return nil
+			}
+		}
+		newValue := func() protoreflect.Value { return m.NewField(fd) }
+		if fd.Message() != nil {
+			// Merge into any existing message, as with proto.Merge.
+			newValue = func() protoreflect.Value { return m.Mutable(fd) }
+		}
+		v, err := c.singularFromGo(fd, src, newValue, path)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, v)
+	}
+	return nil
+}
+
+func (c *converter) mapKeyFromGo(fd protoreflect.FieldDescriptor, k reflect.Value, path string) (protoreflect.Value, error) {
+	k = indirect(k)
+	if k.IsValid() && k.Kind() == reflect.String && fd.Kind() != protoreflect.StringKind {
+		var x interface{}
+		var err error
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			x, err = strconv.ParseBool(k.String())
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			x, err = strconv.ParseInt(k.String(), 10, 64)
+		default:
+			x, err = strconv.ParseUint(k.String(), 10, 64)
+		}
+		if err != nil {
+			return protoreflect.Value{}, newError(path, "invalid map key %q", k.String())
+		}
+		k = reflect.ValueOf(x)
+	}
+	return c.singularFromGo(fd, k, nil, path)
+}
+
+// singularFromGo converts src to a single value of the field fd.
+// For message fields, newValue returns a mutable message value to populate.
+func (c *converter) singularFromGo(fd protoreflect.FieldDescriptor, src reflect.Value, newValue func() protoreflect.Value, path string) (protoreflect.Value, error) {
+	if fd.Message() != nil {
+		v := newValue()
+		if err := c.messageFromGo(v.Message(), src, path); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return v, nil
+	}
+	src = indirect(src)
+	if !src.IsValid() {
+		return protoreflect.Value{}, newError(path, "cannot copy nil to %v field", fd.Kind())
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if src.Kind() == reflect.Bool {
+			return protoreflect.ValueOfBool(src.Bool()), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := intFromGo(src, math.MinInt32, math.MaxInt32, path)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := intFromGo(src, math.MinInt64, math.MaxInt64, path)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := uintFromGo(src, math.MaxUint32, path)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := uintFromGo(src, math.MaxUint64, path)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := floatFromGo(src, path)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := floatFromGo(src, path)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		switch {
+		case src.Kind() == reflect.String:
+			return protoreflect.ValueOfString(src.String()), nil
+		case isBytes(src.Type()):
+			return protoreflect.ValueOfString(string(src.Bytes())), nil
+		}
+	case protoreflect.BytesKind:
+		switch {
+		case src.Kind() == reflect.String:
+			return protoreflect.ValueOfBytes([]byte(src.String())), nil
+		case isBytes(src.Type()):
+			return protoreflect.ValueOfBytes(append([]byte(nil), src.Bytes()...)), nil
+		}
+	case protoreflect.EnumKind:
+		if src.Kind() == reflect.String {
+			ev := fd.Enum().Values().ByName(protoreflect.Name(src.String()))
+			if ev == nil {
+				return protoreflect.Value{}, newError(path, "invalid value %q for enum %v", src.String(), fd.Enum().FullName())
+			}
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := intFromGo(src, math.MinInt32, math.MaxInt32, path)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	}
+	return protoreflect.Value{}, newError(path, "cannot copy %v to %v field", src.Type(), fd.Kind())
+}
+
+// intFromGo converts the number src to an integer within [min, max].
+func intFromGo(src reflect.Value, min, max int64, path string) (int64, error) {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := src.Int(); min <= n && n <= max {
+			return n, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := src.Uint(); n <= uint64(max) {
+			return int64(n), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := src.Float(); f == math.Trunc(f) && float64(min) <= f && f < 1<<63 && int64(f) <= max {
+			return int64(f), nil
+		}
+	default:
+		return 0, newError(path, "cannot copy %v to integer field", src.Type())
+	}
+	return 0, newError(path, "value %v is out of range", src.Interface())
+}
+
+// uintFromGo converts the number src to an unsigned integer within [0, max].
+func uintFromGo(src reflect.Value, max uint64, path string) (uint64, error) {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := src.Int(); n >= 0 && uint64(n) <= max {
+			return uint64(n), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := src.Uint(); n <= max {
+			return n, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := src.Float(); f == math.Trunc(f) && 0 <= f && f < 1<<64 && uint64(f) <= max {
+			return uint64(f), nil
+		}
+	default:
+		return 0, newError(path, "cannot copy %v to integer field", src.Type())
+	}
+	return 0, newError(path, "value %v is out of range", src.Interface())
+}
+
+// floatFromGo converts the number src to a floating-point number.
+func floatFromGo(src reflect.Value, path string) (float64, error) {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(src.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(src.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return src.Float(), nil
+	}
+	return 0, newError(path, "cannot copy %v to floating-point field", src.Type())
+}
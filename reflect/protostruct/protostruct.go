@@ -0,0 +1,245 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protostruct copies data between messages and plain Go values,
+// such as structs used by a persistence layer.
+//
+// The copy is performed using protobuf reflection on the message side and
+// Go reflection on the other side, so it works for generated and dynamic
+// messages alike. A message corresponds to a Go struct or to a Go map with
+// string keys. A struct field corresponds to the message field whose name
+// matches the name given in the struct tag selected by Options.TagKey, or
+// the Go field name if there is no such tag. Names are matched against the
+// proto and JSON names of message fields, ignoring case and underscores.
+// Map keys are matched in the same way.
+//
+// Values are converted as follows:
+//
+//	╔════════════════════════════╤═══════════════════════════════════════╗
+//	║ Protobuf                   │ Go                                    ║
+//	╠════════════════════════════╪═══════════════════════════════════════╣
+//	║ bool, string, bytes        │ bool, string, []byte                  ║
+//	║ numeric types              │ any numeric type that fits the value  ║
+//	║ enums                      │ string (value name) or integer types  ║
+//	║ google.protobuf.Timestamp  │ time.Time                             ║
+//	║ google.protobuf.Duration   │ time.Duration                         ║
+//	║ wrappers                   │ the wrapped type, usually a pointer   ║
+//	║ google.protobuf.Struct     │ map[string]interface{}                ║
+//	║ google.protobuf.ListValue  │ []interface{}                         ║
+//	║ google.protobuf.Value      │ interface{}                           ║
+//	║ other messages             │ struct or map with string keys        ║
+//	║ repeated fields            │ slices                                ║
+//	║ map fields                 │ maps                                  ║
+//	╚════════════════════════════╧═══════════════════════════════════════╝
+//
+// A Go pointer is nil exactly when the corresponding field is not populated.
+// An interface{} receives the value in the representation listed above,
+// with other messages represented as map[string]interface{} keyed by
+// proto field name. A Go value which implements proto.Message
+// is copied as is. Extension fields are not copied.
+package protostruct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Options configures the copy between messages and Go values.
+type Options struct {
+	// TagKey is the key of the struct tag which names the message field
+	// corresponding to a struct field, such as "json" or "db".
+	// The tag value is the field name optionally followed by a comma and
+	// further options, which are ignored. A struct field tagged "-" is
+	// skipped. If TagKey is empty or a struct field has no such tag,
+	// the Go field name is used.
+	TagKey string
+}
+
+// ToGo copies the message src into the Go value pointed to by dst.
+// It is equivalent to Options{}.ToGo(dst, src).
+func ToGo(dst interface{}, src proto.Message) (unmapped []string, err error) {
+	return Options{}.ToGo(dst, src)
+}
+
+// FromGo copies the Go value src into the message dst.
+// It is equivalent to Options{}.FromGo(dst, src).
+func FromGo(dst proto.Message, src interface{}) (unmapped []string, err error) {
+	return Options{}.FromGo(dst, src)
+}
+
+// ToGo copies the message src into the Go value pointed to by dst,
+// which is usually a pointer to a struct or a map with string keys.
+// Struct fields which correspond to a message field are overwritten,
+// while other struct fields are left unchanged.
+//
+// It returns the paths of the message fields which have no corresponding
+// field in dst, such as "address.zip_code". A path is reported at most once
+// regardless of how many list elements or map values contain it.
+func (o Options) ToGo(dst interface{}, src proto.Message) (unmapped []string, err error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, errors.New("destination must be a non-nil pointer, got %T", dst)
+	}
+	c := &converter{opts: o}
+	if err := c.messageToGo(rv.Elem(), src.ProtoReflect(), ""); err != nil {
+		return nil, err
+	}
+	return c.unmapped, nil
+}
+
+// FromGo copies the Go value src into the message dst,
+// where src is usually a struct or a map with string keys,
+// or a pointer to one of those. Nil pointers, slices, maps,
+// and interfaces leave the corresponding message field unpopulated.
+// The result is merged into dst.
+//
+// It returns the paths of the Go values which have no corresponding
+// message field, such as "Address.Country".
+func (o Options) FromGo(dst proto.Message, src interface{}) (unmapped []string, err error) {
+	c := &converter{opts: o}
+	if err := c.messageFromGo(dst.ProtoReflect(), reflect.ValueOf(src), ""); err != nil {
+		return nil, err
+	}
+	return c.unmapped, nil
+}
+
+type converter struct {
+	opts     Options
+	unmapped []string
+	seen     map[string]bool
+}
+
+// unmap records that path has no counterpart in the destination.
+func (c *converter) unmap(path string) {
+	if c.seen == nil {
+		c.seen = make(map[string]bool)
+	}
+	if !c.seen[path] {
+		c.seen[path] = true
+		c.unmapped = append(c.unmapped, path)
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+var (
+	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	genericMapType   = reflect.TypeOf(map[string]interface{}(nil))
+	genericListType  = reflect.TypeOf([]interface{}(nil))
+)
+
+// isEmptyInterface reports whether t is an interface type with no methods,
+// which may hold any value.
+func isEmptyInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() == 0
+}
+
+// isMessage reports whether the Go value v holds a message of type md.
+func isMessage(v reflect.Value, md protoreflect.MessageDescriptor) bool {
+	if !v.Type().Implements(protoMessageType) {
+		return false
+	}
+	return v.Interface().(proto.Message).ProtoReflect().Descriptor().FullName() == md.FullName()
+}
+
+// structField is a struct field which corresponds to a message field.
+type structField struct {
+	index int
+	name  string
+}
+
+var structFieldsCache sync.Map // map[structFieldsKey][]structField
+
+type structFieldsKey struct {
+	t      reflect.Type
+	tagKey string
+}
+
+// structFields returns the exported fields of the struct type t
+// along with the names they are matched by.
+func (c *converter) structFields(t reflect.Type) []structField {
+	key := structFieldsKey{t, c.opts.TagKey}
+	if fs, ok := structFieldsCache.Load(key); ok {
+		return fs.([]structField)
+	}
+	var fs []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		name := f.Name
+		if c.opts.TagKey != "" {
+			if tag, ok := f.Tag.Lookup(c.opts.TagKey); ok {
+				if i := strings.IndexByte(tag, ','); i >= 0 {
+					tag = tag[:i]
+				}
+				if tag == "-" {
+					continue
+				}
+				if tag != "" {
+					name = tag
+				}
+			}
+		}
+		fs = append(fs, structField{index: i, name: name})
+	}
+	structFieldsCache.Store(key, fs)
+	return fs
+}
+
+// normalizeName returns the canonical form of a field name
+// used for matching, which ignores case and underscores.
+func normalizeName(s string) string {
+	return strings.ToLower(strings.Replace(s, "_", "", -1))
+}
+
+// findField returns the field of md matched by name, or nil if none.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fds := md.Fields()
+	if fd := fds.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	if fd := fds.ByJSONName(name); fd != nil {
+		return fd
+	}
+	norm := normalizeName(name)
+	for i := 0; i < fds.Len(); i++ {
+		if fd := fds.Get(i); normalizeName(string(fd.Name())) == norm {
+			return fd
+		}
+	}
+	return nil
+}
+
+// newError returns an error for the value at path.
+func newError(path string, f string, x ...interface{}) error {
+	if path == "" {
+		return errors.New(f, x...)
+	}
+	return errors.New("%v: %v", path, fmt.Sprintf(f, x...))
+}
+
+// indirect dereferences pointers and interfaces in v.
+// It returns the zero Value if v or any value it refers to is nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,238 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protostruct_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protostruct"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	pb "google.golang.org/protobuf/internal/testprotos/conformance"
+)
+
+type nested struct {
+	A int `db:"a"`
+}
+
+type record struct {
+	ID       int64                  `db:"optional_int32"`
+	Name     string                 `db:"optional_string"`
+	Data     []byte                 `db:"optional_bytes"`
+	Enum     string                 `db:"optional_nested_enum"`
+	Nested   *nested                `db:"optional_nested_message"`
+	Created  time.Time              `db:"optional_timestamp"`
+	TTL      time.Duration          `db:"optional_duration"`
+	Count    *int32                 `db:"optional_int32_wrapper"`
+	Label    *string                `db:"optional_string_wrapper"`
+	Meta     map[string]interface{} `db:"optional_struct"`
+	Value    interface{}            `db:"optional_value"`
+	Tags     []string               `db:"repeated_string"`
+	Ints     map[string]int         `db:"map_int32_int32"`
+	Extra    string                 `db:"extra"`
+	Internal string                 `db:"-"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	created := time.Date(2020, 5, 1, 12, 30, 0, 500, time.UTC)
+	rec := record{
+		ID:      42,
+		Name:    "name",
+		Data:    []byte("data"),
+		Enum:    "BAR",
+		Nested:  &nested{A: 7},
+		Created: created,
+		TTL:     90 * time.Second,
+		Count:   proto.Int32(0),
+		Meta: map[string]interface{}{
+			"n":    1.5,
+			"s":    "str",
+			"list": []interface{}{true, nil},
+		},
+		Value:    "value",
+		Tags:     []string{"a", "b"},
+		Ints:     map[string]int{"1": 2},
+		Extra:    "extra",
+		Internal: "internal",
+	}
+	want := &pb.TestAllTypesProto3{
+		OptionalInt32:         42,
+		OptionalString:        "name",
+		OptionalBytes:         []byte("data"),
+		OptionalNestedEnum:    pb.TestAllTypesProto3_BAR,
+		OptionalNestedMessage: &pb.TestAllTypesProto3_NestedMessage{A: 7},
+		OptionalTimestamp:     timestamppb.New(created),
+		OptionalDuration:      durationpb.New(90 * time.Second),
+		OptionalInt32Wrapper:  wrapperspb.Int32(0),
+		OptionalStruct: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"n": structpb.NewNumberValue(1.5),
+			"s": structpb.NewStringValue("str"),
+			"list": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+				structpb.NewBoolValue(true),
+				structpb.NewNullValue(),
+			}}),
+		}},
+		OptionalValue:  structpb.NewStringValue("value"),
+		RepeatedString: []string{"a", "b"},
+		MapInt32Int32:  map[int32]int32{1: 2},
+	}
+	opts := protostruct.Options{TagKey: "db"}
+
+	got := new(pb.TestAllTypesProto3)
+	unmapped, err := opts.FromGo(got, &rec)
+	if err != nil {
+		t.Fatalf("FromGo() error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("FromGo() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"extra"}, unmapped); diff != "" {
+		t.Errorf("FromGo() unmapped mismatch (-want +got):\n%s", diff)
+	}
+
+	back := record{Extra: "extra", Internal: "internal"}
+	unmapped, err = opts.ToGo(&back, got)
+	if err != nil {
+		t.Fatalf("ToGo() error: %v", err)
+	}
+	if diff := cmp.Diff(rec, back); diff != "" {
+		t.Errorf("ToGo() mismatch (-want +got):\n%s", diff)
+	}
+	for _, path := range []string{"optional_int64", "optional_nested_message.corecursive"} {
+		if !contains(unmapped, path) {
+			t.Errorf("ToGo() unmapped = %v, want it to contain %q", unmapped, path)
+		}
+	}
+	for _, path := range []string{"optional_int32", "optional_timestamp"} {
+		if contains(unmapped, path) {
+			t.Errorf("ToGo() unmapped = %v, want it not to contain %q", unmapped, path)
+		}
+	}
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFieldNames(t *testing.T) {
+	// Without a tag key, the Go field name is matched
+	// ignoring case and underscores.
+	src := struct {
+		OptionalInt32 int32
+		Optionalfloat float64
+		Fieldname1    int32
+		unexported    int32
+	}{1, 2.5, 3, 4}
+	got := new(pb.TestAllTypesProto3)
+	if _, err := protostruct.FromGo(got, src); err != nil {
+		t.Fatalf("FromGo() error: %v", err)
+	}
+	want := &pb.TestAllTypesProto3{OptionalInt32: 1, OptionalFloat: 2.5, Fieldname1: 3}
+	if !proto.Equal(got, want) {
+		t.Errorf("FromGo() = %v, want %v", got, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	m := &pb.TestAllTypesProto3{
+		OptionalInt32:         1,
+		OptionalNestedEnum:    pb.TestAllTypesProto3_BAZ,
+		OptionalNestedMessage: &pb.TestAllTypesProto3_NestedMessage{A: 2},
+		OptionalTimestamp:     &timestamppb.Timestamp{Seconds: 10},
+		OptionalBoolWrapper:   wrapperspb.Bool(true),
+		RepeatedInt64:         []int64{3, 4},
+		MapStringString:       map[string]string{"k": "v"},
+	}
+	var got map[string]interface{}
+	if _, err := protostruct.ToGo(&got, m); err != nil {
+		t.Fatalf("ToGo() error: %v", err)
+	}
+	want := map[string]interface{}{
+		"optional_int32":          int32(1),
+		"optional_nested_enum":    "BAZ",
+		"optional_nested_message": map[string]interface{}{"a": int32(2)},
+		"optional_timestamp":      time.Unix(10, 0).UTC(),
+		"optional_bool_wrapper":   true,
+		"repeated_int64":          []interface{}{int64(3), int64(4)},
+		"map_string_string":       map[string]interface{}{"k": "v"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToGo() mismatch (-want +got):\n%s", diff)
+	}
+
+	m2 := new(pb.TestAllTypesProto3)
+	if _, err := protostruct.FromGo(m2, got); err != nil {
+		t.Fatalf("FromGo() error: %v", err)
+	}
+	if diff := cmp.Diff(m, m2, protocmp.Transform()); diff != "" {
+		t.Errorf("FromGo() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromGoMerge(t *testing.T) {
+	m := &pb.TestAllTypesProto3{
+		OptionalNestedMessage: &pb.TestAllTypesProto3_NestedMessage{
+			Corecursive: &pb.TestAllTypesProto3{OptionalInt32: 1},
+		},
+	}
+	if _, err := protostruct.FromGo(m, map[string]interface{}{
+		"optional_nested_message": map[string]interface{}{"a": 2},
+	}); err != nil {
+		t.Fatalf("FromGo() error: %v", err)
+	}
+	want := &pb.TestAllTypesProto3{
+		OptionalNestedMessage: &pb.TestAllTypesProto3_NestedMessage{
+			A:           2,
+			Corecursive: &pb.TestAllTypesProto3{OptionalInt32: 1},
+		},
+	}
+	if diff := cmp.Diff(want, m, protocmp.Transform()); diff != "" {
+		t.Errorf("FromGo() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	m := &pb.TestAllTypesProto3{OptionalInt64: 1 << 40}
+	var small struct{ OptionalInt64 int32 }
+	if _, err := protostruct.ToGo(&small, m); err == nil {
+		t.Errorf("ToGo() with overflow: got nil error, want error")
+	}
+	if _, err := protostruct.ToGo(small, m); err == nil {
+		t.Errorf("ToGo() with non-pointer: got nil error, want error")
+	}
+	var inexact struct {
+		OptionalInt64  float32
+		OptionalUint64 float32
+	}
+	if _, err := protostruct.ToGo(&inexact, &pb.TestAllTypesProto3{OptionalInt64: 1<<24 + 1}); err == nil {
+		t.Errorf("ToGo() with inexact float32 from int64: got nil error, want error")
+	}
+	if _, err := protostruct.ToGo(&inexact, &pb.TestAllTypesProto3{OptionalUint64: 1<<24 + 1}); err == nil {
+		t.Errorf("ToGo() with inexact float32 from uint64: got nil error, want error")
+	}
+	if _, err := protostruct.FromGo(new(pb.TestAllTypesProto3), map[string]interface{}{
+		"optional_nested_enum": "NO_SUCH_VALUE",
+	}); err == nil {
+		t.Errorf("FromGo() with invalid enum: got nil error, want error")
+	}
+	if _, err := protostruct.FromGo(new(pb.TestAllTypesProto3), map[string]interface{}{
+		"optional_uint32": -1,
+	}); err == nil {
+		t.Errorf("FromGo() with negative uint32: got nil error, want error")
+	}
+}
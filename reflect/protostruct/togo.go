@@ -0,0 +1,324 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protostruct
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageToGo copies the message m into dst.
+func (c *converter) messageToGo(dst reflect.Value, m protoreflect.Message, path string) error {
+	if ok, err := c.wellKnownToGo(dst, m, path); ok || err != nil {
+		return err
+	}
+	md := m.Descriptor()
+	fds := md.Fields()
+	t := dst.Type()
+	switch {
+	case isEmptyInterface(t):
+		v := reflect.New(genericMapType).Elem()
+		if err := c.messageToGo(v, m, path); err != nil {
+			return err
+		}
+		dst.Set(v)
+	case t.Kind() == reflect.Ptr:
+		v := reflect.New(t.Elem())
+		if err := c.messageToGo(v.Elem(), m, path); err != nil {
+			return err
+		}
+		dst.Set(v)
+	case t.Kind() == reflect.Struct:
+		indexes := make(map[protoreflect.FieldNumber]int)
+		for _, f := range c.structFields(t) {
+			if fd := findField(md, f.name); fd != nil {
+				indexes[fd.Number()] = f.index
+			}
+		}
+		for i := 0; i < fds.Len(); i++ {
+			fd := fds.Get(i)
+			fieldPath := joinPath(path, string(fd.Name()))
+			index, ok := indexes[fd.Number()]
+			if !ok {
+				c.unmap(fieldPath)
+				continue
+			}
+			if err := c.fieldToGo(dst.Field(index), m, fd, fieldPath); err != nil {
+				return err
+			}
+		}
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(t))
+		}
+		for i := 0; i < fds.Len(); i++ {
+			fd := fds.Get(i)
+			if !m.Has(fd) {
+				continue
+			}
+			v := reflect.New(t.Elem()).Elem()
+			if err := c.fieldToGo(v, m, fd, joinPath(path, string(fd.Name()))); err != nil {
+				return err
+			}
+			dst.SetMapIndex(reflect.ValueOf(string(fd.Name())).Convert(t.Key()), v)
+		}
+	default:
+		return newError(path, "cannot copy message %v to %v", md.FullName(), t)
+	}
+	return nil
+}
+
+// fieldToGo copies the field fd of m into dst.
+func (c *converter) fieldToGo(dst reflect.Value, m protoreflect.Message, fd protoreflect.FieldDescriptor, path string) error {
+	switch {
+	case fd.IsList():
+		return c.listToGo(dst, m.Get(fd).List(), fd, path)
+	case fd.IsMap():
+		return c.mapToGo(dst, m.Get(fd).Map(), fd, path)
+	case fd.HasPresence() && !m.Has(fd):
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	default:
+		return c.singularToGo(dst, m.Get(fd), fd, path)
+	}
+}
+
+func (c *converter) listToGo(dst reflect.Value, list protoreflect.List, fd protoreflect.FieldDescriptor, path string) error {
+	t := dst.Type()
+	if isEmptyInterface(t) {
+		t = genericListType
+	}
+	if t.Kind() != reflect.Slice {
+		return newError(path, "cannot copy repeated field to %v", t)
+	}
+	if list.Len() == 0 {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	s := reflect.MakeSlice(t, list.Len(), list.Len())
+	for i := 0; i < list.Len(); i++ {
+		if err := c.singularToGo(s.Index(i), list.Get(i), fd, path); err != nil {
+			return err
+		}
+	}
+	dst.Set(s)
+	return nil
+}
+
+func (c *converter) mapToGo(dst reflect.Value, mp protoreflect.Map, fd protoreflect.FieldDescriptor, path string) error {
+	t := dst.Type()
+	if isEmptyInterface(t) {
+		t = genericMapType
+	}
+	if t.Kind() != reflect.Map {
+		return newError(path, "cannot copy map field to %v", t)
+	}
+	if mp.Len() == 0 {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	out := reflect.MakeMap(t)
+	var err error
+	mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		kv := reflect.New(t.Key()).Elem()
+		if kd := fd.MapKey(); kv.Kind() == reflect.String && kd.Kind() != protoreflect.StringKind {
+			kv.SetString(k.String())
+		} else if err = c.singularToGo(kv, k.Value(), kd, path); err != nil {
+			return false
+		}
+		vv := reflect.New(t.Elem()).Elem()
+		if err = c.singularToGo(vv, v, fd.MapValue(), path); err != nil {
+			return false
+		}
+		out.SetMapIndex(kv, vv)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	dst.Set(out)
+	return nil
+}
+
+// singularToGo copies a single value v of the field fd into dst.
+func (c *converter) singularToGo(dst reflect.Value, v protoreflect.Value, fd protoreflect.FieldDescriptor, path string) error {
+	t := dst.Type()
+	isMessageKind := fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind
+	switch {
+	case isMessageKind && t.Kind() == reflect.Ptr && t.Implements(protoMessageType):
+		p := reflect.New(t.Elem())
+		pm := p.Interface().(proto.Message)
+		if pm.ProtoReflect().Descriptor().FullName() != fd.Message().FullName() {
+			return newError(path, "cannot copy message %v to %v", fd.Message().FullName(), t)
+		}
+		proto.Merge(pm, v.Message().Interface())
+		dst.Set(p)
+		return nil
+	case isMessageKind:
+		return c.messageToGo(dst, v.Message(), path)
+	case t.Kind() == reflect.Ptr:
+		p := reflect.New(t.Elem())
+		if err := c.singularToGo(p.Elem(), v, fd, path); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	case isEmptyInterface(t):
+		switch x := v.Interface().(type) {
+		case protoreflect.EnumNumber:
+			dst.Set(reflect.ValueOf(enumName(fd.Enum(), x)))
+		case []byte:
+			dst.Set(reflect.ValueOf(append([]byte(nil), x...)))
+		default:
+			dst.Set(reflect.ValueOf(x))
+		}
+		return nil
+	}
+
+	var ok bool
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if ok = t.Kind() == reflect.Bool; ok {
+			dst.SetBool(v.Bool())
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return setInt(dst, v.Int(), path)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return setUint(dst, v.Uint(), path)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return setFloat(dst, v.Float(), path)
+	case protoreflect.StringKind:
+		switch {
+		case t.Kind() == reflect.String:
+			dst.SetString(v.String())
+			ok = true
+		case isBytes(t):
+			dst.SetBytes([]byte(v.String()))
+			ok = true
+		}
+	case protoreflect.BytesKind:
+		switch {
+		case t.Kind() == reflect.String:
+			dst.SetString(string(v.Bytes()))
+			ok = true
+		case isBytes(t):
+			dst.SetBytes(append([]byte(nil), v.Bytes()...))
+			ok = true
+		}
+	case protoreflect.EnumKind:
+		if t.Kind() == reflect.String {
+			dst.SetString(enumName(fd.Enum(), v.Enum()))
+			return nil
+		}
+		return setInt(dst, int64(v.Enum()), path)
+	}
+	if !ok {
+		return newError(path, "cannot copy %v value to %v", fd.Kind(), t)
+	}
+	return nil
+}
+
+// isBytes reports whether t is a slice of bytes.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// enumName returns the name of the enum value n,
+// or its decimal representation if ed has no such value.
+func enumName(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber) string {
+	if ev := ed.Values().ByNumber(n); ev != nil {
+		return string(ev.Name())
+	}
+	return strconv.Itoa(int(n))
+}
+
+// setInt sets dst to the integer n, reporting an error
+// if n cannot be represented exactly by dst.
+func setInt(dst reflect.Value, n int64, path string) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !dst.OverflowInt(n) {
+			dst.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n >= 0 && !dst.OverflowUint(uint64(n)) {
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		f := float64(n)
+		if dst.Kind() == reflect.Float32 {
+			f = float64(float32(n))
+		}
+		if f < math.MaxInt64 && int64(f) == n {
+			dst.SetFloat(f)
+			return nil
+		}
+	default:
+		return newError(path, "cannot copy integer to %v", dst.Type())
+	}
+	return newError(path, "value %v cannot be represented as %v", n, dst.Type())
+}
+
+// setUint sets dst to the integer n, reporting an error
+// if n cannot be represented exactly by dst.
+func setUint(dst reflect.Value, n uint64, path string) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n <= math.MaxInt64 && !dst.OverflowInt(int64(n)) {
+			dst.SetInt(int64(n))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !dst.OverflowUint(n) {
+			dst.SetUint(n)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		f := float64(n)
+		if dst.Kind() == reflect.Float32 {
+			f = float64(float32(n))
+		}
+		if f < math.MaxUint64 && uint64(f) == n {
+			dst.SetFloat(f)
+			return nil
+		}
+	default:
+		return newError(path, "cannot copy integer to %v", dst.Type())
+	}
+	return newError(path, "value %v cannot be represented as %v", n, dst.Type())
+}
+
+// setFloat sets dst to the floating-point number f, reporting an error
+// if f cannot be represented by dst. Integer types require f to be integral.
+func setFloat(dst reflect.Value, f float64, path string) error {
+	switch dst.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if math.IsInf(f, 0) || math.IsNaN(f) || !dst.OverflowFloat(f) {
+			dst.SetFloat(f)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !dst.OverflowInt(int64(f)) {
+			dst.SetInt(int64(f))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !dst.OverflowUint(uint64(f)) {
+			dst.SetUint(uint64(f))
+			return nil
+		}
+	default:
+		return newError(path, "cannot copy floating-point number to %v", dst.Type())
+	}
+	return newError(path, "value %v cannot be represented as %v", f, dst.Type())
+}
@@ -0,0 +1,268 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protostruct
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// isWrapper reports whether the message named name is one of the wrapper
+// messages in wrappers.proto.
+func isWrapper(name protoreflect.FullName) bool {
+	switch name {
+	case genid.BoolValue_message_fullname,
+		genid.Int32Value_message_fullname,
+		genid.Int64Value_message_fullname,
+		genid.UInt32Value_message_fullname,
+		genid.UInt64Value_message_fullname,
+		genid.FloatValue_message_fullname,
+		genid.DoubleValue_message_fullname,
+		genid.StringValue_message_fullname,
+		genid.BytesValue_message_fullname:
+		return true
+	}
+	return false
+}
+
+// wellKnownToGo copies the well-known type m into dst if dst is of the
+// Go type corresponding to it. It reports whether m was copied.
+func (c *converter) wellKnownToGo(dst reflect.Value, m protoreflect.Message, path string) (bool, error) {
+	t := dst.Type()
+	if t.Kind() == reflect.Ptr {
+		return false, nil
+	}
+	md := m.Descriptor()
+	fds := md.Fields()
+	switch name := md.FullName(); {
+	case name == genid.Timestamp_message_fullname:
+		if t != timeType && !isEmptyInterface(t) {
+			return false, nil
+		}
+		secs := m.Get(fds.ByNumber(genid.Timestamp_Seconds_field_number)).Int()
+		nanos := m.Get(fds.ByNumber(genid.Timestamp_Nanos_field_number)).Int()
+		dst.Set(reflect.ValueOf(time.Unix(secs, nanos).UTC()))
+		return true, nil
+	case name == genid.Duration_message_fullname:
+		if t != durationType && !isEmptyInterface(t) {
+			return false, nil
+		}
+		secs := m.Get(fds.ByNumber(genid.Duration_Seconds_field_number)).Int()
+		nanos := m.Get(fds.ByNumber(genid.Duration_Nanos_field_number)).Int()
+		if secs > math.MaxInt64/int64(time.Second) || secs < math.MinInt64/int64(time.Second) {
+			return true, newError(path, "duration of %vs cannot be represented as time.Duration", secs)
+		}
+		dst.Set(reflect.ValueOf(time.Duration(secs)*time.Second + time.Duration(nanos)))
+		return true, nil
+	case isWrapper(name):
+		if t.Kind() == reflect.Struct || t.Kind() == reflect.Map {
+			return false, nil
+		}
+		fd := fds.ByNumber(genid.WrapperValue_Value_field_number)
+		return true, c.singularToGo(dst, m.Get(fd), fd, path)
+	case name == genid.Struct_message_fullname,
+		name == genid.ListValue_message_fullname,
+		name == genid.Value_message_fullname:
+		var v interface{}
+		switch name {
+		case genid.Struct_message_fullname:
+			v = structToGo(m)
+		case genid.ListValue_message_fullname:
+			v = listValueToGo(m)
+		default:
+			v = valueToGo(m)
+		}
+		if v == nil {
+			dst.Set(reflect.Zero(t))
+			return true, nil
+		}
+		rv := reflect.ValueOf(v)
+		switch {
+		case rv.Type().AssignableTo(t):
+			dst.Set(rv)
+		case rv.Kind() == t.Kind() && rv.Type().ConvertibleTo(t):
+			dst.Set(rv.Convert(t))
+		default:
+			return true, newError(path, "cannot copy %v to %v", rv.Type(), t)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// structToGo returns the google.protobuf.Struct m as a map.
+func structToGo(m protoreflect.Message) map[string]interface{} {
+	fd := m.Descriptor().Fields().ByNumber(genid.Struct_Fields_field_number)
+	out := make(map[string]interface{})
+	m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		out[k.String()] = valueToGo(v.Message())
+		return true
+	})
+	return out
+}
+
+// listValueToGo returns the google.protobuf.ListValue m as a slice.
+func listValueToGo(m protoreflect.Message) []interface{} {
+	fd := m.Descriptor().Fields().ByNumber(genid.ListValue_Values_field_number)
+	list := m.Get(fd).List()
+	out := make([]interface{}, list.Len())
+	for i := range out {
+		out[i] = valueToGo(list.Get(i).Message())
+	}
+	return out
+}
+
+// valueToGo returns the google.protobuf.Value m as a Go value,
+// which is nil for a null or unset value.
+func valueToGo(m protoreflect.Message) interface{} {
+	fds := m.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+		switch fd.Number() {
+		case genid.Value_NumberValue_field_number:
+			return v.Float()
+		case genid.Value_StringValue_field_number:
+			return v.String()
+		case genid.Value_BoolValue_field_number:
+			return v.Bool()
+		case genid.Value_StructValue_field_number:
+			return structToGo(v.Message())
+		case genid.Value_ListValue_field_number:
+			return listValueToGo(v.Message())
+		}
+	}
+	return nil
+}
+
+// wellKnownFromGo copies src into the well-known type m if src is of the
+// Go type corresponding to it. It reports whether src was copied.
+func (c *converter) wellKnownFromGo(m protoreflect.Message, src reflect.Value, path string) (bool, error) {
+	md := m.Descriptor()
+	fds := md.Fields()
+	switch name := md.FullName(); {
+	case name == genid.Timestamp_message_fullname:
+		if src.Type() != timeType {
+			return false, nil
+		}
+		t := src.Interface().(time.Time)
+		m.Set(fds.ByNumber(genid.Timestamp_Seconds_field_number), protoreflect.ValueOfInt64(t.Unix()))
+		m.Set(fds.ByNumber(genid.Timestamp_Nanos_field_number), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		return true, nil
+	case name == genid.Duration_message_fullname:
+		if src.Type() != durationType {
+			return false, nil
+		}
+		d := time.Duration(src.Int())
+		m.Set(fds.ByNumber(genid.Duration_Seconds_field_number), protoreflect.ValueOfInt64(int64(d/time.Second)))
+		m.Set(fds.ByNumber(genid.Duration_Nanos_field_number), protoreflect.ValueOfInt32(int32(d%time.Second)))
+		return true, nil
+	case isWrapper(name):
+		if src.Kind() == reflect.Struct || src.Kind() == reflect.Map {
+			return false, nil
+		}
+		fd := fds.ByNumber(genid.WrapperValue_Value_field_number)
+		v, err := c.singularFromGo(fd, src, nil, path)
+		if err != nil {
+			return true, err
+		}
+		m.Set(fd, v)
+		return true, nil
+	case name == genid.Struct_message_fullname:
+		return true, c.structFromGo(m, src, path)
+	case name == genid.ListValue_message_fullname:
+		return true, c.listValueFromGo(m, src, path)
+	}
+	return false, nil
+}
+
+func (c *converter) structFromGo(m protoreflect.Message, src reflect.Value, path string) error {
+	if src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String {
+		return newError(path, "cannot copy %v to %v", src.Type(), m.Descriptor().FullName())
+	}
+	fd := m.Descriptor().Fields().ByNumber(genid.Struct_Fields_field_number)
+	mp := m.Mutable(fd).Map()
+	keys := src.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	for _, k := range keys {
+		v := mp.NewValue()
+		if err := c.valueFromGo(v.Message(), src.MapIndex(k), joinPath(path, k.String())); err != nil {
+			return err
+		}
+		mp.Set(protoreflect.ValueOfString(k.String()).MapKey(), v)
+	}
+	return nil
+}
+
+func (c *converter) listValueFromGo(m protoreflect.Message, src reflect.Value, path string) error {
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return newError(path, "cannot copy %v to %v", src.Type(), m.Descriptor().FullName())
+	}
+	fd := m.Descriptor().Fields().ByNumber(genid.ListValue_Values_field_number)
+	list := m.Mutable(fd).List()
+	for i := 0; i < src.Len(); i++ {
+		v := list.NewElement()
+		if err := c.valueFromGo(v.Message(), src.Index(i), path); err != nil {
+			return err
+		}
+		list.Append(v)
+	}
+	return nil
+}
+
+// valueFromGo copies src into the google.protobuf.Value m,
+// where a nil src is copied as a null value.
+func (c *converter) valueFromGo(m protoreflect.Message, src reflect.Value, path string) error {
+	fds := m.Descriptor().Fields()
+	src = indirect(src)
+	if !src.IsValid() {
+		m.Set(fds.ByNumber(genid.Value_NullValue_field_number), protoreflect.ValueOfEnum(0))
+		return nil
+	}
+	switch src.Kind() {
+	case reflect.Bool:
+		m.Set(fds.ByNumber(genid.Value_BoolValue_field_number), protoreflect.ValueOfBool(src.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		f, err := floatFromGo(src, path)
+		if err != nil {
+			return err
+		}
+		m.Set(fds.ByNumber(genid.Value_NumberValue_field_number), protoreflect.ValueOfFloat64(f))
+	case reflect.String:
+		m.Set(fds.ByNumber(genid.Value_StringValue_field_number), protoreflect.ValueOfString(src.String()))
+	case reflect.Map:
+		fd := fds.ByNumber(genid.Value_StructValue_field_number)
+		v := m.NewField(fd)
+		if err := c.structFromGo(v.Message(), src, path); err != nil {
+			return err
+		}
+		m.Set(fd, v)
+	case reflect.Slice, reflect.Array:
+		fd := fds.ByNumber(genid.Value_ListValue_field_number)
+		v := m.NewField(fd)
+		if err := c.listValueFromGo(v.Message(), src, path); err != nil {
+			return err
+		}
+		m.Set(fd, v)
+	default:
+		return newError(path, "cannot copy %v to %v", src.Type(), m.Descriptor().FullName())
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protounknown provides functions to inspect and manipulate
+// the unknown fields of a message.
+//
+// Unknown fields are stored by a message as a protoreflect.RawFields,
+// which is the raw wire encoding of the fields. The functions in this package
+// operate on RawFields values and never modify their input, so a typical
+// modification of a message reads, transforms, and stores the unknown fields:
+//
+//	m := msg.ProtoReflect()
+//	m.SetUnknown(protounknown.Delete(m.GetUnknown(), 5, 6))
+package protounknown
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Field is a single unknown field.
+type Field struct {
+	Number protoreflect.FieldNumber
+	Type   protowire.Type
+
+	// Raw is the wire encoding of the field, including the tag.
+	// For groups, it includes the end group marker.
+	Raw protoreflect.RawFields
+}
+
+// Value returns the wire encoding of the field value, excluding the tag.
+// For length-delimited fields, it excludes the length prefix, and
+// for groups, it excludes the end group marker.
+func (f Field) Value() []byte {
+	_, _, n := protowire.ConsumeTag(f.Raw)
+	b := f.Raw[n:]
+	switch f.Type {
+	case protowire.BytesType:
+		v, _ := protowire.ConsumeBytes(b)
+		return v
+	case protowire.StartGroupType:
+		v, _ := protowire.ConsumeGroup(f.Number, b)
+		return v
+	default:
+		return b
+	}
+}
+
+// Range calls f for each field in b in the order they appear.
+// If f returns false, Range stops the iteration.
+// Syntactically invalid data at the end of b is ignored.
+func Range(b protoreflect.RawFields, f func(Field) bool) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeField(b)
+		if n < 0 {
+			return
+		}
+		if !f(Field{Number: num, Type: typ, Raw: b[:n]}) {
+			return
+		}
+		b = b[n:]
+	}
+}
+
+// Fields returns all fields in b with the given field number,
+// in the order they appear.
+func Fields(b protoreflect.RawFields, num protoreflect.FieldNumber) []Field {
+	var fs []Field
+	Range(b, func(f Field) bool {
+		if f.Number == num {
+			fs = append(fs, f)
+		}
+		return true
+	})
+	return fs
+}
+
+// Has reports whether b contains a field with the given field number.
+func Has(b protoreflect.RawFields, num protoreflect.FieldNumber) bool {
+	var has bool
+	Range(b, func(f Field) bool {
+		has = f.Number == num
+		return !has
+	})
+	return has
+}
+
+// Delete returns a copy of b with all fields of the given field numbers removed.
+func Delete(b protoreflect.RawFields, nums ...protoreflect.FieldNumber) protoreflect.RawFields {
+	return Replace(b, nil, nums...)
+}
+
+// Replace returns a copy of b with all fields of the given field numbers
+// replaced by the fields in with. The replacement is placed at the position
+// of the first removed field, or at the end if no fields were removed.
+func Replace(b, with protoreflect.RawFields, nums ...protoreflect.FieldNumber) protoreflect.RawFields {
+	var out protoreflect.RawFields
+	replaced := false
+	for len(b) > 0 {
+		num, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			// Preserve invalid data as is.
+			out = append(out, b...)
+			break
+		}
+		switch {
+		case !containsNumber(nums, num):
+			out = append(out, b[:n]...)
+		case !replaced:
+			out = append(out, with...)
+			replaced = true
+		}
+		b = b[n:]
+	}
+	if !replaced {
+		out = append(out, with...)
+	}
+	return out
+}
+
+func containsNumber(nums []protoreflect.FieldNumber, num protoreflect.FieldNumber) bool {
+	for _, n := range nums {
+		if n == num {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode decodes all fields in b with the field number of fd as a value of
+// that field, such as an extension that was not registered when the message
+// was unmarshaled or a field added in a newer version of the message.
+// The fields are merged as they would be when unmarshaling the message,
+// so the last value of a scalar field is used and list fields are appended.
+// If b contains no such fields, it returns the zero value of the field.
+//
+// If fd is an extension and implements protoreflect.ExtensionTypeDescriptor,
+// values are decoded using its type. Otherwise, message values are
+// represented using dynamicpb.
+func Decode(b protoreflect.RawFields, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	var raw protoreflect.RawFields
+	for _, f := range Fields(b, fd.Number()) {
+		raw = append(raw, f.Raw...)
+	}
+	m := dynamicpb.NewMessage(fd.ContainingMessage())
+	opts := proto.UnmarshalOptions{AllowPartial: true}
+	if fd.IsExtension() {
+		xtd, ok := fd.(protoreflect.ExtensionTypeDescriptor)
+		if !ok {
+			xtd = dynamicpb.NewExtensionType(fd).TypeDescriptor()
+		}
+		r := new(protoregistry.Types)
+		if err := r.RegisterExtension(xtd.Type()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		opts.Resolver = r
+		fd = xtd
+	}
+	if err := opts.Unmarshal(raw, m); err != nil {
+		return protoreflect.Value{}, err
+	}
+	return m.Get(fd), nil
+}
+
+// Resolver is the resolver used to look up extension fields.
+// It is implemented by *protoregistry.Types.
+type Resolver interface {
+	FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
+	FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error)
+}
+
+// Promote re-parses the unknown fields of m and of all messages it contains,
+// moving any fields that are now known into the known fields of the message.
+// This is useful when an extension is registered after m was unmarshaled.
+// Fields that remain unknown are retained as unknown fields.
+// If r is nil, protoregistry.GlobalTypes is used.
+// If an error occurs, some messages within m may already be promoted,
+// but the message in which the error occurred is left unmodified.
+//
+// To promote fields after a schema upgrade, where a newer version of the
+// message declares fields that were previously unknown, use Upgrade.
+func Promote(m protoreflect.Message, r Resolver) error {
+	if r == nil {
+		r = protoregistry.GlobalTypes
+	}
+	return promote(m, r)
+}
+
+func promote(m protoreflect.Message, r Resolver) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i, list := 0, v.List(); i < list.Len() && err == nil; i++ {
+				err = promote(list.Get(i).Message(), r)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				err = promote(v.Message(), r)
+				return err == nil
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			err = promote(v.Message(), r)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	raw := m.GetUnknown()
+	if len(raw) == 0 {
+		return nil
+	}
+	// Unmarshal into a new message first so that m is unmodified on error.
+	m2 := m.New()
+	if err := (proto.UnmarshalOptions{AllowPartial: true, Resolver: r}).Unmarshal(raw, m2.Interface()); err != nil {
+		return err
+	}
+	m.SetUnknown(nil)
+	proto.Merge(m.Interface(), m2.Interface())
+	return nil
+}
+
+// Upgrade converts m to a message of type mt by marshaling m and unmarshaling
+// the result as mt, where mt is usually a newer version of the type of m.
+// Unknown fields of m that are declared by mt become known fields,
+// while known fields of m that are not declared by mt become unknown fields.
+func Upgrade(m proto.Message, mt protoreflect.MessageType) (proto.Message, error) {
+	b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	m2 := mt.New().Interface()
+	if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(b, m2); err != nil {
+		return nil, err
+	}
+	return m2, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protounknown_test
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/reflect/protounknown"
+	"google.golang.org/protobuf/testing/protopack"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestRange(t *testing.T) {
+	raw := protoreflect.RawFields(protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		protopack.Tag{2, protopack.BytesType}, protopack.String("two"),
+		protopack.Tag{3, protopack.StartGroupType},
+		protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(3),
+		protopack.Tag{3, protopack.EndGroupType},
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(4),
+	}.Marshal())
+
+	type field struct {
+		num   protoreflect.FieldNumber
+		typ   protowire.Type
+		value string
+	}
+	var got []field
+	protounknown.Range(raw, func(f protounknown.Field) bool {
+		got = append(got, field{f.Number, f.Type, string(f.Value())})
+		return true
+	})
+	want := []field{
+		{1, protowire.VarintType, "\x01"},
+		{2, protowire.BytesType, "two"},
+		{3, protowire.StartGroupType, "\x0d\x03\x00\x00\x00"},
+		{1, protowire.VarintType, "\x04"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Range() visited %d fields, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Range() field %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := protounknown.Fields(raw, 1); len(got) != 2 || string(got[1].Value()) != "\x04" {
+		t.Errorf("Fields(1) = %v, want two fields", got)
+	}
+	if !protounknown.Has(raw, 3) {
+		t.Errorf("Has(3) = false, want true")
+	}
+	if protounknown.Has(raw, 4) {
+		t.Errorf("Has(4) = true, want false")
+	}
+}
+
+func TestDeleteReplace(t *testing.T) {
+	raw := protoreflect.RawFields(protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		protopack.Tag{2, protopack.VarintType}, protopack.Varint(2),
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+		protopack.Tag{3, protopack.VarintType}, protopack.Varint(4),
+	}.Marshal())
+	orig := append(protoreflect.RawFields(nil), raw...)
+	with := protoreflect.RawFields(protopack.Message{
+		protopack.Tag{5, protopack.BytesType}, protopack.String("five"),
+	}.Marshal())
+
+	tests := []struct {
+		desc string
+		got  protoreflect.RawFields
+		want protopack.Message
+	}{{
+		desc: "delete",
+		got:  protounknown.Delete(raw, 1),
+		want: protopack.Message{
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(2),
+			protopack.Tag{3, protopack.VarintType}, protopack.Varint(4),
+		},
+	}, {
+		desc: "delete multiple",
+		got:  protounknown.Delete(raw, 1, 3, 100),
+		want: protopack.Message{
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(2),
+		},
+	}, {
+		desc: "replace",
+		got:  protounknown.Replace(raw, with, 2),
+		want: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{5, protopack.BytesType}, protopack.String("five"),
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+			protopack.Tag{3, protopack.VarintType}, protopack.Varint(4),
+		},
+	}, {
+		desc: "replace absent",
+		got:  protounknown.Replace(raw, with, 100),
+		want: protopack.Message{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			protopack.Tag{2, protopack.VarintType}, protopack.Varint(2),
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+			protopack.Tag{3, protopack.VarintType}, protopack.Varint(4),
+			protopack.Tag{5, protopack.BytesType}, protopack.String("five"),
+		},
+	}}
+	for _, tt := range tests {
+		if want := tt.want.Marshal(); !bytes.Equal(tt.got, want) {
+			t.Errorf("%s: got %x, want %x", tt.desc, []byte(tt.got), want)
+		}
+	}
+	if !bytes.Equal(raw, orig) {
+		t.Errorf("input was modified: got %x, want %x", []byte(raw), []byte(orig))
+	}
+}
+
+func TestDecode(t *testing.T) {
+	raw := protoreflect.RawFields(protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{1, protopack.VarintType}, protopack.Varint(5),
+		},
+		protopack.Tag{31, protopack.VarintType}, protopack.Varint(2),
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(3),
+		protopack.Tag{31, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Varint(4), protopack.Varint(5),
+		},
+	}.Marshal())
+
+	// Extension with a Go type.
+	v, err := protounknown.Decode(raw, testpb.E_OptionalInt32.TypeDescriptor())
+	if err != nil {
+		t.Fatalf("Decode(optional_int32) error: %v", err)
+	}
+	if got := v.Int(); got != 3 {
+		t.Errorf("Decode(optional_int32) = %v, want 3", got)
+	}
+	v, err = protounknown.Decode(raw, testpb.E_OptionalNestedMessage.TypeDescriptor())
+	if err != nil {
+		t.Fatalf("Decode(optional_nested_message) error: %v", err)
+	}
+	got, ok := v.Message().Interface().(*testpb.TestAllExtensions_NestedMessage)
+	if want := (&testpb.TestAllExtensions_NestedMessage{A: proto.Int32(5)}); !ok || !proto.Equal(got, want) {
+		t.Errorf("Decode(optional_nested_message) = %v, want %v", v.Message().Interface(), want)
+	}
+
+	// Extension without a Go type.
+	v, err = protounknown.Decode(raw, testpb.E_RepeatedInt32.TypeDescriptor().Descriptor())
+	if err != nil {
+		t.Fatalf("Decode(repeated_int32) error: %v", err)
+	}
+	if list := v.List(); list.Len() != 3 || list.Get(0).Int() != 2 || list.Get(2).Int() != 5 {
+		t.Errorf("Decode(repeated_int32) = %v, want [2 4 5]", list)
+	}
+
+	// Field of a message.
+	fd := (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor().Fields().ByName("optional_nested_message")
+	v, err = protounknown.Decode(raw, fd)
+	if err != nil {
+		t.Fatalf("Decode(optional_nested_message) error: %v", err)
+	}
+	if got := v.Message().Get(fd.Message().Fields().ByName("a")).Int(); got != 5 {
+		t.Errorf("Decode(optional_nested_message).a = %v, want 5", got)
+	}
+
+	// Absent field.
+	fd = (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor().Fields().ByName("default_int32")
+	v, err = protounknown.Decode(raw, fd)
+	if err != nil {
+		t.Fatalf("Decode(default_int32) error: %v", err)
+	}
+	if got := v.Int(); got != 81 {
+		t.Errorf("Decode(default_int32) = %v, want default of 81", got)
+	}
+}
+
+func TestPromote(t *testing.T) {
+	b := protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		protopack.Tag{1000, protopack.VarintType}, protopack.Varint(2),
+	}.Marshal()
+
+	// Unmarshal without knowledge of any extensions.
+	m := new(testpb.TestAllExtensions)
+	if err := (proto.UnmarshalOptions{Resolver: new(protoregistry.Types)}).Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	if proto.HasExtension(m, testpb.E_OptionalInt32) {
+		t.Fatalf("extension is unexpectedly known before promotion")
+	}
+
+	if err := protounknown.Promote(m.ProtoReflect(), nil); err != nil {
+		t.Fatalf("Promote() error: %v", err)
+	}
+	if got := proto.GetExtension(m, testpb.E_OptionalInt32).(int32); got != 1 {
+		t.Errorf("optional_int32 = %v, want 1", got)
+	}
+	want := protopack.Message{
+		protopack.Tag{1000, protopack.VarintType}, protopack.Varint(2),
+	}.Marshal()
+	if got := m.ProtoReflect().GetUnknown(); !bytes.Equal(got, want) {
+		t.Errorf("unknown fields after Promote() = %x, want %x", []byte(got), want)
+	}
+}
+
+func TestPromoteNested(t *testing.T) {
+	b := protopack.Message{
+		protopack.Tag{18, protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{
+				protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+			},
+		},
+	}.Marshal()
+
+	// Unmarshal with knowledge of only optional_nested_message,
+	// so that the extension in the nested message is unknown.
+	r := new(protoregistry.Types)
+	if err := r.RegisterExtension(testpb.E_OptionalNestedMessage); err != nil {
+		t.Fatal(err)
+	}
+	m := new(testpb.TestAllExtensions)
+	if err := (proto.UnmarshalOptions{Resolver: r}).Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	nested := proto.GetExtension(m, testpb.E_OptionalNestedMessage).(*testpb.TestAllExtensions_NestedMessage)
+	if len(nested.GetCorecursive().ProtoReflect().GetUnknown()) == 0 {
+		t.Fatalf("nested extension is unexpectedly known before promotion")
+	}
+
+	if err := r.RegisterExtension(testpb.E_OptionalInt32); err != nil {
+		t.Fatal(err)
+	}
+	if err := protounknown.Promote(m.ProtoReflect(), r); err != nil {
+		t.Fatalf("Promote() error: %v", err)
+	}
+	if got := proto.GetExtension(nested.GetCorecursive(), testpb.E_OptionalInt32).(int32); got != 1 {
+		t.Errorf("nested optional_int32 = %v, want 1", got)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	// FooRequest has no fields, so it stands in for an older version
+	// of a message which does not yet declare any fields.
+	old := new(testpb.FooRequest)
+	old.ProtoReflect().SetUnknown(protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(1),
+		protopack.Tag{14, protopack.BytesType}, protopack.String("hello"),
+		protopack.Tag{10000, protopack.VarintType}, protopack.Varint(2),
+	}.Marshal())
+
+	got, err := protounknown.Upgrade(old, (*testpb.TestAllTypes)(nil).ProtoReflect().Type())
+	if err != nil {
+		t.Fatalf("Upgrade() error: %v", err)
+	}
+	want := &testpb.TestAllTypes{
+		OptionalInt32:  proto.Int32(1),
+		OptionalString: proto.String("hello"),
+	}
+	want.ProtoReflect().SetUnknown(protopack.Message{
+		protopack.Tag{10000, protopack.VarintType}, protopack.Varint(2),
+	}.Marshal())
+	if !proto.Equal(got, want) {
+		t.Errorf("Upgrade() = %v, want %v", got, want)
+	}
+}
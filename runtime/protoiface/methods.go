@@ -37,8 +37,18 @@ type Methods = struct {
 	// Merge merges the contents of a source message into a destination message.
 	Merge func(MergeInput) MergeOutput
 
+	// Equal reports whether two messages of the same type are equal.
+	Equal func(EqualInput) EqualOutput
+
+	// Reset clears every field in a message.
+	Reset func(ResetInput) ResetOutput
+
 	// CheckInitialized returns an error if any required fields in the message are not set.
 	CheckInitialized func(CheckInitializedInput) (CheckInitializedOutput, error)
+
+	// Freeze makes a message read-only.
+	// It does not freeze the messages contained within it.
+	Freeze func(FreezeInput) FreezeOutput
 }
 
 // SupportFlags indicate support for optional features.
@@ -50,6 +60,12 @@ const (
 
 	// SupportUnmarshalDiscardUnknown reports whether UnmarshalOptions.DiscardUnknown is supported.
 	SupportUnmarshalDiscardUnknown
+
+	// SupportUnmarshalFieldSelector reports whether UnmarshalOptions.Fields is supported.
+	SupportUnmarshalFieldSelector
+
+	// SupportUnmarshalReset reports whether the UnmarshalReset flag is supported.
+	SupportUnmarshalReset
 )
 
 // SizeInput is input to the Size method.
@@ -90,6 +106,12 @@ type MarshalInputFlags = uint8
 const (
 	MarshalDeterministic MarshalInputFlags = 1 << iota
 	MarshalUseCachedSize
+
+	// MarshalReadOnly requests that the marshaler not modify the message,
+	// such as by recording sizes in a cache within it, so that the message
+	// may be marshaled concurrently with other reads. Cached sizes are
+	// neither used nor updated.
+	MarshalReadOnly
 )
 
 // UnmarshalInput is input to the Unmarshal method.
@@ -103,6 +125,16 @@ type UnmarshalInput = struct {
 		FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
 		FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error)
 	}
+
+	// FieldSelector, if non-nil, restricts unmarshaling to a subset of fields.
+	// Fields that are not selected are handled as if they were unknown.
+	// It is only set if the SupportUnmarshalFieldSelector flag is supported.
+	FieldSelector interface {
+		// SelectField reports whether the field numbered n is selected.
+		// For a selected field, sub is the selector for the fields of its
+		// message value, or nil if the entire value is selected.
+		SelectField(n protoreflect.FieldNumber) (sub interface{}, ok bool)
+	}
 }
 
 // UnmarshalOutput is output from the Unmarshal method.
@@ -118,6 +150,26 @@ type UnmarshalInputFlags = uint8
 
 const (
 	UnmarshalDiscardUnknown UnmarshalInputFlags = 1 << iota
+
+	// UnmarshalAliasBuffer permits the unmarshaler to retain references to
+	// the input buffer in the message, such as for the values of bytes and
+	// string fields, instead of copying them.
+	// Implementations which do not support aliasing may ignore it.
+	UnmarshalAliasBuffer
+
+	// UnmarshalRetainCapacity permits the unmarshaler to reuse messages left
+	// in the spare capacity of repeated message fields by a Reset with the
+	// ResetRetainCapacity flag, and with UnmarshalReset, the values of
+	// singular message fields.
+	// Implementations which do not reuse memory may ignore it.
+	UnmarshalRetainCapacity
+
+	// UnmarshalReset requests that the message be reset, retaining its
+	// memory as a Reset with the ResetRetainCapacity flag does, before
+	// unmarshaling into it. Unlike a separate call to Reset, this permits
+	// the unmarshaler to reuse the values of singular message fields.
+	// It is only set if the SupportUnmarshalReset flag is supported.
+	UnmarshalReset
 )
 
 // UnmarshalOutputFlags are output from the Unmarshal method.
@@ -154,6 +206,69 @@ const (
 	MergeComplete MergeOutputFlags = 1 << iota
 )
 
+// EqualInput is input to the Equal method.
+type EqualInput = struct {
+	pragma.NoUnkeyedLiterals
+
+	MessageA protoreflect.Message
+	MessageB protoreflect.Message
+}
+
+// EqualOutput is output from the Equal method.
+type EqualOutput = struct {
+	pragma.NoUnkeyedLiterals
+
+	Flags EqualOutputFlags
+}
+
+// EqualOutputFlags are output from the Equal method.
+type EqualOutputFlags = uint8
+
+const (
+	// EqualComplete reports whether the comparison was performed.
+	// If unset, the caller must compare the messages itself.
+	EqualComplete EqualOutputFlags = 1 << iota
+
+	// EqualEqual reports whether the messages are equal.
+	// It is only meaningful if EqualComplete is set.
+	EqualEqual
+)
+
+// ResetInput is input to the Reset method.
+type ResetInput = struct {
+	pragma.NoUnkeyedLiterals
+
+	Message protoreflect.Message
+	Flags   ResetInputFlags
+}
+
+// ResetOutput is output from the Reset method.
+type ResetOutput = struct {
+	pragma.NoUnkeyedLiterals
+
+	Flags ResetOutputFlags
+}
+
+// ResetInputFlags configure the reset behavior.
+// Most flags correspond to fields in proto.ResetOptions.
+type ResetInputFlags = uint8
+
+const (
+	// ResetRetainCapacity requests that memory allocated for the fields of the
+	// message be retained for reuse, such as the capacity of repeated fields
+	// and the entries of map fields.
+	ResetRetainCapacity ResetInputFlags = 1 << iota
+)
+
+// ResetOutputFlags are output from the Reset method.
+type ResetOutputFlags = uint8
+
+const (
+	// ResetComplete reports whether the reset was performed.
+	// If unset, the resetter must have made no changes to the message.
+	ResetComplete ResetOutputFlags = 1 << iota
+)
+
 // CheckInitializedInput is input to the CheckInitialized method.
 type CheckInitializedInput = struct {
 	pragma.NoUnkeyedLiterals
@@ -165,3 +280,26 @@ type CheckInitializedInput = struct {
 type CheckInitializedOutput = struct {
 	pragma.NoUnkeyedLiterals
 }
+
+// FreezeInput is input to the Freeze method.
+type FreezeInput = struct {
+	pragma.NoUnkeyedLiterals
+
+	Message protoreflect.Message
+}
+
+// FreezeOutput is output from the Freeze method.
+type FreezeOutput = struct {
+	pragma.NoUnkeyedLiterals
+
+	Flags FreezeOutputFlags
+}
+
+// FreezeOutputFlags are output from the Freeze method.
+type FreezeOutputFlags = uint8
+
+const (
+	// FreezeComplete reports whether the message is read-only.
+	// If unset, the message does not support freezing and is unchanged.
+	FreezeComplete FreezeOutputFlags = 1 << iota
+)
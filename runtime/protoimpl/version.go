@@ -18,6 +18,11 @@ const (
 	// in the runtime.
 	GenVersion = 20
 
+	// CodecVersion is the runtime version required by generated .pb.go files
+	// that contain specialized codec functions, which protoc-gen-go produces
+	// with the generate_codec option.
+	CodecVersion = 25
+
 	// MinVersion is the minimum supported version for generated .pb.go files.
 	// This is incremented when the runtime drops support for old code.
 	MinVersion = 0
@@ -49,8 +54,9 @@ const (
 type EnforceVersion uint
 
 // This enforces the following invariant:
-//	MinVersion ≤ GenVersion ≤ MaxVersion
+//	MinVersion ≤ GenVersion ≤ CodecVersion ≤ MaxVersion
 const (
 	_ = EnforceVersion(GenVersion - MinVersion)
-	_ = EnforceVersion(MaxVersion - GenVersion)
+	_ = EnforceVersion(CodecVersion - GenVersion)
+	_ = EnforceVersion(MaxVersion - CodecVersion)
 )
@@ -0,0 +1,181 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocmp
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EquateApprox treats float and double values as equal if they are within
+// a relative fraction or an absolute margin of each other.
+// That is, x and y are equal if |x-y| ≤ max(fraction*min(|x|, |y|), margin).
+// NaNs are equal to each other, while infinities are only equal to
+// infinities of the same sign. It panics if fraction or margin is
+// negative or NaN.
+//
+// It applies to singular fields, list fields, and map fields of float and
+// double values anywhere in the messages, where list fields must have the
+// same length and map fields must have the same keys. This includes the
+// number_value field of google.protobuf.Value, which holds the numbers in
+// google.protobuf.Struct and google.protobuf.ListValue messages.
+//
+// The tolerance can be limited to a specific field using FilterField
+// or to the fields of a specific message type using FilterMessageFields:
+//
+//	FilterField(new(structpb.Value), "number_value", EquateApprox(0, 1e-9))
+//
+// This must be used in conjunction with Transform.
+func EquateApprox(fraction, margin float64) cmp.Option {
+	if fraction < 0 || margin < 0 || math.IsNaN(fraction) || math.IsNaN(margin) {
+		panic("margin or fraction must be a non-negative number")
+	}
+	a := approximator{fraction, margin}
+	return cmp.FilterValues(isFloats, cmp.Comparer(a.compare))
+}
+
+type approximator struct {
+	fraction, margin float64
+}
+
+// isFloats reports whether x and y are both floats,
+// or lists or maps of floats of the same type.
+func isFloats(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if !vx.IsValid() || !vy.IsValid() || vx.Type() != vy.Type() {
+		return false
+	}
+	t := vx.Type()
+	if k := t.Kind(); k == reflect.Slice || k == reflect.Map {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+func (a approximator) compare(x, y interface{}) bool {
+	return a.equal(reflect.ValueOf(x), reflect.ValueOf(y))
+}
+
+func (a approximator) equal(vx, vy reflect.Value) bool {
+	switch vx.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return a.equalFloat(vx.Float(), vy.Float())
+	case reflect.Slice:
+		if vx.Len() != vy.Len() {
+			return false
+		}
+		for i := 0; i < vx.Len(); i++ {
+			if !a.equal(vx.Index(i), vy.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if vx.Len() != vy.Len() {
+			return false
+		}
+		for _, k := range vx.MapKeys() {
+			v := vy.MapIndex(k)
+			if !v.IsValid() || !a.equal(vx.MapIndex(k), v) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (a approximator) equalFloat(x, y float64) bool {
+	if equalFloat64(x, y) {
+		return true
+	}
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return false
+	}
+	d := math.Abs(x - y)
+	return d <= a.margin || d <= a.fraction*math.Min(math.Abs(x), math.Abs(y))
+}
+
+// EquateApproxTimestamps treats google.protobuf.Timestamp messages as equal
+// if the times they represent are within margin of each other.
+// Only the seconds and nanos fields are considered.
+// It panics if margin is negative.
+//
+// The tolerance can be limited to specific fields using FilterField
+// or FilterMessageFields.
+//
+// This must be used in conjunction with Transform.
+func EquateApproxTimestamps(margin time.Duration) cmp.Option {
+	return equateApproxTime(genid.Timestamp_message_fullname, margin)
+}
+
+// EquateApproxDurations treats google.protobuf.Duration messages as equal
+// if the durations they represent are within margin of each other.
+// Only the seconds and nanos fields are considered.
+// It panics if margin is negative.
+//
+// The tolerance can be limited to specific fields using FilterField
+// or FilterMessageFields.
+//
+// This must be used in conjunction with Transform.
+func EquateApproxDurations(margin time.Duration) cmp.Option {
+	return equateApproxTime(genid.Duration_message_fullname, margin)
+}
+
+func equateApproxTime(name protoreflect.FullName, margin time.Duration) cmp.Option {
+	if margin < 0 {
+		panic("margin must be a non-negative duration")
+	}
+	return cmp.FilterValues(func(x, y Message) bool {
+		return isMessageNamed(x, name) && isMessageNamed(y, name)
+	}, cmp.Comparer(func(x, y Message) bool {
+		sx, nx := secondsNanos(x)
+		sy, ny := secondsNanos(y)
+		return withinMargin(sx, nx, sy, ny, margin)
+	}))
+}
+
+func isMessageNamed(m Message, name protoreflect.FullName) bool {
+	if _, ok := m[messageInvalidKey]; ok {
+		return false
+	}
+	return m.Descriptor() != nil && m.Descriptor().FullName() == name
+}
+
+// secondsNanos returns the seconds and nanos fields of a Timestamp
+// or Duration message, which have the same field names.
+func secondsNanos(m Message) (int64, int32) {
+	secs, _ := m[string(genid.Timestamp_Seconds_field_name)].(int64)
+	nanos, _ := m[string(genid.Timestamp_Nanos_field_name)].(int32)
+	return secs, nanos
+}
+
+// withinMargin reports whether the difference between the times represented
+// by the seconds and nanos of x and y is at most margin.
+func withinMargin(sx int64, nx int32, sy int64, ny int32, margin time.Duration) bool {
+	s, n := sx-sy, int64(nx)-int64(ny)
+	if (s < 0) != (sx < sy) || s == math.MinInt64 {
+		return false // overflow
+	}
+	// Normalize the difference to have the same sign in both parts.
+	s, n = s+n/1e9, n%1e9
+	switch {
+	case s > 0 && n < 0:
+		s, n = s-1, n+1e9
+	case s < 0 && n > 0:
+		s, n = s+1, n-1e9
+	}
+	if s < 0 || n < 0 {
+		s, n = -s, -n
+	}
+	ms, mn := int64(margin/time.Second), int64(margin%time.Second)
+	return s < ms || (s == ms && n <= mn)
+}
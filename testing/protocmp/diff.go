@@ -0,0 +1,204 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Difference is a single difference between two messages.
+type Difference struct {
+	// Path is the path to the differing value relative to the root messages.
+	// Fields are separated by dots (e.g., "a.b.c"), list elements are indexed
+	// by position (e.g., "a[3]"), and map entries are indexed by their key
+	// (e.g., `m["key"]` or "m[5]"). Extension fields are written as their
+	// parenthesized full name (e.g., "a.(pkg.ext)") and unknown fields as
+	// their field number (e.g., "a.1000"). It is empty if the root messages
+	// themselves differ, such as when only one of them is nil.
+	Path string
+
+	// X and Y are the differing values in the first and second message,
+	// using the representation documented on the Message type.
+	// A value is nil if it is not present in that message.
+	X, Y interface{}
+}
+
+// Differences is a list of differences between two messages,
+// in the order that they were encountered.
+type Differences []Difference
+
+// Diff returns the differences between messages x and y.
+// It is empty if and only if cmp.Equal(x, y, Transform(), opts...) is true.
+//
+// The messages are compared using Transform, which must not be included in
+// opts. All other options in this package, such as IgnoreFields,
+// SortRepeated, and IgnoreDefaultScalars, may be used. The contents of
+// google.protobuf.Any messages are compared as messages if they can be
+// resolved, in which case paths descend into the "value" field.
+//
+// Unlike cmp.Diff, the result is intended to be consumed by programs.
+// See Differences.String and Differences.MarshalJSON for its renderings.
+func Diff(x, y proto.Message, opts ...cmp.Option) Differences {
+	r := new(diffReporter)
+	cmp.Equal(x, y, Transform(), cmp.Options(opts), cmp.Reporter(r))
+	return r.diffs
+}
+
+// String formats the differences with one difference per line in the form:
+//
+//   a.b[3].c: "old" -> "new"
+//   m["key"]: <absent> -> 5
+//
+// It is intended for human consumption and has no guarantees about its exact
+// format or the stability of its output.
+func (ds Differences) String() string {
+	var b bytes.Buffer
+	for _, d := range ds {
+		path := d.Path
+		if path == "" {
+			path = "<root>"
+		}
+		fmt.Fprintf(&b, "%s: %s -> %s\n", path, formatDiffValue(d.X), formatDiffValue(d.Y))
+	}
+	return b.String()
+}
+
+// MarshalJSON formats the differences as a JSON array of objects, one per
+// difference, with the following members:
+//
+//   "path": the Path of the difference
+//   "x":    the formatted value in the first message, omitted if absent
+//   "y":    the formatted value in the second message, omitted if absent
+//
+// Values are formatted as strings in the same way as by Differences.String.
+func (ds Differences) MarshalJSON() ([]byte, error) {
+	type jsonDiff struct {
+		Path string  `json:"path"`
+		X    *string `json:"x,omitempty"`
+		Y    *string `json:"y,omitempty"`
+	}
+	jds := make([]jsonDiff, 0, len(ds))
+	for _, d := range ds {
+		jd := jsonDiff{Path: d.Path}
+		if d.X != nil {
+			s := formatDiffValue(d.X)
+			jd.X = &s
+		}
+		if d.Y != nil {
+			s := formatDiffValue(d.Y)
+			jd.Y = &s
+		}
+		jds = append(jds, jd)
+	}
+	return json.Marshal(jds)
+}
+
+func formatDiffValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "<absent>"
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return strconv.Quote(string(v))
+	case protoreflect.RawFields:
+		return strconv.Quote(string(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// diffReporter is a cmp.Reporter that records differences
+// by their protobuf field path.
+type diffReporter struct {
+	steps []cmp.PathStep
+	diffs Differences
+}
+
+func (r *diffReporter) PushStep(ps cmp.PathStep) {
+	r.steps = append(r.steps, ps)
+}
+
+func (r *diffReporter) PopStep() {
+	r.steps = r.steps[:len(r.steps)-1]
+}
+
+func (r *diffReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	path, vx, vy := r.current()
+	// Differences within bytes are reported once for the entire value.
+	if n := len(r.diffs); n > 0 && r.diffs[n-1].Path == path {
+		return
+	}
+	r.diffs = append(r.diffs, Difference{Path: path, X: diffValue(vx), Y: diffValue(vy)})
+}
+
+// current returns the field path and values of the current node.
+func (r *diffReporter) current() (path string, vx, vy reflect.Value) {
+	var b bytes.Buffer
+	for i, ps := range r.steps {
+		switch ps := ps.(type) {
+		case cmp.MapIndex:
+			if r.steps[i-1].Type() != messageReflectType {
+				k := ps.Key()
+				if k.Kind() == reflect.String {
+					fmt.Fprintf(&b, "[%q]", k.String())
+				} else {
+					fmt.Fprintf(&b, "[%v]", k.Interface())
+				}
+				break
+			}
+			k := ps.Key().String()
+			if k == messageTypeKey || k == messageInvalidKey {
+				// A difference in message type or validity is reported
+				// for the message as a whole.
+				vx, vy = r.steps[i-1].Values()
+				return b.String(), vx, vy
+			}
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			if strings.HasPrefix(k, "[") && strings.HasSuffix(k, "]") {
+				k = "(" + k[len("["):len(k)-len("]")] + ")"
+			}
+			b.WriteString(k)
+		case cmp.SliceIndex:
+			if ps.Type().Kind() == reflect.Uint8 {
+				// Report bytes and unknown fields as a whole.
+				vx, vy = r.steps[i-1].Values()
+				return b.String(), vx, vy
+			}
+			ix, iy := ps.SplitKeys()
+			if ix < 0 {
+				ix = iy
+			}
+			fmt.Fprintf(&b, "[%d]", ix)
+		}
+	}
+	vx, vy = r.steps[len(r.steps)-1].Values()
+	return b.String(), vx, vy
+}
+
+func diffValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if (v.Kind() == reflect.Interface || v.Kind() == reflect.Map) && v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,200 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocmp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestDiff(t *testing.T) {
+	anyOf := func(m proto.Message) *anypb.Any {
+		a, err := anypb.New(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	extOf := func(v int32) *testpb.TestAllExtensions {
+		m := new(testpb.TestAllExtensions)
+		proto.SetExtension(m, testpb.E_OptionalInt32, v)
+		return m
+	}
+	unknownOf := func(v uint64) *testpb.TestAllTypes {
+		m := new(testpb.TestAllTypes)
+		m.ProtoReflect().SetUnknown(protopack.Message{
+			protopack.Tag{1000, protopack.VarintType}, protopack.Uvarint(v),
+		}.Marshal())
+		return m
+	}
+
+	tests := []struct {
+		desc string
+		x, y proto.Message
+		opts []cmp.Option
+		want []string // paths of the differences
+	}{{
+		desc: "equal",
+		x:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)},
+		y:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)},
+	}, {
+		desc: "nil message",
+		x:    (*testpb.TestAllTypes)(nil),
+		y:    new(testpb.TestAllTypes),
+		want: []string{""},
+	}, {
+		desc: "scalars",
+		x:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(1), OptionalString: proto.String("a")},
+		y:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(2), OptionalBytes: []byte("abc")},
+		want: []string{"optional_bytes", "optional_int32", "optional_string"},
+	}, {
+		desc: "bytes are reported whole",
+		x:    &testpb.TestAllTypes{OptionalBytes: []byte("abc")},
+		y:    &testpb.TestAllTypes{OptionalBytes: []byte("xyz")},
+		want: []string{"optional_bytes"},
+	}, {
+		desc: "nested message",
+		x:    &testpb.TestAllTypes{OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(1)}},
+		y: &testpb.TestAllTypes{OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(5)},
+		}},
+		want: []string{"optional_nested_message.a", "optional_nested_message.corecursive"},
+	}, {
+		desc: "lists",
+		x: &testpb.TestAllTypes{
+			RepeatedInt32:         []int32{1, 2, 3},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(1)}},
+		},
+		y: &testpb.TestAllTypes{
+			RepeatedInt32:         []int32{1, 5, 3},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(2)}},
+		},
+		want: []string{"repeated_int32[1]", "repeated_nested_message[0].a"},
+	}, {
+		desc: "sorted lists",
+		x:    &testpb.TestAllTypes{RepeatedInt32: []int32{3, 2, 1}},
+		y:    &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+		opts: []cmp.Option{SortRepeated(func(x, y int32) bool { return x < y })},
+	}, {
+		desc: "maps",
+		x: &testpb.TestAllTypes{
+			MapStringString:        map[string]string{"a": "1", "b": "2"},
+			MapInt32Int32:          map[int32]int32{5: 1},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{"m": {A: proto.Int32(1)}},
+		},
+		y: &testpb.TestAllTypes{
+			MapStringString:        map[string]string{"a": "1", "c.d": "3"},
+			MapInt32Int32:          map[int32]int32{5: 2},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{"m": {A: proto.Int32(2)}},
+		},
+		want: []string{"map_int32_int32[5]", `map_string_nested_message["m"].a`, `map_string_string["b"]`, `map_string_string["c.d"]`},
+	}, {
+		desc: "oneofs",
+		x:    &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofUint32{1}},
+		y:    &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofString{"1"}},
+		want: []string{"oneof_string", "oneof_uint32"},
+	}, {
+		desc: "extensions",
+		x:    extOf(1),
+		y:    extOf(2),
+		want: []string{"(goproto.proto.test.optional_int32)"},
+	}, {
+		desc: "unknown fields",
+		x:    unknownOf(1),
+		y:    unknownOf(2),
+		want: []string{"1000"},
+	}, {
+		desc: "any contents",
+		x:    anyOf(&testpb.TestAllTypes{OptionalInt32: proto.Int32(1)}),
+		y:    anyOf(&testpb.TestAllTypes{OptionalInt32: proto.Int32(2)}),
+		want: []string{"value.optional_int32"},
+	}, {
+		desc: "any types",
+		x:    anyOf(&testpb.TestAllTypes{}),
+		y:    anyOf(&testpb.TestAllExtensions{}),
+		want: []string{"type_url", "value"},
+	}, {
+		desc: "ignored fields",
+		x:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(1), OptionalString: proto.String("a")},
+		y:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(2), OptionalString: proto.String("b")},
+		opts: []cmp.Option{IgnoreFields(new(testpb.TestAllTypes), "optional_int32")},
+		want: []string{"optional_string"},
+	}, {
+		desc: "default scalars",
+		x:    &testpb.TestAllTypes{OptionalInt32: proto.Int32(0)},
+		y:    &testpb.TestAllTypes{},
+		opts: []cmp.Option{IgnoreDefaultScalars()},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ds := Diff(tt.x, tt.y, tt.opts...)
+			var got []string
+			for _, d := range ds {
+				got = append(got, d.Path)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Diff() paths mismatch (-want +got):\n%s\n%v", diff, ds)
+			}
+			if equal := cmp.Equal(tt.x, tt.y, append(tt.opts, Transform())...); equal != (len(ds) == 0) {
+				t.Errorf("cmp.Equal() = %v, but Diff() = %v", equal, ds)
+			}
+		})
+	}
+}
+
+func TestDiffRendering(t *testing.T) {
+	x := &testpb.TestAllTypes{
+		OptionalInt32:   proto.Int32(1),
+		OptionalString:  proto.String("a"),
+		MapStringString: map[string]string{"k": "v"},
+	}
+	y := &testpb.TestAllTypes{
+		OptionalInt32:  proto.Int32(2),
+		OptionalString: proto.String("a"),
+		RepeatedInt32:  []int32{7},
+	}
+	ds := Diff(x, y)
+
+	wantText := `map_string_string: map[k:v] -> <absent>
+optional_int32: 1 -> 2
+repeated_int32: <absent> -> [7]
+`
+	if got := ds.String(); got != wantText {
+		t.Errorf("String() = %q, want %q", got, wantText)
+	}
+
+	b, err := json.Marshal(ds)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	var got []map[string]string
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	want := []map[string]string{
+		{"path": "map_string_string", "x": "map[k:v]"},
+		{"path": "optional_int32", "x": "1", "y": "2"},
+		{"path": "repeated_int32", "y": "[7]"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MarshalJSON() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := Diff(x, x).String(); got != "" {
+		t.Errorf("Diff(x, x).String() = %q, want empty", got)
+	}
+	if d := Diff(x, y)[1]; d.X != int32(1) || d.Y != int32(2) {
+		t.Errorf("Diff()[1] values = (%v, %v), want (1, 2)", d.X, d.Y)
+	}
+}
@@ -86,6 +86,47 @@ func FilterOneof(message proto.Message, name protoreflect.Name, opt cmp.Option)
 	return FilterDescriptor(mustFindOneofDescriptor(md, name), opt)
 }
 
+// FilterMessageFields filters opt to only be applicable on the fields
+// of messages that are the same type as the specified message,
+// including extension and unknown fields.
+// Unlike FilterMessage, it applies to the field values within the message
+// rather than the message itself, and does not apply to fields of
+// nested messages of a different type.
+//
+// The Go type of the last path step may be an:
+//	• T for singular fields
+//	• []T for list fields
+//	• map[K]T for map fields
+//	• interface{} for a Message map entry value
+//
+// This must be used in conjunction with Transform.
+func FilterMessageFields(message proto.Message, opt cmp.Option) cmp.Option {
+	name := message.ProtoReflect().Descriptor().FullName()
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		// Trim off trailing type-assertions so that the filter can match on
+		// the concrete value held within an interface value.
+		if _, ok := p.Last().(cmp.TypeAssertion); ok {
+			p = p[:len(p)-1]
+		}
+
+		// Filter for Message maps.
+		if _, ok := p.Index(-1).(cmp.MapIndex); !ok {
+			return false
+		}
+		ps := p.Index(-2)
+		if ps.Type() != messageReflectType {
+			return false
+		}
+
+		// Check message type.
+		vx, vy := ps.Values()
+		mx := vx.Interface().(Message)
+		my := vy.Interface().(Message)
+		return mx.Descriptor() != nil && mx.Descriptor().FullName() == name &&
+			my.Descriptor() != nil && my.Descriptor().FullName() == name
+	}, opt)
+}
+
 // FilterDescriptor ignores the specified descriptor.
 //
 // The following descriptor types may be specified:
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -16,6 +17,9 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protopack"
 	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
 )
@@ -1283,6 +1287,127 @@ func TestEqual(t *testing.T) {
 		want: true,
 	}}...)
 
+	// Test EquateApprox.
+	tests = append(tests, []test{{
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.0)},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.05)},
+		opts: cmp.Options{Transform()},
+		want: false,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.0)},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.05)},
+		opts: cmp.Options{Transform(), EquateApprox(0, 0.1)},
+		want: true,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.0)},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.05)},
+		opts: cmp.Options{Transform(), EquateApprox(0.01, 0)},
+		want: false,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalFloat: proto.Float32(1000), OptionalDouble: proto.Float64(1000)},
+		y:    &testpb.TestAllTypes{OptionalFloat: proto.Float32(1001), OptionalDouble: proto.Float64(1001)},
+		opts: cmp.Options{Transform(), EquateApprox(0.01, 0)},
+		want: true,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.NaN())},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.NaN())},
+		opts: cmp.Options{Transform(), EquateApprox(0, 0.1)},
+		want: true,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.Inf(+1))},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.MaxFloat64)},
+		opts: cmp.Options{Transform(), EquateApprox(1, 1)},
+		want: false,
+	}, {
+		x: &testpb.TestAllTypes{
+			RepeatedFloat:  []float32{1, 2, 3},
+			MapInt32Double: map[int32]float64{1: 1, 2: 2},
+		},
+		y: &testpb.TestAllTypes{
+			RepeatedFloat:  []float32{1.01, 2.01, 3.01},
+			MapInt32Double: map[int32]float64{1: 1.01, 2: 2.01},
+		},
+		opts: cmp.Options{Transform(), EquateApprox(0, 0.1)},
+		want: true,
+	}, {
+		x:    &testpb.TestAllTypes{RepeatedFloat: []float32{1, 2, 3}},
+		y:    &testpb.TestAllTypes{RepeatedFloat: []float32{1, 2}},
+		opts: cmp.Options{Transform(), EquateApprox(0, 10)},
+		want: false,
+	}, {
+		x:    &testpb.TestAllTypes{MapInt32Double: map[int32]float64{1: 1}},
+		y:    &testpb.TestAllTypes{MapInt32Double: map[int32]float64{2: 1}},
+		opts: cmp.Options{Transform(), EquateApprox(0, 10)},
+		want: false,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalFloat: proto.Float32(1), OptionalDouble: proto.Float64(1)},
+		y:    &testpb.TestAllTypes{OptionalFloat: proto.Float32(1.01), OptionalDouble: proto.Float64(1.01)},
+		opts: cmp.Options{Transform(), FilterField(new(testpb.TestAllTypes), "optional_float", EquateApprox(0, 0.1))},
+		want: false,
+	}, {
+		x:    &testpb.TestAllTypes{OptionalFloat: proto.Float32(1), OptionalDouble: proto.Float64(1)},
+		y:    &testpb.TestAllTypes{OptionalFloat: proto.Float32(1.01), OptionalDouble: proto.Float64(1.01)},
+		opts: cmp.Options{Transform(), FilterMessageFields(new(testpb.TestAllTypes), EquateApprox(0, 0.1))},
+		want: true,
+	}, {
+		x: &testpb.TestAllTypes{OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			Corecursive: &testpb.TestAllTypes{OptionalDouble: proto.Float64(1)},
+		}},
+		y: &testpb.TestAllTypes{OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			Corecursive: &testpb.TestAllTypes{OptionalDouble: proto.Float64(1.01)},
+		}},
+		opts: cmp.Options{Transform(), FilterMessageFields(new(testpb.TestAllTypes_NestedMessage), EquateApprox(0, 0.1))},
+		want: false,
+	}, {
+		x:    mustStruct(t, map[string]interface{}{"a": 1.0, "b": []interface{}{2.0, "x"}}),
+		y:    mustStruct(t, map[string]interface{}{"a": 1.001, "b": []interface{}{2.001, "x"}}),
+		opts: cmp.Options{Transform()},
+		want: false,
+	}, {
+		x:    mustStruct(t, map[string]interface{}{"a": 1.0, "b": []interface{}{2.0, "x"}}),
+		y:    mustStruct(t, map[string]interface{}{"a": 1.001, "b": []interface{}{2.001, "x"}}),
+		opts: cmp.Options{Transform(), FilterField(new(structpb.Value), "number_value", EquateApprox(0, 0.01))},
+		want: true,
+	}}...)
+
+	// Test EquateApproxTimestamps and EquateApproxDurations.
+	tests = append(tests, []test{{
+		x:    &timestamppb.Timestamp{Seconds: 10, Nanos: 900000000},
+		y:    &timestamppb.Timestamp{Seconds: 11, Nanos: 100000000},
+		opts: cmp.Options{Transform()},
+		want: false,
+	}, {
+		x:    &timestamppb.Timestamp{Seconds: 10, Nanos: 900000000},
+		y:    &timestamppb.Timestamp{Seconds: 11, Nanos: 100000000},
+		opts: cmp.Options{Transform(), EquateApproxTimestamps(200 * time.Millisecond)},
+		want: true,
+	}, {
+		x:    &timestamppb.Timestamp{Seconds: 11, Nanos: 100000000},
+		y:    &timestamppb.Timestamp{Seconds: 10, Nanos: 900000000},
+		opts: cmp.Options{Transform(), EquateApproxTimestamps(199 * time.Millisecond)},
+		want: false,
+	}, {
+		x:    &timestamppb.Timestamp{Seconds: 100},
+		y:    &timestamppb.Timestamp{Seconds: 102, Nanos: 1},
+		opts: cmp.Options{Transform(), EquateApproxTimestamps(2 * time.Second)},
+		want: false,
+	}, {
+		x:    &timestamppb.Timestamp{Seconds: math.MaxInt64},
+		y:    &timestamppb.Timestamp{Seconds: math.MinInt64},
+		opts: cmp.Options{Transform(), EquateApproxTimestamps(time.Second)},
+		want: false,
+	}, {
+		x:    &durationpb.Duration{Seconds: -1, Nanos: -500000000},
+		y:    &durationpb.Duration{Seconds: -2},
+		opts: cmp.Options{Transform(), EquateApproxDurations(time.Second / 2)},
+		want: true,
+	}, {
+		x:    &durationpb.Duration{Seconds: 1},
+		y:    &durationpb.Duration{Seconds: 1, Nanos: 1},
+		opts: cmp.Options{Transform(), EquateApproxTimestamps(time.Second)},
+		want: false,
+	}}...)
+
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			got := cmp.Equal(tt.x, tt.y, tt.opts)
@@ -1297,6 +1422,14 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func mustStruct(t *testing.T, v map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
 type setField struct {
 	num protoreflect.FieldNumber
 	val interface{}
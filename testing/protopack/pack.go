@@ -495,11 +495,11 @@ func (m Message) Format(s fmt.State, r rune) {
 	case 'v':
 		switch {
 		case s.Flag('#'):
-			io.WriteString(s, m.format(true, true))
+			io.WriteString(s, m.format(true, true, nil))
 		case s.Flag('+'):
-			io.WriteString(s, m.format(false, true))
+			io.WriteString(s, m.format(false, true, nil))
 		default:
-			io.WriteString(s, m.format(false, false))
+			io.WriteString(s, m.format(false, false, nil))
 		}
 	default:
 		panic("invalid verb: " + string(r))
@@ -509,9 +509,12 @@ func (m Message) Format(s fmt.State, r rune) {
 // format formats the message.
 // If source is enabled, this emits valid Go source.
 // If multi is enabled, the output may span multiple lines.
-func (m Message) format(source, multi bool) string {
+// If desc is non-nil and multi is enabled, each known field is preceded
+// by a comment with the field name.
+func (m Message) format(source, multi bool, desc protoreflect.MessageDescriptor) string {
 	var ss []string
 	var prefix, nextPrefix string
+	var fd protoreflect.FieldDescriptor // field of the last tag
 	for _, v := range m {
 		// Ensure certain tokens have preceding or succeeding newlines.
 		prefix, nextPrefix = nextPrefix, " "
@@ -528,7 +531,22 @@ func (m Message) format(source, multi bool) string {
 			}
 		}
 
-		s := formatToken(v, source, multi)
+		// Annotate the start of each known field with its name,
+		// and use the field's message descriptor for its contents.
+		var subDesc protoreflect.MessageDescriptor
+		if tag, ok := formatTag(v); ok && desc != nil && multi {
+			fd = nil
+			if tag.Type != EndGroupType {
+				fd = desc.Fields().ByNumber(tag.Number)
+			}
+			if fd != nil {
+				prefix += "// " + string(fd.Name()) + "\n"
+			}
+		} else if fd != nil {
+			subDesc = fd.Message()
+		}
+
+		s := formatToken(v, source, multi, subDesc)
 		ss = append(ss, prefix+s+",")
 	}
 
@@ -548,15 +566,25 @@ func (m Message) format(source, multi bool) string {
 	return s
 }
 
+// formatTag returns the Tag of a possibly denormalized tag token.
+func formatTag(t Token) (Tag, bool) {
+	if v, ok := t.(Denormalized); ok {
+		t = v.Value
+	}
+	tag, ok := t.(Tag)
+	return tag, ok
+}
+
 // formatToken formats a single token.
-func formatToken(t Token, source, multi bool) (s string) {
+// The desc is used to annotate the fields of a message token.
+func formatToken(t Token, source, multi bool, desc protoreflect.MessageDescriptor) (s string) {
 	switch v := t.(type) {
 	case Message:
-		s = v.format(source, multi)
+		s = v.format(source, multi, desc)
 	case LengthPrefix:
 		s = formatPacked(v, source, multi)
 		if s == "" {
-			ms := Message(v).format(source, multi)
+			ms := Message(v).format(source, multi, desc)
 			s = fmt.Sprintf("%T(%s)", v, ms)
 		}
 	case Tag:
@@ -596,7 +624,7 @@ func formatToken(t Token, source, multi bool) (s string) {
 		s = fmt.Sprintf("%s", v)
 		s = fmt.Sprintf("%T(%s)", v, formatString(s))
 	case Denormalized:
-		s = fmt.Sprintf("%T{+%d, %v}", v, v.Count, formatToken(v.Value, source, multi))
+		s = fmt.Sprintf("%T{+%d, %v}", v, v.Count, formatToken(v.Value, source, multi, desc))
 	default:
 		panic(fmt.Sprintf("unknown type: %T", v))
 	}
@@ -620,7 +648,7 @@ func formatPacked(v LengthPrefix, source, multi bool) string {
 					return ""
 				}
 			}
-			ss = append(ss, formatToken(v, source, multi))
+			ss = append(ss, formatToken(v, source, multi, nil))
 		default:
 			return ""
 		}
@@ -9,9 +9,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"google.golang.org/protobuf/encoding/prototext"
 	pdesc "google.golang.org/protobuf/reflect/protodesc"
@@ -328,6 +330,14 @@ func TestPack(t *testing.T) {
 			return math.Float64bits(float64(x)) == math.Float64bits(float64(y))
 		}),
 	}
+	equateFloats := cmp.Options{
+		cmp.Comparer(func(x, y Float32) bool {
+			return x == y || (math.IsNaN(float64(x)) && math.IsNaN(float64(y)))
+		}),
+		cmp.Comparer(func(x, y Float64) bool {
+			return x == y || (math.IsNaN(float64(x)) && math.IsNaN(float64(y)))
+		}),
+	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			var msg Message
@@ -361,6 +371,136 @@ func TestPack(t *testing.T) {
 					t.Errorf("fmt.Sprintf(%q, msg):\ngot:  %s\nwant: %s", "%#v", gotOut, tt.wantOutSource)
 				}
 			}
+			for _, verb := range []string{"%v", "%+v", "%#v"} {
+				s := fmt.Sprintf(verb, tt.msg)
+				got, err := Parse(s)
+				if err != nil {
+					t.Errorf("Parse(fmt.Sprintf(%q, msg)) error: %v\n%s", verb, err, s)
+					continue
+				}
+				// Formatting without the "#" flag does not preserve NaN payloads.
+				opts := equateFloatBits
+				if verb != "%#v" {
+					opts = equateFloats
+				}
+				if !cmp.Equal(got, tt.msg, opts, cmpopts.EquateEmpty()) {
+					t.Errorf("Parse(fmt.Sprintf(%q, msg)) mismatch:\ngot  %+v\nwant %+v", verb, got, tt.msg)
+				}
+			}
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Message
+		wantErr string
+	}{{
+		in:   `Message{}`,
+		want: Message{},
+	}, {
+		in: `
+			// A comment.
+			Message{
+				Tag{1, Bytes}, String("Hello, world!"),
+				Tag{2, Varint}, Varint(-10), /* another comment */
+				protopack.Tag{3, protopack.BytesType}, LengthPrefix{Float32(1.5), Float32(-Inf), Float32(math.Inf(+1))},
+				Tag{4, Bytes}, LengthPrefix(Message{
+					Tag{1, Varint}, Denormalized{+2, Uvarint(5)},
+				}),
+				Tag{5, StartGroup},
+				Message{Tag{1, Fixed64}, Float64(math.Float64frombits(0x7ff8000000000001))},
+				Tag{5, EndGroup},
+				Tag{6, Fixed32}, Int32(-1), Uint32(0xffffffff),
+				Tag{7, Type(6)}, Raw("\xff"), Bytes(` + "`raw`" + `),
+			}`,
+		want: Message{
+			Tag{1, BytesType}, String("Hello, world!"),
+			Tag{2, VarintType}, Varint(-10),
+			Tag{3, BytesType}, LengthPrefix{Float32(1.5), Float32(math.Inf(-1)), Float32(math.Inf(+1))},
+			Tag{4, BytesType}, LengthPrefix(Message{
+				Tag{1, VarintType}, Denormalized{2, Uvarint(5)},
+			}),
+			Tag{5, StartGroupType},
+			Message{Tag{1, Fixed64Type}, Float64(math.Float64frombits(0x7ff8000000000001))},
+			Tag{5, EndGroupType},
+			Tag{6, Fixed32Type}, Int32(-1), Uint32(math.MaxUint32),
+			Tag{7, Type(6)}, Raw("\xff"), Bytes("raw"),
+		},
+	}, {
+		in:      `Message{Tag{1, Varint}, Uvarint(-1)}`,
+		wantErr: "want number",
+	}, {
+		in:      `Message{Int32(4294967296)}`,
+		wantErr: "invalid 32-bit integer",
+	}, {
+		in:      `Message{Tag{1, Bogus}}`,
+		wantErr: `unknown wire type "Bogus"`,
+	}, {
+		in:      `Message{Unknown(1)}`,
+		wantErr: `unknown token type "Unknown"`,
+	}, {
+		in:      `Message{String("unterminated)}`,
+		wantErr: "line 1",
+	}, {
+		in:      `Message{} Message{}`,
+		wantErr: "after message",
+	}}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		switch {
+		case tt.wantErr != "":
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want error containing %q", tt.in, err, tt.wantErr)
+			}
+		case err != nil:
+			t.Errorf("Parse(%q) error: %v", tt.in, err)
+		case !bytes.Equal(got.Marshal(), tt.want.Marshal()):
+			t.Errorf("Parse(%q) mismatch:\ngot  %+v\nwant %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	raw := Message{
+		Tag{11, BytesType}, String("hello"),
+		Tag{13, BytesType}, LengthPrefix(Message{
+			Tag{4, VarintType}, Uvarint(5),
+			Tag{100, VarintType}, Uvarint(6),
+		}),
+		Tag{14, StartGroupType},
+		Message{Tag{1, VarintType}, Uvarint(7)},
+		Tag{14, EndGroupType},
+	}.Marshal()
+
+	got := Disassemble(raw, msgDesc)
+	want := `Message{
+	// f11
+	Tag{11, Bytes}, String("hello"),
+	// f13
+	Tag{13, Bytes}, LengthPrefix(Message{
+		// f4
+		Tag{4, Varint}, Uvarint(5),
+		Tag{100, Varint}, Uvarint(6),
+	}),
+	// f14
+	Tag{14, StartGroup},
+	Message{
+		Tag{1, Varint}, Uvarint(7),
+	},
+	Tag{14, EndGroup},
+}`
+	if got != want {
+		t.Errorf("Disassemble() mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	m, err := Parse(got)
+	if err != nil {
+		t.Fatalf("Parse(Disassemble()) error: %v", err)
+	}
+	if !bytes.Equal(m.Marshal(), raw) {
+		t.Errorf("Parse(Disassemble()).Marshal() = %x, want %x", m.Marshal(), raw)
+	}
+}
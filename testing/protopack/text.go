@@ -0,0 +1,390 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protopack
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Parse parses the textual form of a syntax tree, which is the form produced
+// by formatting a Message with the "%v", "%+v", or "%#v" verbs or by
+// Disassemble. This permits wire data to be stored in a readable form,
+// such as in golden test files.
+//
+// Example textual form:
+//	Message{
+//		// A comment.
+//		Tag{1, Bytes}, String("Hello, world!"),
+//		Tag{2, Varint}, Varint(-10),
+//		Tag{3, Bytes}, LengthPrefix{Float32(1.1), Float32(2.2), Float32(3.3)},
+//		Tag{4, Bytes}, LengthPrefix(Message{
+//			Tag{1, Varint}, Denormalized{+2, Uvarint(5)},
+//		}),
+//		Raw("\xff"),
+//	}
+//
+// Identifiers may be qualified with the package name (e.g., "protopack.Tag")
+// and wire types may have a "Type" suffix (e.g., "protopack.BytesType"),
+// as in Go source. Floating-point values may be written as in Go source
+// (e.g., "math.Inf(+1)" or "math.Float32frombits(0x7fc00001)") or as
+// formatted by the fmt package (e.g., "+Inf" or "NaN").
+// Line and block comments are ignored.
+//
+// Malformed wire data may be represented using the Denormalized and Raw
+// tokens, or by writing a Tag and length prefix (as a Uvarint) followed by
+// content of a different length.
+func Parse(s string) (m Message, err error) {
+	p := new(textParser)
+	p.s.Init(strings.NewReader(s))
+	p.s.Mode = scanner.GoTokens
+	p.s.Error = func(s *scanner.Scanner, msg string) {
+		p.fail("%s", msg)
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			te, ok := e.(textError)
+			if !ok {
+				panic(e)
+			}
+			m, err = nil, te
+		}
+	}()
+	p.next()
+	p.expectIdent("Message")
+	m = p.parseMessage()
+	if p.tok != scanner.EOF {
+		p.fail("unexpected %s after message", p.text())
+	}
+	return m, nil
+}
+
+type textError struct {
+	pos scanner.Position
+	msg string
+}
+
+func (e textError) Error() string {
+	return fmt.Sprintf("protopack: syntax error (line %d:%d): %s", e.pos.Line, e.pos.Column, e.msg)
+}
+
+type textParser struct {
+	s   scanner.Scanner
+	tok rune
+}
+
+func (p *textParser) fail(f string, x ...interface{}) {
+	panic(textError{p.s.Position, fmt.Sprintf(f, x...)})
+}
+
+func (p *textParser) next() {
+	p.tok = p.s.Scan()
+}
+
+func (p *textParser) text() string {
+	if p.tok == scanner.EOF {
+		return "end of input"
+	}
+	return strconv.Quote(p.s.TokenText())
+}
+
+func (p *textParser) expect(tok rune) {
+	if p.tok != tok {
+		p.fail("got %s, want %q", p.text(), tok)
+	}
+	p.next()
+}
+
+// ident parses an identifier, ignoring any package qualifier.
+func (p *textParser) ident() string {
+	if p.tok != scanner.Ident {
+		p.fail("got %s, want identifier", p.text())
+	}
+	s := p.s.TokenText()
+	p.next()
+	if p.tok == '.' && (s == pkg || s == "math") {
+		p.next()
+		return p.ident()
+	}
+	return s
+}
+
+func (p *textParser) expectIdent(want string) {
+	if got := p.ident(); got != want {
+		p.fail("got %q, want %q", got, want)
+	}
+}
+
+// parseMessage parses the remainder of a Message after its name.
+func (p *textParser) parseMessage() Message {
+	p.expect('{')
+	return p.parseTokens('}')
+}
+
+// parseTokens parses a comma-separated list of tokens up to and
+// including the given terminator.
+func (p *textParser) parseTokens(end rune) []Token {
+	var toks []Token
+	for p.tok != end {
+		toks = append(toks, p.parseToken())
+		if p.tok != ',' {
+			break
+		}
+		p.next()
+	}
+	p.expect(end)
+	return toks
+}
+
+func (p *textParser) parseToken() Token {
+	switch name := p.ident(); name {
+	case "Message":
+		return p.parseMessage()
+	case "Tag":
+		p.expect('{')
+		num := p.parseUint(32)
+		p.expect(',')
+		typ := p.parseType()
+		p.expect('}')
+		return Tag{Number(num), typ}
+	case "Bool":
+		p.expect('(')
+		var v bool
+		switch s := p.ident(); s {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		default:
+			p.fail("got %q, want boolean", s)
+		}
+		p.expect(')')
+		return Bool(v)
+	case "Varint", "Svarint", "Int32", "Int64":
+		p.expect('(')
+		bits := 64
+		if name == "Int32" {
+			bits = 32
+		}
+		v := p.parseInt(bits)
+		p.expect(')')
+		switch name {
+		case "Varint":
+			return Varint(v)
+		case "Svarint":
+			return Svarint(v)
+		case "Int32":
+			return Int32(v)
+		default:
+			return Int64(v)
+		}
+	case "Uvarint", "Uint32", "Uint64":
+		p.expect('(')
+		bits := 64
+		if name == "Uint32" {
+			bits = 32
+		}
+		v := p.parseUint(bits)
+		p.expect(')')
+		switch name {
+		case "Uvarint":
+			return Uvarint(v)
+		case "Uint32":
+			return Uint32(v)
+		default:
+			return Uint64(v)
+		}
+	case "Float32":
+		p.expect('(')
+		v := p.parseFloat(32)
+		p.expect(')')
+		return Float32(v)
+	case "Float64":
+		p.expect('(')
+		v := p.parseFloat(64)
+		p.expect(')')
+		return Float64(v)
+	case "String", "Bytes", "Raw":
+		p.expect('(')
+		v := p.parseString()
+		p.expect(')')
+		switch name {
+		case "String":
+			return String(v)
+		case "Bytes":
+			return Bytes(v)
+		default:
+			return Raw(v)
+		}
+	case "LengthPrefix":
+		if p.tok == '(' {
+			p.next()
+			p.expectIdent("Message")
+			m := p.parseMessage()
+			p.expect(')')
+			return LengthPrefix(m)
+		}
+		p.expect('{')
+		return LengthPrefix(p.parseTokens('}'))
+	case "Denormalized":
+		p.expect('{')
+		if p.tok == '+' {
+			p.next()
+		}
+		n := p.parseUint(32)
+		p.expect(',')
+		v := p.parseToken()
+		p.expect('}')
+		return Denormalized{uint(n), v}
+	default:
+		p.fail("unknown token type %q", name)
+		panic("unreachable")
+	}
+}
+
+// parseType parses a wire type.
+func (p *textParser) parseType() Type {
+	name := p.ident()
+	if name == "Type" {
+		p.expect('(')
+		v := p.parseInt(8)
+		p.expect(')')
+		return Type(v)
+	}
+	switch strings.TrimSuffix(name, "Type") {
+	case "Varint":
+		return VarintType
+	case "Fixed32":
+		return Fixed32Type
+	case "Fixed64":
+		return Fixed64Type
+	case "Bytes":
+		return BytesType
+	case "StartGroup":
+		return StartGroupType
+	case "EndGroup":
+		return EndGroupType
+	}
+	p.fail("unknown wire type %q", name)
+	panic("unreachable")
+}
+
+// sign parses an optional sign and reports whether it is negative.
+func (p *textParser) sign() bool {
+	switch p.tok {
+	case '-':
+		p.next()
+		return true
+	case '+':
+		p.next()
+	}
+	return false
+}
+
+func (p *textParser) number() string {
+	if p.tok != scanner.Int && p.tok != scanner.Float {
+		p.fail("got %s, want number", p.text())
+	}
+	s := p.s.TokenText()
+	p.next()
+	return s
+}
+
+func (p *textParser) parseInt(bits int) int64 {
+	neg := p.sign()
+	s := p.number()
+	if neg {
+		s = "-" + s
+	}
+	v, err := strconv.ParseInt(s, 0, bits)
+	if err != nil {
+		p.fail("invalid %d-bit integer %q", bits, s)
+	}
+	return v
+}
+
+func (p *textParser) parseUint(bits int) uint64 {
+	s := p.number()
+	v, err := strconv.ParseUint(s, 0, bits)
+	if err != nil {
+		p.fail("invalid %d-bit unsigned integer %q", bits, s)
+	}
+	return v
+}
+
+func (p *textParser) parseFloat(bits int) float64 {
+	neg := p.sign()
+	var v float64
+	if p.tok == scanner.Ident {
+		name := p.ident()
+		switch name {
+		case "Inf":
+			v = math.Inf(+1)
+		case "NaN":
+			v = math.NaN()
+		case "Float32frombits", "Float64frombits":
+			p.expect('(')
+			u := p.parseUint(bits)
+			p.expect(')')
+			if bits == 32 {
+				v = float64(math.Float32frombits(uint32(u)))
+			} else {
+				v = math.Float64frombits(u)
+			}
+		default:
+			p.fail("invalid floating-point value %q", name)
+		}
+		// Handle the function forms of infinity and NaN from the math package.
+		if (name == "Inf" || name == "NaN") && p.tok == '(' {
+			p.next()
+			if name == "Inf" {
+				if p.sign() {
+					v = math.Inf(-1)
+				}
+				p.parseUint(8)
+			}
+			p.expect(')')
+		}
+	} else {
+		s := p.number()
+		var err error
+		v, err = strconv.ParseFloat(s, bits)
+		if err != nil {
+			p.fail("invalid %d-bit floating-point number %q", bits, s)
+		}
+	}
+	if neg {
+		v = -v
+	}
+	return v
+}
+
+func (p *textParser) parseString() string {
+	if p.tok != scanner.String && p.tok != scanner.RawString {
+		p.fail("got %s, want string", p.text())
+	}
+	s, err := strconv.Unquote(p.s.TokenText())
+	if err != nil {
+		p.fail("invalid string %s", p.text())
+	}
+	p.next()
+	return s
+}
+
+// Disassemble parses the input protobuf wire data as a syntax tree using the
+// provided message descriptor, as with Message.UnmarshalDescriptor, and
+// returns it in the multi-line textual form accepted by Parse.
+// Each known field is preceded by a comment with the field name.
+// The descriptor may be nil, in which case no fields are known.
+func Disassemble(in []byte, desc protoreflect.MessageDescriptor) string {
+	var m Message
+	m.UnmarshalDescriptor(in, desc)
+	return m.format(false, true, desc)
+}
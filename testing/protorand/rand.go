@@ -0,0 +1,380 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protorand generates random messages for property-based testing.
+//
+// The generated messages are valid: required fields are populated, enum
+// fields with closed enums only hold declared values, at most one field in a
+// oneof is populated, and strings are valid UTF-8. As such, a generated
+// message can be round-tripped through any of the encodings provided by
+// this module, except where a well-known type places further restrictions
+// on its JSON representation (e.g., the range of a google.protobuf.Timestamp)
+// or where Options.UnknownFields is set. Unknown fields are only preserved by
+// the wire format; protojson and prototext do not parse them back.
+//
+// Floating-point fields are never populated with NaN, which does not compare
+// equal to itself under most equality functions other than proto.Equal.
+//
+// For example, a property test of a marshal and unmarshal round-trip:
+//
+//   for seed := int64(0); seed < 100; seed++ {
+//       m := protorand.Options{Seed: seed}.New(mt)
+//       b, err := proto.Marshal(m)
+//       ...
+//   }
+package protorand
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/encoding/messageset"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	defaultMaxDepth   = 3
+	defaultMaxListLen = 4
+	defaultMaxMapLen  = 4
+	maxStringLen      = 16
+
+	// requiredDepthLimit bounds the depth of required message fields
+	// beyond MaxDepth, in case the required fields form a cycle.
+	requiredDepthLimit = 100
+)
+
+// Options configures the generation of random messages.
+// The zero value generates messages using the default settings.
+type Options struct {
+	// Seed is the seed for the random number generator.
+	// Generating a message of the same type with the same options
+	// always produces the same message.
+	Seed int64
+
+	// MaxDepth is the maximum depth of nested messages, where the
+	// message being generated is at depth zero. Message fields below this
+	// depth are only populated if they are required.
+	// If zero, a default of 3 is used. If negative, no message fields
+	// other than required ones are populated.
+	MaxDepth int
+
+	// MaxListLen and MaxMapLen are the maximum number of elements in
+	// repeated and map fields, respectively.
+	// If zero, a default of 4 is used. If negative, such fields are not populated.
+	MaxListLen int
+	MaxMapLen  int
+
+	// Extensions specifies whether to populate extension fields.
+	Extensions bool
+
+	// Resolver is used to determine the list of extension fields
+	// to populate when Extensions is set.
+	// If nil, this defaults to using protoregistry.GlobalTypes.
+	Resolver interface {
+		RangeExtensionsByMessage(message protoreflect.FullName, f func(protoreflect.ExtensionType) bool)
+	}
+
+	// UnknownFields specifies whether to add random unknown fields.
+	// The unknown fields never use a field number that is declared in the
+	// message or is within one of its extension ranges.
+	// Since protojson and prototext do not preserve unknown fields,
+	// such messages only round-trip through the wire format.
+	UnknownFields bool
+}
+
+// New returns a new message of type mt populated with random values.
+func (o Options) New(mt protoreflect.MessageType) proto.Message {
+	m := mt.New()
+	o.fill(m)
+	return m.Interface()
+}
+
+// Fill populates m with random values. Fields already populated in m
+// may be overwritten or appended to.
+func (o Options) Fill(m proto.Message) {
+	o.fill(m.ProtoReflect())
+}
+
+func (o Options) fill(m protoreflect.Message) {
+	g := &generator{
+		opts:       o,
+		rand:       rand.New(rand.NewSource(o.Seed)),
+		maxDepth:   limit(o.MaxDepth, defaultMaxDepth),
+		maxListLen: limit(o.MaxListLen, defaultMaxListLen),
+		maxMapLen:  limit(o.MaxMapLen, defaultMaxMapLen),
+	}
+	if o.Extensions {
+		g.resolver = o.Resolver
+		if g.resolver == nil {
+			g.resolver = protoregistry.GlobalTypes
+		}
+	}
+	g.message(m, 0)
+}
+
+func limit(n, def int) int {
+	switch {
+	case n == 0:
+		return def
+	case n < 0:
+		return 0
+	default:
+		return n
+	}
+}
+
+type generator struct {
+	opts     Options
+	rand     *rand.Rand
+	resolver interface {
+		RangeExtensionsByMessage(message protoreflect.FullName, f func(protoreflect.ExtensionType) bool)
+	}
+	maxDepth   int
+	maxListLen int
+	maxMapLen  int
+}
+
+// chance reports true with a probability of one in n.
+func (g *generator) chance(n int) bool {
+	return g.rand.Intn(n) == 0
+}
+
+// message populates the fields of m, which is at the given depth.
+func (g *generator) message(m protoreflect.Message, depth int) {
+	md := m.Descriptor()
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			continue
+		}
+		if fd.Cardinality() != protoreflect.Required && g.chance(2) {
+			continue
+		}
+		g.field(m, fd, depth)
+	}
+
+	ods := md.Oneofs()
+	for i := 0; i < ods.Len(); i++ {
+		od := ods.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		if n := g.rand.Intn(od.Fields().Len() + 1); n < od.Fields().Len() {
+			g.field(m, od.Fields().Get(n), depth)
+		}
+	}
+
+	if g.resolver != nil {
+		var xts []protoreflect.ExtensionType
+		g.resolver.RangeExtensionsByMessage(md.FullName(), func(xt protoreflect.ExtensionType) bool {
+			xts = append(xts, xt)
+			return true
+		})
+		// The resolver may range over extensions in any order.
+		sort.Slice(xts, func(i, j int) bool {
+			return xts[i].TypeDescriptor().Number() < xts[j].TypeDescriptor().Number()
+		})
+		for _, xt := range xts {
+			if g.chance(2) {
+				continue
+			}
+			g.field(m, xt.TypeDescriptor(), depth)
+		}
+	}
+
+	if g.opts.UnknownFields && !messageset.IsMessageSet(md) && g.chance(2) {
+		g.unknown(m)
+	}
+}
+
+// field populates the field fd of m, which is at the given depth.
+func (g *generator) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, depth int) {
+	if fd.IsWeak() {
+		return
+	}
+	if hasMessage(fd) && fd.Cardinality() != protoreflect.Required && depth >= g.maxDepth {
+		return
+	}
+	if fd.Cardinality() == protoreflect.Required && depth >= g.maxDepth+requiredDepthLimit {
+		return
+	}
+	switch {
+	case fd.IsList():
+		list := m.Mutable(fd).List()
+		for n := g.rand.Intn(g.maxListLen + 1); n > 0; n-- {
+			if isMessage(fd) {
+				v := list.NewElement()
+				g.message(v.Message(), depth+1)
+				list.Append(v)
+			} else {
+				list.Append(g.scalar(fd))
+			}
+		}
+	case fd.IsMap():
+		mapv := m.Mutable(fd).Map()
+		kd, vd := fd.MapKey(), fd.MapValue()
+		for n := g.rand.Intn(g.maxMapLen + 1); n > 0; n-- {
+			k := g.scalar(kd).MapKey()
+			if isMessage(vd) {
+				v := mapv.NewValue()
+				g.message(v.Message(), depth+1)
+				mapv.Set(k, v)
+			} else {
+				mapv.Set(k, g.scalar(vd))
+			}
+		}
+	case isMessage(fd):
+		v := m.NewField(fd)
+		g.message(v.Message(), depth+1)
+		m.Set(fd, v)
+	default:
+		m.Set(fd, g.scalar(fd))
+	}
+}
+
+// hasMessage reports whether the values of fd are messages,
+// where the values of a map field are the map values.
+func hasMessage(fd protoreflect.FieldDescriptor) bool {
+	if fd.IsMap() {
+		return isMessage(fd.MapValue())
+	}
+	return isMessage(fd)
+}
+
+func isMessage(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind
+}
+
+// scalar returns a random value for a non-message field.
+func (g *generator) scalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(g.chance(2))
+	case protoreflect.EnumKind:
+		ed := fd.Enum()
+		// Values of an open enum need not be declared,
+		// while values of a closed enum must be.
+		if ed.ParentFile().Syntax() != protoreflect.Proto2 && g.chance(8) {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(g.int32()))
+		}
+		vals := ed.Values()
+		return protoreflect.ValueOfEnum(vals.Get(g.rand.Intn(vals.Len())).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(g.int32())
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(g.int64())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(g.int32()))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(g.int64()))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(g.float(math.MaxFloat32, math.SmallestNonzeroFloat32)))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(g.float(math.MaxFloat64, math.SmallestNonzeroFloat64))
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(g.string())
+	case protoreflect.BytesKind:
+		b := make([]byte, g.rand.Intn(maxStringLen+1))
+		g.rand.Read(b)
+		return protoreflect.ValueOfBytes(b)
+	}
+	panic("invalid kind: " + fd.Kind().String())
+}
+
+// int32 and int64 return either a small value near zero
+// or a value from the full range of the type.
+func (g *generator) int32() int32 {
+	if g.chance(2) {
+		return int32(g.rand.Intn(201) - 100)
+	}
+	return int32(g.rand.Uint32())
+}
+
+func (g *generator) int64() int64 {
+	if g.chance(2) {
+		return int64(g.rand.Intn(201) - 100)
+	}
+	return int64(g.rand.Uint64())
+}
+
+// float returns a finite value, an infinity, or one of the given extremes.
+func (g *generator) float(max, min float64) float64 {
+	if g.chance(8) {
+		switch g.rand.Intn(6) {
+		case 0:
+			return math.Inf(+1)
+		case 1:
+			return math.Inf(-1)
+		case 2:
+			return max
+		case 3:
+			return -max
+		case 4:
+			return min
+		default:
+			return math.Copysign(0, -1)
+		}
+	}
+	return g.rand.NormFloat64() * math.Pow(10, float64(g.rand.Intn(9)-4))
+}
+
+// string returns a valid UTF-8 string that is mostly ASCII.
+func (g *generator) string() string {
+	var b []byte
+	for n := g.rand.Intn(maxStringLen + 1); n > 0; n-- {
+		var r rune
+		switch {
+		case g.chance(4):
+			r = rune(g.rand.Intn(utf8.MaxRune + 1))
+			if !utf8.ValidRune(r) {
+				r = utf8.RuneError
+			}
+		default:
+			r = rune(' ' + g.rand.Intn('~'-' '+1))
+		}
+		var buf [utf8.UTFMax]byte
+		b = append(b, buf[:utf8.EncodeRune(buf[:], r)]...)
+	}
+	return string(b)
+}
+
+// unknown appends between one and three unknown fields to m.
+func (g *generator) unknown(m protoreflect.Message) {
+	md := m.Descriptor()
+	b := m.GetUnknown()
+	for n := 1 + g.rand.Intn(3); n > 0; n-- {
+		num := protowire.Number(1 + g.rand.Int31n(int32(protowire.MaxValidNumber)))
+		switch {
+		case num >= protowire.FirstReservedNumber && num <= protowire.LastReservedNumber:
+			continue
+		case md.Fields().ByNumber(num) != nil:
+			continue
+		case md.ExtensionRanges().Has(num):
+			continue
+		}
+		switch g.rand.Intn(4) {
+		case 0:
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, uint64(g.int64()))
+		case 1:
+			b = protowire.AppendTag(b, num, protowire.Fixed32Type)
+			b = protowire.AppendFixed32(b, g.rand.Uint32())
+		case 2:
+			b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+			b = protowire.AppendFixed64(b, g.rand.Uint64())
+		default:
+			v := make([]byte, g.rand.Intn(maxStringLen+1))
+			g.rand.Read(v)
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendBytes(b, v)
+		}
+	}
+	m.SetUnknown(b)
+}
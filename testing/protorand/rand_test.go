@@ -0,0 +1,132 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protorand_test
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protorand"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+var messageTypes = []protoreflect.MessageType{
+	(*testpb.TestAllTypes)(nil).ProtoReflect().Type(),
+	(*test3pb.TestAllTypes)(nil).ProtoReflect().Type(),
+	(*testpb.TestRequired)(nil).ProtoReflect().Type(),
+	(*testpb.TestRequiredForeign)(nil).ProtoReflect().Type(),
+	(*testpb.TestAllExtensions)(nil).ProtoReflect().Type(),
+	dynamicpb.NewMessageType((*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor()),
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, mt := range messageTypes {
+		t.Run(fmt.Sprintf("%v", mt.Descriptor().FullName()), func(t *testing.T) {
+			for seed := int64(0); seed < 50; seed++ {
+				opts := protorand.Options{
+					Seed:          seed,
+					Extensions:    true,
+					UnknownFields: true,
+				}
+				m := opts.New(mt)
+				if err := proto.CheckInitialized(m); err != nil {
+					t.Fatalf("seed %v: CheckInitialized() error: %v", seed, err)
+				}
+				b, err := proto.Marshal(m)
+				if err != nil {
+					t.Fatalf("seed %v: Marshal() error: %v", seed, err)
+				}
+				got := mt.New().Interface()
+				if err := proto.Unmarshal(b, got); err != nil {
+					t.Fatalf("seed %v: Unmarshal() error: %v", seed, err)
+				}
+				if !proto.Equal(got, m) {
+					t.Fatalf("seed %v: round-trip mismatch:\ngot:  %v\nwant: %v", seed, got, m)
+				}
+
+				// The text format discards unknown fields.
+				opts.UnknownFields = false
+				m = opts.New(mt)
+				b, err = prototext.Marshal(m)
+				if err != nil {
+					t.Fatalf("seed %v: prototext.Marshal() error: %v", seed, err)
+				}
+				got = mt.New().Interface()
+				if err := prototext.Unmarshal(b, got); err != nil {
+					t.Fatalf("seed %v: prototext.Unmarshal() error: %v", seed, err)
+				}
+				if !proto.Equal(got, m) {
+					t.Fatalf("seed %v: prototext round-trip mismatch:\ngot:  %v\nwant: %v", seed, got, m)
+				}
+			}
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	mt := (*testpb.TestAllTypes)(nil).ProtoReflect().Type()
+	m1 := protorand.Options{Seed: 1}.New(mt)
+	if m2 := (protorand.Options{Seed: 1}).New(mt); !proto.Equal(m1, m2) {
+		t.Errorf("messages with the same seed differ:\n%v\n%v", m1, m2)
+	}
+	if m2 := (protorand.Options{Seed: 2}).New(mt); proto.Equal(m1, m2) {
+		t.Errorf("messages with different seeds are equal:\n%v", m1)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	mt := (*testpb.TestAllTypes)(nil).ProtoReflect().Type()
+	for seed := int64(0); seed < 20; seed++ {
+		m := protorand.Options{Seed: seed, MaxDepth: 2, MaxListLen: 2, MaxMapLen: -1}.New(mt)
+		walk(m.ProtoReflect(), 0, func(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value, depth int) {
+			switch {
+			case depth > 2:
+				t.Errorf("seed %v: message at depth %v, want at most 2", seed, depth)
+			case fd.IsMap():
+				t.Errorf("seed %v: populated map field %v", seed, fd.FullName())
+			case fd.IsList() && v.List().Len() > 2:
+				t.Errorf("seed %v: list field %v has length %v, want at most 2", seed, fd.FullName(), v.List().Len())
+			case fd.IsExtension():
+				t.Errorf("seed %v: populated extension field %v", seed, fd.FullName())
+			}
+			if len(m.GetUnknown()) > 0 {
+				t.Errorf("seed %v: populated unknown fields", seed)
+			}
+		})
+	}
+
+	// Required fields are always populated.
+	m := protorand.Options{MaxDepth: -1}.New((*testpb.TestRequiredForeign)(nil).ProtoReflect().Type())
+	if err := proto.CheckInitialized(m); err != nil {
+		t.Errorf("CheckInitialized() error: %v", err)
+	}
+}
+
+// walk calls f for every populated field in m and its sub-messages.
+func walk(m protoreflect.Message, depth int, f func(protoreflect.Message, protoreflect.FieldDescriptor, protoreflect.Value, int)) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		f(m, fd, v, depth)
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i := 0; i < v.List().Len(); i++ {
+				walk(v.List().Get(i).Message(), depth+1, f)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				walk(v.Message(), depth+1, f)
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			walk(v.Message(), depth+1, f)
+		}
+		return true
+	})
+}
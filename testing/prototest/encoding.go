@@ -0,0 +1,258 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prototest
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	pref "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protorand"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Encoding tests the serialization of a message type.
+//
+// For each sample message, it verifies that the message round-trips through
+// the wire, JSON, and text formats; that Size agrees with the length of the
+// marshaled output; that deterministic marshaling is stable; that Clone and
+// Merge are consistent with Equal and with concatenating wire data; and that
+// the fast-path methods of the message agree with the reflective slow path,
+// as implemented by a dynamicpb.Message of the same descriptor.
+//
+// The JSON and text formats do not preserve unknown fields,
+// so they are discarded before comparing the round-tripped messages.
+type Encoding struct {
+	// Resolver is used to look up extension and message types when
+	// unmarshaling and to determine the list of extension fields to populate
+	// in random messages.
+	// If nil, this defaults to using protoregistry.GlobalTypes.
+	Resolver interface {
+		FindMessageByName(message pref.FullName) (pref.MessageType, error)
+		FindMessageByURL(url string) (pref.MessageType, error)
+		FindExtensionByName(field pref.FullName) (pref.ExtensionType, error)
+		FindExtensionByNumber(message pref.FullName, field pref.FieldNumber) (pref.ExtensionType, error)
+		RangeExtensionsByMessage(message pref.FullName, f func(pref.ExtensionType) bool)
+	}
+
+	// Messages are sample messages to test, which must be of the tested type.
+	Messages []proto.Message
+
+	// Random is the number of randomly generated messages to test in addition
+	// to Messages, which are created using the protorand package.
+	// If zero and Messages is empty, a default of 20 is used.
+	//
+	// A random message that cannot be marshaled in the JSON format, such as
+	// one with a google.protobuf.Any holding an unresolvable type URL,
+	// is not tested in that format.
+	Random int
+}
+
+// Test performs tests on the serialization of a MessageType implementation.
+func (test Encoding) Test(t testing.TB, mt pref.MessageType) {
+	if test.Resolver == nil {
+		test.Resolver = protoregistry.GlobalTypes
+	}
+	for i, m := range test.Messages {
+		if got := m.ProtoReflect().Descriptor().FullName(); got != mt.Descriptor().FullName() {
+			t.Errorf("Messages[%d] has type %v, want %v", i, got, mt.Descriptor().FullName())
+			continue
+		}
+		test.testMessage(t, mt, m, false)
+	}
+	n := test.Random
+	if n == 0 && len(test.Messages) == 0 {
+		n = 20
+	}
+	for seed := 0; seed < n; seed++ {
+		m := protorand.Options{
+			Seed:          int64(seed),
+			Extensions:    true,
+			Resolver:      test.Resolver,
+			UnknownFields: true,
+		}.New(mt)
+		test.testMessage(t, mt, m, true)
+	}
+}
+
+func (test Encoding) testMessage(t testing.TB, mt pref.MessageType, m proto.Message, random bool) {
+	b := test.testWire(t, mt, m)
+	if b == nil {
+		return
+	}
+	test.testCloneMerge(t, mt, m, b)
+	test.testSlowPath(t, mt, m, b)
+	test.testText(t, mt, m)
+	test.testJSON(t, mt, m, random)
+}
+
+// testWire tests the wire format and returns the deterministic marshaling
+// of m, or nil if it could not be marshaled.
+func (test Encoding) testWire(t testing.TB, mt pref.MessageType, m proto.Message) []byte {
+	b, err := proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(m)
+	if err != nil {
+		t.Errorf("Marshal() = %v, want nil\n%v", err, prototext.Format(m))
+		return nil
+	}
+	if size := (proto.MarshalOptions{AllowPartial: true}).Size(m); size != len(b) {
+		t.Errorf("Size() = %v, want %v\n%v", size, len(b), prototext.Format(m))
+	}
+	if b2, _ := (proto.MarshalOptions{AllowPartial: true, Deterministic: true}).Marshal(m); !bytes.Equal(b, b2) {
+		t.Errorf("deterministic Marshal() is not stable\n%v", prototext.Format(m))
+	}
+	m2 := mt.New().Interface()
+	if err := test.unmarshal(b, m2); err != nil {
+		t.Errorf("Unmarshal() = %v, want nil\n%v", err, prototext.Format(m))
+		return b
+	}
+	if !proto.Equal(m, m2) {
+		t.Errorf("round-trip marshal/unmarshal did not preserve message\nOriginal:\n%v\nNew:\n%v", prototext.Format(m), prototext.Format(m2))
+	}
+	return b
+}
+
+// testCloneMerge tests that Clone and Merge are consistent with Equal
+// and with the wire format, where b is the marshaling of m.
+func (test Encoding) testCloneMerge(t testing.TB, mt pref.MessageType, m proto.Message, b []byte) {
+	c := proto.Clone(m)
+	if !proto.Equal(m, c) {
+		t.Errorf("Clone() did not preserve message\nOriginal:\n%v\nClone:\n%v", prototext.Format(m), prototext.Format(c))
+	}
+	if b2, _ := (proto.MarshalOptions{AllowPartial: true, Deterministic: true}).Marshal(c); !bytes.Equal(b, b2) {
+		t.Errorf("deterministic Marshal() of Clone() differs from original\n%v", prototext.Format(m))
+	}
+
+	merged := mt.New().Interface()
+	proto.Merge(merged, m)
+	if !proto.Equal(m, merged) {
+		t.Errorf("Merge() into empty message did not preserve message\nOriginal:\n%v\nMerged:\n%v", prototext.Format(m), prototext.Format(merged))
+	}
+
+	// Merging a message into itself must match unmarshaling
+	// the concatenation of its wire data.
+	proto.Merge(merged, m)
+	want := mt.New().Interface()
+	if err := test.unmarshal(append(append([]byte(nil), b...), b...), want); err != nil {
+		t.Errorf("Unmarshal() of concatenated data = %v, want nil\n%v", err, prototext.Format(m))
+		return
+	}
+	if !proto.Equal(want, merged) {
+		t.Errorf("Merge() differs from unmarshaling concatenated data\nMerged:\n%v\nUnmarshaled:\n%v", prototext.Format(merged), prototext.Format(want))
+	}
+}
+
+// testSlowPath tests that the reflective implementation of the wire format
+// agrees with the fast-path methods of m, where b is the marshaling of m.
+func (test Encoding) testSlowPath(t testing.TB, mt pref.MessageType, m proto.Message, b []byte) {
+	if mt.New().ProtoMethods() == nil {
+		// The message has no fast-path methods.
+		return
+	}
+	slow := dynamicpb.NewMessage(mt.Descriptor())
+	if err := test.unmarshal(b, slow); err != nil {
+		t.Errorf("Unmarshal() into dynamic message = %v, want nil\n%v", err, prototext.Format(m))
+		return
+	}
+	if !proto.Equal(m, slow) {
+		t.Errorf("slow path Unmarshal() differs from original\nOriginal:\n%v\nDynamic:\n%v", prototext.Format(m), prototext.Format(slow))
+	}
+	if size := (proto.MarshalOptions{AllowPartial: true}).Size(slow); size != len(b) {
+		t.Errorf("slow path Size() = %v, want %v\n%v", size, len(b), prototext.Format(m))
+	}
+
+	// The slow path may order fields differently, so the output is
+	// compared by unmarshaling it using the fast path.
+	b2, err := proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(slow)
+	if err != nil {
+		t.Errorf("Marshal() of dynamic message = %v, want nil\n%v", err, prototext.Format(m))
+		return
+	}
+	m2 := mt.New().Interface()
+	if err := test.unmarshal(b2, m2); err != nil {
+		t.Errorf("Unmarshal() of slow path output = %v, want nil\n%v", err, prototext.Format(m))
+		return
+	}
+	if !proto.Equal(m, m2) {
+		t.Errorf("slow path Marshal() differs from original\nOriginal:\n%v\nNew:\n%v", prototext.Format(m), prototext.Format(m2))
+	}
+}
+
+// testText tests the text format.
+func (test Encoding) testText(t testing.TB, mt pref.MessageType, m proto.Message) {
+	b, err := prototext.MarshalOptions{AllowPartial: true, Resolver: test.Resolver}.Marshal(m)
+	if err != nil {
+		t.Errorf("prototext.Marshal() = %v, want nil\n%v", err, prototext.Format(m))
+		return
+	}
+	m2 := mt.New().Interface()
+	if err := (prototext.UnmarshalOptions{AllowPartial: true, Resolver: test.Resolver}).Unmarshal(b, m2); err != nil {
+		t.Errorf("prototext.Unmarshal() = %v, want nil\n%s", err, b)
+		return
+	}
+	if want := withoutUnknown(m); !proto.Equal(want, m2) {
+		t.Errorf("round-trip through text format did not preserve message\nOriginal:\n%v\nNew:\n%v", prototext.Format(want), prototext.Format(m2))
+	}
+}
+
+// testJSON tests the JSON format. If random is set,
+// a message that cannot be marshaled is not tested.
+func (test Encoding) testJSON(t testing.TB, mt pref.MessageType, m proto.Message, random bool) {
+	b, err := protojson.MarshalOptions{AllowPartial: true, Resolver: test.Resolver}.Marshal(m)
+	if err != nil {
+		if !random {
+			t.Errorf("protojson.Marshal() = %v, want nil\n%v", err, prototext.Format(m))
+		}
+		return
+	}
+	m2 := mt.New().Interface()
+	if err := (protojson.UnmarshalOptions{AllowPartial: true, Resolver: test.Resolver}).Unmarshal(b, m2); err != nil {
+		t.Errorf("protojson.Unmarshal() = %v, want nil\n%s", err, b)
+		return
+	}
+	if want := withoutUnknown(m); !proto.Equal(want, m2) {
+		t.Errorf("round-trip through JSON format did not preserve message\nOriginal:\n%v\nNew:\n%v", prototext.Format(want), prototext.Format(m2))
+	}
+}
+
+func (test Encoding) unmarshal(b []byte, m proto.Message) error {
+	return proto.UnmarshalOptions{AllowPartial: true, Resolver: test.Resolver}.Unmarshal(b, m)
+}
+
+// withoutUnknown returns a copy of m with all unknown fields discarded.
+func withoutUnknown(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	discardUnknown(m.ProtoReflect())
+	return m
+}
+
+func discardUnknown(m pref.Message) {
+	m.Range(func(fd pref.FieldDescriptor, v pref.Value) bool {
+		switch {
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					discardUnknown(v.List().Get(i).Message())
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ pref.MapKey, v pref.Value) bool {
+					discardUnknown(v.Message())
+					return true
+				})
+			}
+		case fd.Message() != nil:
+			discardUnknown(v.Message())
+		}
+		return true
+	})
+	if m.GetUnknown() != nil {
+		m.SetUnknown(nil)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoimpl"
 	"google.golang.org/protobuf/testing/prototest"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	irregularpb "google.golang.org/protobuf/internal/testprotos/irregular"
 	legacypb "google.golang.org/protobuf/internal/testprotos/legacy"
@@ -42,3 +43,33 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoding(t *testing.T) {
+	ms := []proto.Message{
+		(*testpb.TestAllTypes)(nil),
+		(*test3pb.TestAllTypes)(nil),
+		(*testpb.TestRequired)(nil),
+		(*testpb.TestRequiredForeign)(nil),
+		(*testpb.TestAllExtensions)(nil),
+		dynamicpb.NewMessage((*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor()),
+	}
+	for _, m := range ms {
+		t.Run(fmt.Sprintf("%v", m.ProtoReflect().Descriptor().FullName()), func(t *testing.T) {
+			prototest.Encoding{}.Test(t, m.ProtoReflect().Type())
+		})
+	}
+
+	t.Run("Messages", func(t *testing.T) {
+		prototest.Encoding{
+			Messages: []proto.Message{
+				&testpb.TestAllTypes{},
+				&testpb.TestAllTypes{
+					OptionalInt32:  proto.Int32(1),
+					OptionalString: proto.String("hello"),
+					RepeatedInt32:  []int32{1, 2, 3},
+					OneofField:     &testpb.TestAllTypes_OneofUint32{OneofUint32: 5},
+				},
+			},
+		}.Test(t, (*testpb.TestAllTypes)(nil).ProtoReflect().Type())
+	})
+}
@@ -0,0 +1,249 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamicpb
+
+import (
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/internal/errors"
+	pref "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// A DescriptorResolver looks up descriptors by their full name.
+// It is implemented by *protoregistry.Files.
+//
+// A DescriptorResolver may additionally implement:
+//
+//   // FindMessageDescriptorByURL looks up a message descriptor by the
+//   // type URL of a google.protobuf.Any.
+//   FindMessageDescriptorByURL(url string) (protoreflect.MessageDescriptor, error)
+//
+//   // RangeFiles iterates over all files known to the resolver.
+//   RangeFiles(func(protoreflect.FileDescriptor) bool)
+//
+// If FindMessageDescriptorByURL is implemented, it is used to resolve type URLs
+// instead of looking up the message name following the last '/' in the URL.
+// This permits descriptors to be fetched on demand from a schema registry
+// using its own URL scheme.
+// If RangeFiles is implemented, it is used to look up extensions by number.
+type DescriptorResolver interface {
+	FindDescriptorByName(pref.FullName) (pref.Descriptor, error)
+}
+
+// Types is a type resolver that creates dynamic message and extension types
+// from the descriptors provided by a DescriptorResolver.
+// It implements the type resolver interfaces used by the proto, protojson,
+// and prototext packages, and may be used to unmarshal a google.protobuf.Any
+// or extension field whose Go type is not linked into the program.
+//
+// Types created by a Types are cached, so repeated lookups of the same name
+// return the same type. It is safe for concurrent use.
+type Types struct {
+	resolver DescriptorResolver
+
+	mu           sync.RWMutex
+	messages     map[pref.FullName]pref.MessageType
+	urls         map[string]pref.MessageType
+	extensions   map[pref.FullName]pref.ExtensionType
+	numbers      map[pref.FullName]map[pref.FieldNumber]pref.ExtensionDescriptor
+	numbersBuilt bool
+}
+
+// NewTypes creates a new Types that resolves descriptors using r.
+func NewTypes(r DescriptorResolver) *Types {
+	return &Types{
+		resolver:   r,
+		messages:   make(map[pref.FullName]pref.MessageType),
+		urls:       make(map[string]pref.MessageType),
+		extensions: make(map[pref.FullName]pref.ExtensionType),
+	}
+}
+
+// FindMessageByName looks up a message by its full name.
+//
+// This returns (nil, protoregistry.NotFound) if not found.
+func (t *Types) FindMessageByName(name pref.FullName) (pref.MessageType, error) {
+	t.mu.RLock()
+	mt := t.messages[name]
+	t.mu.RUnlock()
+	if mt != nil {
+		return mt, nil
+	}
+
+	d, err := t.resolver.FindDescriptorByName(name)
+	if err != nil {
+		return nil, err
+	}
+	md, ok := d.(pref.MessageDescriptor)
+	if !ok {
+		return nil, errors.New("found wrong type: got %v, want message", descName(d))
+	}
+	return t.messageType(md), nil
+}
+
+// FindMessageByURL looks up a message by a URL identifier.
+// See documentation on google.protobuf.Any.type_url for the URL format.
+//
+// This returns (nil, protoregistry.NotFound) if not found.
+func (t *Types) FindMessageByURL(url string) (pref.MessageType, error) {
+	r, ok := t.resolver.(interface {
+		FindMessageDescriptorByURL(string) (pref.MessageDescriptor, error)
+	})
+	if !ok {
+		message := pref.FullName(url)
+		if i := strings.LastIndexByte(url, '/'); i >= 0 {
+			message = message[i+len("/"):]
+		}
+		return t.FindMessageByName(message)
+	}
+
+	t.mu.RLock()
+	mt := t.urls[url]
+	t.mu.RUnlock()
+	if mt != nil {
+		return mt, nil
+	}
+
+	md, err := r.FindMessageDescriptorByURL(url)
+	if err != nil {
+		return nil, err
+	}
+	mt = t.messageType(md)
+	t.mu.Lock()
+	t.urls[url] = mt
+	t.mu.Unlock()
+	return mt, nil
+}
+
+// FindExtensionByName looks up an extension field by the field's full name.
+//
+// This returns (nil, protoregistry.NotFound) if not found.
+func (t *Types) FindExtensionByName(field pref.FullName) (pref.ExtensionType, error) {
+	t.mu.RLock()
+	xt := t.extensions[field]
+	t.mu.RUnlock()
+	if xt != nil {
+		return xt, nil
+	}
+
+	d, err := t.resolver.FindDescriptorByName(field)
+	if err != nil {
+		return nil, err
+	}
+	xd, ok := d.(pref.ExtensionDescriptor)
+	if !ok || !xd.IsExtension() {
+		return nil, errors.New("found wrong type: got %v, want extension", descName(d))
+	}
+	return t.extensionType(xd), nil
+}
+
+// FindExtensionByNumber looks up an extension field by the field number
+// within some parent message, identified by full name.
+//
+// The extensions are indexed on first use from the files reported by
+// the RangeFiles method of the DescriptorResolver.
+// Files added to the DescriptorResolver later are not observed.
+//
+// This returns (nil, protoregistry.NotFound) if not found.
+func (t *Types) FindExtensionByNumber(message pref.FullName, field pref.FieldNumber) (pref.ExtensionType, error) {
+	t.mu.RLock()
+	built := t.numbersBuilt
+	xd := t.numbers[message][field]
+	t.mu.RUnlock()
+	if !built {
+		t.buildNumbers()
+		t.mu.RLock()
+		xd = t.numbers[message][field]
+		t.mu.RUnlock()
+	}
+	if xd == nil {
+		return nil, protoregistry.NotFound
+	}
+	return t.FindExtensionByName(xd.FullName())
+}
+
+func (t *Types) buildNumbers() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.numbersBuilt {
+		return
+	}
+	t.numbersBuilt = true
+	r, ok := t.resolver.(interface {
+		RangeFiles(func(pref.FileDescriptor) bool)
+	})
+	if !ok {
+		return
+	}
+	t.numbers = make(map[pref.FullName]map[pref.FieldNumber]pref.ExtensionDescriptor)
+	r.RangeFiles(func(fd pref.FileDescriptor) bool {
+		t.addExtensions(fd.Extensions())
+		t.addMessageExtensions(fd.Messages())
+		return true
+	})
+}
+
+func (t *Types) addMessageExtensions(mds pref.MessageDescriptors) {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		t.addExtensions(md.Extensions())
+		t.addMessageExtensions(md.Messages())
+	}
+}
+
+func (t *Types) addExtensions(xds pref.ExtensionDescriptors) {
+	for i := 0; i < xds.Len(); i++ {
+		xd := xds.Get(i)
+		message := xd.ContainingMessage().FullName()
+		if t.numbers[message] == nil {
+			t.numbers[message] = make(map[pref.FieldNumber]pref.ExtensionDescriptor)
+		}
+		t.numbers[message][xd.Number()] = xd
+	}
+}
+
+func (t *Types) messageType(md pref.MessageDescriptor) pref.MessageType {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if mt := t.messages[md.FullName()]; mt != nil && mt.Descriptor() == md {
+		return mt
+	}
+	mt := NewMessageType(md)
+	t.messages[md.FullName()] = mt
+	return mt
+}
+
+func (t *Types) extensionType(xd pref.ExtensionDescriptor) pref.ExtensionType {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if xt := t.extensions[xd.FullName()]; xt != nil {
+		return xt
+	}
+	xt := NewExtensionType(xd)
+	t.extensions[xd.FullName()] = xt
+	return xt
+}
+
+func descName(d pref.Descriptor) string {
+	switch d := d.(type) {
+	case pref.EnumDescriptor:
+		return "enum"
+	case pref.EnumValueDescriptor:
+		return "enum value"
+	case pref.MessageDescriptor:
+		return "message"
+	case pref.FieldDescriptor:
+		if d.IsExtension() {
+			return "extension"
+		}
+		return "field"
+	case pref.ServiceDescriptor:
+		return "service"
+	default:
+		return "descriptor"
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamicpb_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	pref "google.golang.org/protobuf/reflect/protoreflect"
+	preg "google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func mustNewFiles(t *testing.T, s string) *preg.Files {
+	t.Helper()
+	fdp := new(descriptorpb.FileDescriptorProto)
+	if err := prototext.Unmarshal([]byte(s), fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := new(preg.Files)
+	if err := files.RegisterFile(fd); err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+const typesTestFile = `
+	name: "dynamic_types.proto"
+	package: "dynamic.test"
+	message_type: [{
+		name: "Message"
+		field: [{name:"value" number:1 label:LABEL_OPTIONAL type:TYPE_STRING json_name:"value"}]
+		extension_range: [{start:100 end:200}]
+	}]
+	extension: [{
+		name: "ext"
+		number: 100
+		label: LABEL_OPTIONAL
+		type: TYPE_INT32
+		extendee: ".dynamic.test.Message"
+	}]
+`
+
+func TestTypes(t *testing.T) {
+	types := dynamicpb.NewTypes(mustNewFiles(t, typesTestFile))
+
+	mt, err := types.FindMessageByName("dynamic.test.Message")
+	if err != nil {
+		t.Fatalf("FindMessageByName() error: %v", err)
+	}
+	if mt2, _ := types.FindMessageByName("dynamic.test.Message"); mt2 != mt {
+		t.Errorf("FindMessageByName() returned a different type on second lookup")
+	}
+	if mt2, _ := types.FindMessageByURL("example.com/x/dynamic.test.Message"); mt2 != mt {
+		t.Errorf("FindMessageByURL() = %v, want %v", mt2, mt)
+	}
+	if _, err := types.FindMessageByName("dynamic.test.Missing"); err != preg.NotFound {
+		t.Errorf("FindMessageByName(missing) error = %v, want NotFound", err)
+	}
+	if _, err := types.FindMessageByName("dynamic.test.ext"); err == nil {
+		t.Errorf("FindMessageByName(extension) succeeded, want error")
+	}
+
+	xt, err := types.FindExtensionByName("dynamic.test.ext")
+	if err != nil {
+		t.Fatalf("FindExtensionByName() error: %v", err)
+	}
+	if xt2, err := types.FindExtensionByNumber("dynamic.test.Message", 100); err != nil || xt2 != xt {
+		t.Errorf("FindExtensionByNumber() = %v, %v; want %v, nil", xt2, err, xt)
+	}
+	if _, err := types.FindExtensionByNumber("dynamic.test.Message", 101); err != preg.NotFound {
+		t.Errorf("FindExtensionByNumber(missing) error = %v, want NotFound", err)
+	}
+
+	m := mt.New()
+	m.Set(mt.Descriptor().Fields().ByName("value"), pref.ValueOfString("hello"))
+	m.Set(xt.TypeDescriptor(), pref.ValueOfInt32(5))
+	b, err := proto.Marshal(m.Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := mt.New().Interface()
+	if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(b, got); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if !proto.Equal(got, m.Interface()) {
+		t.Errorf("Unmarshal() = %v, want %v", got, m)
+	}
+}
+
+// urlResolver resolves type URLs of the form "registry.example.com/<version>/<name>".
+type urlResolver struct {
+	*preg.Files
+	lookups int
+}
+
+func (r *urlResolver) FindMessageDescriptorByURL(url string) (pref.MessageDescriptor, error) {
+	r.lookups++
+	const prefix = "registry.example.com/v1/"
+	if !strings.HasPrefix(url, prefix) {
+		return nil, preg.NotFound
+	}
+	d, err := r.FindDescriptorByName(pref.FullName(url[len(prefix):]))
+	if err != nil {
+		return nil, err
+	}
+	md, ok := d.(pref.MessageDescriptor)
+	if !ok {
+		return nil, preg.NotFound
+	}
+	return md, nil
+}
+
+func TestTypesAny(t *testing.T) {
+	r := &urlResolver{Files: mustNewFiles(t, typesTestFile)}
+	types := dynamicpb.NewTypes(r)
+
+	mt, err := types.FindMessageByURL("registry.example.com/v1/dynamic.test.Message")
+	if err != nil {
+		t.Fatalf("FindMessageByURL() error: %v", err)
+	}
+	if _, err := types.FindMessageByURL("registry.example.com/v2/dynamic.test.Message"); err != preg.NotFound {
+		t.Errorf("FindMessageByURL(v2) error = %v, want NotFound", err)
+	}
+
+	m := mt.New()
+	m.Set(mt.Descriptor().Fields().ByName("value"), pref.ValueOfString("hello"))
+	any, err := anypb.NewWithPrefix(m.Interface(), "registry.example.com/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := protojson.MarshalOptions{Resolver: types}.Marshal(any)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error: %v", err)
+	}
+	got := new(anypb.Any)
+	if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal(b, got); err != nil {
+		t.Fatalf("protojson.Unmarshal() error: %v", err)
+	}
+	if !proto.Equal(got, any) {
+		t.Errorf("protojson round-trip = %v, want %v", got, any)
+	}
+
+	b, err = prototext.MarshalOptions{Resolver: types}.Marshal(any)
+	if err != nil {
+		t.Fatalf("prototext.Marshal() error: %v", err)
+	}
+	got = new(anypb.Any)
+	if err := (prototext.UnmarshalOptions{Resolver: types}).Unmarshal(b, got); err != nil {
+		t.Fatalf("prototext.Unmarshal() error: %v", err)
+	}
+	if !proto.Equal(got, any) {
+		t.Errorf("prototext round-trip = %v, want %v", got, any)
+	}
+
+	m2, err := any.UnmarshalNew()
+	if err == nil {
+		t.Errorf("UnmarshalNew() with global registry = %v, want error", m2)
+	}
+	m2, err = anypb.UnmarshalNew(any, proto.UnmarshalOptions{Resolver: types})
+	if err != nil {
+		t.Fatalf("UnmarshalNew() error: %v", err)
+	}
+	if !proto.Equal(m2, m.Interface()) {
+		t.Errorf("UnmarshalNew() = %v, want %v", m2, m)
+	}
+
+	if r.lookups != 2 {
+		t.Errorf("FindMessageDescriptorByURL called %d times, want 2 (results should be cached)", r.lookups)
+	}
+}
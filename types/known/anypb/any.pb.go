@@ -48,6 +48,15 @@
 //	}
 //	... // make use of any
 //
+// By default, the type URL is the full name of the message type prefixed with
+// "type.googleapis.com/". A different prefix may be used with NewWithPrefix:
+//
+//	any, err := anypb.NewWithPrefix(m, "schemas.example.com/tenant")
+//	if err != nil {
+//		... // handle error
+//	}
+//	... // any.TypeUrl is "schemas.example.com/tenant/foopb.MyMessage"
+//
 //
 // Unmarshaling an Any
 //
@@ -95,6 +104,14 @@
 // listed in the case clauses are linked into the Go binary and therefore also
 // registered in the global registry.
 //
+// Message types that are not linked into the Go binary can be resolved from
+// descriptors by setting the Resolver in proto.UnmarshalOptions to a
+// dynamicpb.Types and calling the UnmarshalNew function:
+//
+//	m, err := anypb.UnmarshalNew(any, proto.UnmarshalOptions{
+//		Resolver: dynamicpb.NewTypes(files),
+//	})
+//
 //
 // Type checking an Any
 //
@@ -246,6 +263,9 @@ type Any struct {
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
+// DefaultTypeURLPrefix is the type URL prefix used by New and MarshalFrom.
+const DefaultTypeURLPrefix = "type.googleapis.com/"
+
 // New marshals src into a new Any instance.
 func New(src proto.Message) (*Any, error) {
 	dst := new(Any)
@@ -255,20 +275,43 @@ func New(src proto.Message) (*Any, error) {
 	return dst, nil
 }
 
+// NewWithPrefix marshals src into a new Any instance whose type URL
+// is the given prefix followed by the full name of src.
+// A trailing slash is added to the prefix if it does not already have one.
+func NewWithPrefix(src proto.Message, prefix string) (*Any, error) {
+	dst := new(Any)
+	if err := MarshalFromWithPrefix(dst, src, prefix, proto.MarshalOptions{}); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
 // MarshalFrom marshals src into dst as the underlying message
 // using the provided marshal options.
 //
 // If no options are specified, call dst.MarshalFrom instead.
 func MarshalFrom(dst *Any, src proto.Message, opts proto.MarshalOptions) error {
-	const urlPrefix = "type.googleapis.com/"
+	return MarshalFromWithPrefix(dst, src, DefaultTypeURLPrefix, opts)
+}
+
+// MarshalFromWithPrefix marshals src into dst as the underlying message
+// using the provided type URL prefix and marshal options.
+// A trailing slash is added to the prefix if it does not already have one.
+func MarshalFromWithPrefix(dst *Any, src proto.Message, prefix string, opts proto.MarshalOptions) error {
 	if src == nil {
 		return protoimpl.X.NewError("invalid nil source message")
 	}
+	if prefix == "" {
+		return protoimpl.X.NewError("invalid empty type URL prefix")
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
 	b, err := opts.Marshal(src)
 	if err != nil {
 		return err
 	}
-	dst.TypeUrl = urlPrefix + string(src.ProtoReflect().Descriptor().FullName())
+	dst.TypeUrl = prefix + string(src.ProtoReflect().Descriptor().FullName())
 	dst.Value = b
 	return nil
 }
@@ -332,6 +375,14 @@ func (x *Any) MessageIs(m proto.Message) bool {
 	return len(url) == len(name) || url[len(url)-len(name)-1] == '/'
 }
 
+// TypeURLPrefix reports the prefix of the type URL of the underlying message,
+// which is everything up to and including the last slash.
+// It returns an empty string if the type URL has no slash.
+func (x *Any) TypeURLPrefix() string {
+	url := x.GetTypeUrl()
+	return url[:strings.LastIndexByte(url, '/')+len("/")]
+}
+
 // MessageName reports the full name of the underlying message,
 // returning an empty string if invalid.
 func (x *Any) MessageName() protoreflect.FullName {
@@ -182,3 +182,54 @@ func TestRoundtrip(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeURLPrefix(t *testing.T) {
+	tests := []struct {
+		inPrefix   string
+		wantURL    string
+		wantPrefix string
+		wantErr    bool
+	}{{
+		inPrefix: "",
+		wantErr:  true,
+	}, {
+		inPrefix:   apb.DefaultTypeURLPrefix,
+		wantURL:    "type.googleapis.com/goproto.proto.test.TestAllTypes",
+		wantPrefix: "type.googleapis.com/",
+	}, {
+		inPrefix:   "schemas.example.com/tenant",
+		wantURL:    "schemas.example.com/tenant/goproto.proto.test.TestAllTypes",
+		wantPrefix: "schemas.example.com/tenant/",
+	}, {
+		inPrefix:   "schemas.example.com/tenant/",
+		wantURL:    "schemas.example.com/tenant/goproto.proto.test.TestAllTypes",
+		wantPrefix: "schemas.example.com/tenant/",
+	}}
+
+	m := &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)}
+	for _, tt := range tests {
+		got, err := apb.NewWithPrefix(m, tt.inPrefix)
+		if gotErr := err != nil; gotErr != tt.wantErr {
+			t.Errorf("NewWithPrefix(%q) error = %v, want error %v", tt.inPrefix, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if got.GetTypeUrl() != tt.wantURL {
+			t.Errorf("NewWithPrefix(%q).TypeUrl = %q, want %q", tt.inPrefix, got.GetTypeUrl(), tt.wantURL)
+		}
+		if got.TypeURLPrefix() != tt.wantPrefix {
+			t.Errorf("NewWithPrefix(%q).TypeURLPrefix() = %q, want %q", tt.inPrefix, got.TypeURLPrefix(), tt.wantPrefix)
+		}
+		if !got.MessageIs(m) {
+			t.Errorf("NewWithPrefix(%q).MessageIs(%T) = false, want true", tt.inPrefix, m)
+		}
+		m2 := new(testpb.TestAllTypes)
+		if err := got.UnmarshalTo(m2); err != nil {
+			t.Errorf("UnmarshalTo error: %v", err)
+		}
+		if diff := cmp.Diff(m, m2, protocmp.Transform()); diff != "" {
+			t.Errorf("UnmarshalTo mismatch (-want +got):\n%v", diff)
+		}
+	}
+}
@@ -74,6 +74,29 @@
 //	dur := durationpb.New(d)
 //	... // make use of d as a *durationpb.Duration
 //
+//
+// Parsing and formatting
+//
+// The Parse function and Format method convert between a Duration and its
+// JSON representation, which is a number of seconds with the suffix "s".
+// The ParseISO8601 function and FormatISO8601 method convert between a
+// Duration and its ISO 8601 representation:
+//
+//	dur, err := durationpb.ParseISO8601("PT1H30M")
+//	if err != nil {
+//		... // handle error
+//	}
+//	s := dur.Format() // "5400s"
+//
+//
+// Arithmetic
+//
+// Since a Duration may represent spans of up to 10,000 years, which exceeds
+// the range of a time.Duration, the Add, Sub, and Compare methods operate
+// exactly on the seconds and nanos fields. The results saturate instead of
+// overflowing. The Normalize method carries excess nanos into the seconds
+// and gives them the same sign as the seconds.
+//
 package durationpb
 
 import (
@@ -248,6 +271,151 @@ func (x *Duration) check() uint {
 	}
 }
 
+// Parse parses a duration in the JSON format of a Duration, which is a decimal
+// number of seconds with the suffix "s" (e.g., "1.5s"). The fractional part
+// may have at most 9 digits. It reports an error if the duration is invalid.
+func Parse(s string) (*Duration, error) {
+	secs, nanos, ok := protoimpl.X.ParseDuration(s)
+	if !ok {
+		return nil, protoimpl.X.NewError("invalid duration %q", s)
+	}
+	x := &Duration{Seconds: secs, Nanos: nanos}
+	if err := x.CheckValid(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// ParseISO8601 parses a duration in the ISO 8601 format "PnWnDTnHnMnS"
+// with an optional leading sign (e.g., "PT1H30M" or "-P1DT0.5S").
+// Weeks are 7 days and days are 24 hours long. Years and months are not
+// accepted since they do not have a fixed length. Only the seconds may have
+// a fractional part of at most 9 digits.
+// It reports an error if the duration is invalid.
+func ParseISO8601(s string) (*Duration, error) {
+	secs, nanos, ok := protoimpl.X.ParseISO8601Duration(s)
+	if !ok {
+		return nil, protoimpl.X.NewError("invalid ISO 8601 duration %q", s)
+	}
+	x := newDuration(secs, int64(nanos), true)
+	if err := x.CheckValid(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Format formats x in the JSON format of a Duration, which is a decimal number
+// of seconds with the suffix "s" (e.g., "1.5s"). The fractional part has
+// 0, 3, 6, or 9 digits depending on the required precision.
+// The duration is normalized before formatting.
+func (x *Duration) Format() string {
+	d := x.normalized()
+	return protoimpl.X.FormatDuration(d.Seconds, d.Nanos)
+}
+
+// FormatISO8601 formats x in the ISO 8601 format using hours, minutes, and
+// seconds (e.g., "PT1H30M0.5S"). A negative duration has a leading minus sign.
+// The duration is normalized before formatting.
+func (x *Duration) FormatISO8601() string {
+	d := x.normalized()
+	return protoimpl.X.FormatISO8601Duration(d.Seconds, d.Nanos)
+}
+
+// Add returns the exact sum of x and y as a new normalized Duration.
+// A nil Duration is treated as zero. The result may be outside the range of
+// a valid duration, but it saturates instead of overflowing the seconds.
+func (x *Duration) Add(y *Duration) *Duration {
+	secs, ok := addSeconds(x.GetSeconds(), y.GetSeconds())
+	return newDuration(secs, int64(x.GetNanos())+int64(y.GetNanos()), ok)
+}
+
+// Sub returns the exact difference of x and y as a new normalized Duration.
+// A nil Duration is treated as zero. The result may be outside the range of
+// a valid duration, but it saturates instead of overflowing the seconds.
+func (x *Duration) Sub(y *Duration) *Duration {
+	secs, ok := subSeconds(x.GetSeconds(), y.GetSeconds())
+	return newDuration(secs, int64(x.GetNanos())-int64(y.GetNanos()), ok)
+}
+
+// Compare returns -1, 0, or +1 depending on whether x is shorter than,
+// equal to, or longer than y. A nil Duration is treated as zero.
+// Denormalized durations are compared by the values they represent.
+func (x *Duration) Compare(y *Duration) int {
+	dx, dy := x.normalized(), y.normalized()
+	switch {
+	case dx.Seconds < dy.Seconds:
+		return -1
+	case dx.Seconds > dy.Seconds:
+		return +1
+	case dx.Nanos < dy.Nanos:
+		return -1
+	case dx.Nanos > dy.Nanos:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Normalize converts x to its canonical form where the nanos are within the
+// range of -999,999,999 to +999,999,999 inclusive and have the same sign as
+// the seconds. Excess nanos are carried into the seconds.
+func (x *Duration) Normalize() {
+	d := x.normalized()
+	x.Seconds, x.Nanos = d.Seconds, d.Nanos
+}
+
+func (x *Duration) normalized() *Duration {
+	return newDuration(x.GetSeconds(), int64(x.GetNanos()), true)
+}
+
+// newDuration constructs a normalized Duration from secs and nanos,
+// where nanos may be of any magnitude and sign. If ok is false,
+// secs is the saturated result of an overflowed computation.
+func newDuration(secs, nanos int64, ok bool) *Duration {
+	if ok {
+		secs, ok = addSeconds(secs, nanos/1e9)
+		nanos %= 1e9
+	}
+	if !ok {
+		// Saturate to the largest value with the same sign.
+		if secs < 0 {
+			return &Duration{Seconds: secs, Nanos: -(1e9 - 1)}
+		}
+		return &Duration{Seconds: secs, Nanos: +(1e9 - 1)}
+	}
+	switch {
+	case secs > 0 && nanos < 0:
+		secs, nanos = secs-1, nanos+1e9
+	case secs < 0 && nanos > 0:
+		secs, nanos = secs+1, nanos-1e9
+	}
+	return &Duration{Seconds: secs, Nanos: int32(nanos)}
+}
+
+// addSeconds returns x+y, or the saturated sum and false on overflow.
+func addSeconds(x, y int64) (int64, bool) {
+	switch {
+	case y > 0 && x > math.MaxInt64-y:
+		return math.MaxInt64, false
+	case y < 0 && x < math.MinInt64-y:
+		return math.MinInt64, false
+	default:
+		return x + y, true
+	}
+}
+
+// subSeconds returns x-y, or the saturated difference and false on overflow.
+func subSeconds(x, y int64) (int64, bool) {
+	switch {
+	case y < 0 && x > math.MaxInt64+y:
+		return math.MaxInt64, false
+	case y > 0 && x < math.MinInt64+y:
+		return math.MinInt64, false
+	default:
+		return x - y, true
+	}
+}
+
 func (x *Duration) Reset() {
 	*x = Duration{}
 	if protoimpl.UnsafeEnabled {
@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/internal/detrand"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	durpb "google.golang.org/protobuf/types/known/durationpb"
@@ -98,3 +99,121 @@ type textError string
 
 func (e textError) Error() string     { return string(e) }
 func (e textError) Is(err error) bool { return err != nil && strings.Contains(err.Error(), e.Error()) }
+
+func TestArithmetic(t *testing.T) {
+	d := func(secs int64, nanos int32) *durpb.Duration {
+		return &durpb.Duration{Seconds: secs, Nanos: nanos}
+	}
+	tests := []struct {
+		x, y    *durpb.Duration
+		wantAdd *durpb.Duration
+		wantSub *durpb.Duration
+		wantCmp int
+	}{
+		{x: nil, y: nil, wantAdd: d(0, 0), wantSub: d(0, 0), wantCmp: 0},
+		{x: d(1, 5e8), y: d(1, 5e8), wantAdd: d(3, 0), wantSub: d(0, 0), wantCmp: 0},
+		{x: d(1, 0), y: d(0, 1), wantAdd: d(1, 1), wantSub: d(0, 999999999), wantCmp: +1},
+		{x: d(0, -1), y: d(1, 0), wantAdd: d(0, 999999999), wantSub: d(-1, -1), wantCmp: -1},
+		{x: d(-1, -5e8), y: d(2, 0), wantAdd: d(0, 5e8), wantSub: d(-3, -5e8), wantCmp: -1},
+		{x: d(absSeconds, 999999999), y: d(absSeconds, 999999999), wantAdd: d(2*absSeconds+1, 999999998), wantSub: d(0, 0), wantCmp: 0},
+		{x: d(0, 2e9), y: d(2, 0), wantAdd: d(4, 0), wantSub: d(0, 0), wantCmp: 0},
+		{x: d(math.MaxInt64, 0), y: d(1, 0), wantAdd: d(math.MaxInt64, 999999999), wantSub: d(math.MaxInt64-1, 0), wantCmp: +1},
+		{x: d(math.MinInt64, 0), y: d(1, 0), wantAdd: d(math.MinInt64+1, 0), wantSub: d(math.MinInt64, -999999999), wantCmp: -1},
+		{x: d(math.MaxInt64, 999999999), y: d(0, 1), wantAdd: d(math.MaxInt64, 999999999), wantSub: d(math.MaxInt64, 999999998), wantCmp: +1},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.wantAdd, tt.x.Add(tt.y), protocmp.Transform()); diff != "" {
+			t.Errorf("Add(%v, %v) mismatch (-want +got):\n%s", tt.x, tt.y, diff)
+		}
+		if diff := cmp.Diff(tt.wantSub, tt.x.Sub(tt.y), protocmp.Transform()); diff != "" {
+			t.Errorf("Sub(%v, %v) mismatch (-want +got):\n%s", tt.x, tt.y, diff)
+		}
+		if got := tt.x.Compare(tt.y); got != tt.wantCmp {
+			t.Errorf("Compare(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.wantCmp)
+		}
+		if got := tt.y.Compare(tt.x); got != -tt.wantCmp {
+			t.Errorf("Compare(%v, %v) = %v, want %v", tt.y, tt.x, got, -tt.wantCmp)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   *durpb.Duration
+		want *durpb.Duration
+	}{
+		{in: &durpb.Duration{Seconds: 1, Nanos: 1}, want: &durpb.Duration{Seconds: 1, Nanos: 1}},
+		{in: &durpb.Duration{Seconds: 1, Nanos: -1}, want: &durpb.Duration{Seconds: 0, Nanos: 999999999}},
+		{in: &durpb.Duration{Seconds: -1, Nanos: 1}, want: &durpb.Duration{Seconds: 0, Nanos: -999999999}},
+		{in: &durpb.Duration{Seconds: 0, Nanos: 1500000000}, want: &durpb.Duration{Seconds: 1, Nanos: 5e8}},
+		{in: &durpb.Duration{Seconds: 0, Nanos: math.MinInt32}, want: &durpb.Duration{Seconds: -2, Nanos: -147483648}},
+		{in: &durpb.Duration{Seconds: math.MaxInt64, Nanos: 1e9}, want: &durpb.Duration{Seconds: math.MaxInt64, Nanos: 999999999}},
+	}
+	for _, tt := range tests {
+		got := proto.Clone(tt.in).(*durpb.Duration)
+		got.Normalize()
+		if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("Normalize(%v) mismatch (-want +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in            *durpb.Duration
+		wantFormat    string
+		wantISO8601   string
+		parseISO8601s []string
+	}{{
+		in:          &durpb.Duration{},
+		wantFormat:  "0s",
+		wantISO8601: "PT0S",
+	}, {
+		in:            &durpb.Duration{Seconds: 5400, Nanos: 5e8},
+		wantFormat:    "5400.500s",
+		wantISO8601:   "PT1H30M0.5S",
+		parseISO8601s: []string{"PT90M0.5S", "+PT1H1800.500S"},
+	}, {
+		in:            &durpb.Duration{Seconds: -(8*24*3600 + 2), Nanos: -1},
+		wantFormat:    "-691202.000000001s",
+		wantISO8601:   "-PT192H2.000000001S",
+		parseISO8601s: []string{"-P1W1DT2.000000001S"},
+	}, {
+		in:          &durpb.Duration{Seconds: absSeconds, Nanos: 999999999},
+		wantFormat:  "315576000000.999999999s",
+		wantISO8601: "PT87660000H0.999999999S",
+	}}
+	for _, tt := range tests {
+		if got := tt.in.Format(); got != tt.wantFormat {
+			t.Errorf("Format(%v) = %q, want %q", tt.in, got, tt.wantFormat)
+		}
+		if got := tt.in.FormatISO8601(); got != tt.wantISO8601 {
+			t.Errorf("FormatISO8601(%v) = %q, want %q", tt.in, got, tt.wantISO8601)
+		}
+		got, err := durpb.Parse(tt.wantFormat)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.wantFormat, err)
+		} else if diff := cmp.Diff(tt.in, got, protocmp.Transform()); diff != "" {
+			t.Errorf("Parse(%q) mismatch (-want +got):\n%s", tt.wantFormat, diff)
+		}
+		for _, s := range append([]string{tt.wantISO8601}, tt.parseISO8601s...) {
+			got, err := durpb.ParseISO8601(s)
+			if err != nil {
+				t.Errorf("ParseISO8601(%q) error: %v", s, err)
+			} else if diff := cmp.Diff(tt.in, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ParseISO8601(%q) mismatch (-want +got):\n%s", s, diff)
+			}
+		}
+	}
+
+	for _, s := range []string{"", "1", "1.0000000001s", "315576000001s", "1.5"} {
+		if got, err := durpb.Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", s, got)
+		}
+	}
+	for _, s := range []string{"", "P", "PT", "P1Y", "P1M", "PT1D", "P1DT", "PT1S1M", "PT0.1M", "PT1.0000000001S", "PT1,5S", "1H", "P100000000W"} {
+		if got, err := durpb.ParseISO8601(s); err == nil {
+			t.Errorf("ParseISO8601(%q) = %v, want error", s, got)
+		}
+	}
+}
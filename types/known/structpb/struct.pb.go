@@ -59,6 +59,39 @@
 // NewList, and NewValue constructor functions.
 //
 //
+// Conversion to and from arbitrary Go types
+//
+// The ConvertValue and ConvertStruct functions construct messages from any Go
+// value that the "encoding/json" package can marshal, such as json.RawMessage,
+// json.Number, time.Time, and Go structs with json field tags.
+// The Value.ConvertTo, Struct.ConvertTo, and ListValue.ConvertTo methods are
+// their inverse and store the message in a Go value following the rules of
+// "encoding/json".Unmarshal:
+//
+//	type Person struct {
+//		Name     string    `json:"name"`
+//		Birthday time.Time `json:"birthday"`
+//	}
+//	s, err := structpb.ConvertStruct(Person{Name: "John Smith"})
+//	if err != nil {
+//		... // handle error
+//	}
+//	var p Person
+//	if err := s.ConvertTo(&p); err != nil {
+//		... // handle error
+//	}
+//
+//
+// Accessing nested values
+//
+// The Value.GetPath and Struct.GetPath methods retrieve a nested value by
+// a path of field names and list indexes (e.g., "phoneNumbers[0].type").
+// They return nil if the value does not exist, so the result can be used
+// directly with the getter methods of Value:
+//
+//	typ := m.GetPath("phoneNumbers[0].type").GetStringValue()
+//
+//
 // Example usage
 //
 // Consider the following example JSON object:
@@ -123,11 +156,14 @@ package structpb
 
 import (
 	base64 "encoding/base64"
+	json "encoding/json"
 	protojson "google.golang.org/protobuf/encoding/protojson"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	math "math"
 	reflect "reflect"
+	strconv "strconv"
+	strings "strings"
 	sync "sync"
 	utf8 "unicode/utf8"
 )
@@ -233,6 +269,41 @@ func (x *Struct) UnmarshalJSON(b []byte) error {
 	return protojson.Unmarshal(b, x)
 }
 
+// ConvertStruct constructs a Struct from any Go value that the standard
+// "encoding/json" package marshals as a JSON object, such as a Go struct
+// with json field tags or a map with string keys.
+// The value is converted using ConvertValue.
+func ConvertStruct(v interface{}) (*Struct, error) {
+	x, err := ConvertValue(v)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := x.GetKind().(*Value_StructValue)
+	if !ok {
+		return nil, protoimpl.X.NewError("invalid type: %T is not a JSON object", v)
+	}
+	return s.StructValue, nil
+}
+
+// ConvertTo stores x in the Go value pointed to by v
+// following the rules of "encoding/json".Unmarshal.
+func (x *Struct) ConvertTo(v interface{}) error {
+	b, err := protojson.Marshal(x)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// GetPath returns the value at the given path relative to x.
+// See Value.GetPath for the path syntax.
+func (x *Struct) GetPath(path string) *Value {
+	if x == nil {
+		return nil
+	}
+	return NewStructValue(x).GetPath(path)
+}
+
 func (x *Struct) Reset() {
 	*x = Struct{}
 	if protoimpl.UnsafeEnabled {
@@ -440,6 +511,117 @@ func (x *Value) UnmarshalJSON(b []byte) error {
 	return protojson.Unmarshal(b, x)
 }
 
+// ConvertValue constructs a Value from any Go value that can be marshaled by
+// the standard "encoding/json" package, including json.RawMessage, json.Number,
+// time.Time, and Go structs with json field tags.
+//
+// The Go types accepted by NewValue are converted as NewValue does.
+// All other values are marshaled as JSON by "encoding/json".Marshal and
+// the result is parsed as a Value. Consequently, types implementing
+// json.Marshaler or encoding.TextMarshaler use their custom representation
+// (e.g., a time.Time is stored as an RFC 3339 string).
+func ConvertValue(v interface{}) (*Value, error) {
+	switch v.(type) {
+	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64, string, []byte:
+		return NewValue(v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, protoimpl.X.NewError("invalid type %T: %v", v, err)
+	}
+	x := new(Value)
+	if err := protojson.Unmarshal(b, x); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// ConvertTo stores x in the Go value pointed to by v
+// following the rules of "encoding/json".Unmarshal.
+// It is the inverse of ConvertValue.
+func (x *Value) ConvertTo(v interface{}) error {
+	b, err := protojson.Marshal(x)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// GetPath returns the value at the given path relative to x,
+// or nil if there is no such value or the path is malformed.
+//
+// A path is a sequence of Struct field names separated by dots,
+// where each name may be followed by ListValue indexes in brackets
+// (e.g., "a.b[0].c"). A field name that contains a dot or bracket
+// is written as a quoted string in brackets (e.g., `a["b.c"]`).
+// The empty path refers to x itself.
+//
+// Since the getter methods of a nil Value return the zero value,
+// the result may be used directly (e.g., x.GetPath("a.b").GetStringValue()).
+func (x *Value) GetPath(path string) *Value {
+	if path != "" && path[0] != '[' {
+		path = "." + path
+	}
+	for x != nil && path != "" {
+		switch path[0] {
+		case '.':
+			path = path[len("."):]
+			i := strings.IndexAny(path, ".[")
+			if i < 0 {
+				i = len(path)
+			}
+			if i == 0 {
+				return nil
+			}
+			x = x.GetStructValue().GetFields()[path[:i]]
+			path = path[i:]
+		case '[':
+			path = path[len("["):]
+			if path != "" && path[0] == '"' {
+				n := quotedLen(path)
+				if n < 0 || n >= len(path) || path[n] != ']' {
+					return nil
+				}
+				k, err := strconv.Unquote(path[:n])
+				if err != nil {
+					return nil
+				}
+				x = x.GetStructValue().GetFields()[k]
+				path = path[n+len("]"):]
+			} else {
+				i := strings.IndexByte(path, ']')
+				if i < 0 {
+					return nil
+				}
+				n, err := strconv.Atoi(path[:i])
+				vs := x.GetListValue().GetValues()
+				if err != nil || n < 0 || n >= len(vs) {
+					return nil
+				}
+				x = vs[n]
+				path = path[i+len("]"):]
+			}
+		default:
+			return nil
+		}
+	}
+	return x
+}
+
+// quotedLen returns the length of the quoted string at the start of s,
+// or -1 if it is not terminated.
+func quotedLen(s string) int {
+	for i := len(`"`); i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + len(`"`)
+		}
+	}
+	return -1
+}
+
 func (x *Value) Reset() {
 	*x = Value{}
 	if protoimpl.UnsafeEnabled {
@@ -611,6 +793,16 @@ func (x *ListValue) UnmarshalJSON(b []byte) error {
 	return protojson.Unmarshal(b, x)
 }
 
+// ConvertTo stores x in the Go value pointed to by v
+// following the rules of "encoding/json".Unmarshal.
+func (x *ListValue) ConvertTo(v interface{}) error {
+	b, err := protojson.Marshal(x)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
 func (x *ListValue) Reset() {
 	*x = ListValue{}
 	if protoimpl.UnsafeEnabled {
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -510,3 +511,179 @@ func TestFromValue(t *testing.T) {
 		}
 	}
 }
+
+type testPerson struct {
+	Name     string            `json:"name"`
+	Age      int               `json:"age,omitempty"`
+	Birthday time.Time         `json:"birthday"`
+	Tags     []string          `json:"tags"`
+	Extra    json.RawMessage   `json:"extra,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Ignored  string            `json:"-"`
+}
+
+func TestConvertValue(t *testing.T) {
+	birthday := time.Date(1990, time.May, 4, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		in      interface{}
+		wantPB  *spb.Value
+		wantErr error
+	}{{
+		in:     nil,
+		wantPB: spb.NewNullValue(),
+	}, {
+		in:     float64(123.456),
+		wantPB: spb.NewNumberValue(123.456),
+	}, {
+		in:     protoreflect.Name("named string"),
+		wantPB: spb.NewStringValue("named string"),
+	}, {
+		in:     json.Number("12.5"),
+		wantPB: spb.NewNumberValue(12.5),
+	}, {
+		in:      json.Number("bad"),
+		wantErr: cmpopts.AnyError,
+	}, {
+		in: json.RawMessage(`{"a":[1,"two",null]}`),
+		wantPB: spb.NewStructValue(&spb.Struct{Fields: map[string]*spb.Value{
+			"a": spb.NewListValue(&spb.ListValue{Values: []*spb.Value{
+				spb.NewNumberValue(1),
+				spb.NewStringValue("two"),
+				spb.NewNullValue(),
+			}}),
+		}}),
+	}, {
+		in:      json.RawMessage(`{"a":`),
+		wantErr: cmpopts.AnyError,
+	}, {
+		in:     birthday,
+		wantPB: spb.NewStringValue("1990-05-04T12:30:00Z"),
+	}, {
+		in: &testPerson{
+			Name:     "John Smith",
+			Birthday: birthday,
+			Extra:    json.RawMessage(`true`),
+			Ignored:  "ignored",
+		},
+		wantPB: spb.NewStructValue(&spb.Struct{Fields: map[string]*spb.Value{
+			"name":     spb.NewStringValue("John Smith"),
+			"birthday": spb.NewStringValue("1990-05-04T12:30:00Z"),
+			"tags":     spb.NewNullValue(),
+			"extra":    spb.NewBoolValue(true),
+		}}),
+	}, {
+		in: map[string]interface{}{"when": birthday, "n": []int{1, 2}},
+		wantPB: spb.NewStructValue(&spb.Struct{Fields: map[string]*spb.Value{
+			"when": spb.NewStringValue("1990-05-04T12:30:00Z"),
+			"n": spb.NewListValue(&spb.ListValue{Values: []*spb.Value{
+				spb.NewNumberValue(1),
+				spb.NewNumberValue(2),
+			}}),
+		}}),
+	}, {
+		in:      make(chan int),
+		wantErr: cmpopts.AnyError,
+	}}
+
+	for _, tt := range tests {
+		gotPB, gotErr := spb.ConvertValue(tt.in)
+		if diff := cmp.Diff(tt.wantPB, gotPB, protocmp.Transform()); diff != "" {
+			t.Errorf("ConvertValue(%v) output mismatch (-want +got):\n%s", tt.in, diff)
+		}
+		if diff := cmp.Diff(tt.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+			t.Errorf("ConvertValue(%v) error mismatch (-want +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestConvertStruct(t *testing.T) {
+	in := testPerson{
+		Name:     "John Smith",
+		Age:      27,
+		Birthday: time.Date(1990, time.May, 4, 12, 30, 0, 0, time.UTC),
+		Tags:     []string{"a", "b"},
+		Extra:    json.RawMessage(`{"k":"v"}`),
+		Labels:   map[string]string{"x": "y"},
+	}
+	s, err := spb.ConvertStruct(in)
+	if err != nil {
+		t.Fatalf("ConvertStruct() error: %v", err)
+	}
+	var got testPerson
+	if err := s.ConvertTo(&got); err != nil {
+		t.Fatalf("ConvertTo() error: %v", err)
+	}
+	if diff := cmp.Diff(in, got, equateJSON); diff != "" {
+		t.Errorf("ConvertStruct round-trip mismatch (-want +got):\n%s", diff)
+	}
+
+	var gotList []string
+	if err := s.GetFields()["tags"].GetListValue().ConvertTo(&gotList); err != nil {
+		t.Fatalf("ListValue.ConvertTo() error: %v", err)
+	}
+	if diff := cmp.Diff(in.Tags, gotList); diff != "" {
+		t.Errorf("ListValue.ConvertTo() mismatch (-want +got):\n%s", diff)
+	}
+
+	var gotNumber json.Number
+	if err := spb.NewNumberValue(27).ConvertTo(&gotNumber); err != nil {
+		t.Fatalf("Value.ConvertTo() error: %v", err)
+	}
+	if gotNumber != "27" {
+		t.Errorf("Value.ConvertTo() = %q, want %q", gotNumber, "27")
+	}
+
+	if _, err := spb.ConvertStruct([]string{"not", "an", "object"}); err == nil {
+		t.Errorf("ConvertStruct(slice) succeeded, want error")
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	m, err := spb.ConvertStruct(json.RawMessage(`{
+		"a": {"b": [{"c": "first"}, {"c": "second"}]},
+		"dotted.key": {"x[0]": 5},
+		"list": [[1, 2], [3]]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path string
+		want *spb.Value
+	}{
+		{"a.b[0].c", spb.NewStringValue("first")},
+		{"a.b[1].c", spb.NewStringValue("second")},
+		{"a.b[1]", spb.NewStructValue(&spb.Struct{Fields: map[string]*spb.Value{"c": spb.NewStringValue("second")}})},
+		{`["dotted.key"]["x[0]"]`, spb.NewNumberValue(5)},
+		{"list[0][1]", spb.NewNumberValue(2)},
+		{"list[1][0]", spb.NewNumberValue(3)},
+		{"", spb.NewStructValue(m)},
+		{"a.b[2].c", nil},
+		{"a.b[-1]", nil},
+		{"a.b[x]", nil},
+		{"a.b[0", nil},
+		{"a..b", nil},
+		{".a", nil},
+		{"a.", nil},
+		{"a.b.c", nil},
+		{"list[0]x", nil},
+		{`["unterminated]`, nil},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		got := m.GetPath(tt.path)
+		if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("GetPath(%q) mismatch (-want +got):\n%s", tt.path, diff)
+		}
+	}
+
+	if got := m.GetPath("a.b[0].c").GetStringValue(); got != "first" {
+		t.Errorf("GetPath().GetStringValue() = %q, want %q", got, "first")
+	}
+	if got := m.GetPath("missing.field").GetStringValue(); got != "" {
+		t.Errorf("GetPath(missing).GetStringValue() = %q, want empty", got)
+	}
+	if got := (*spb.Struct)(nil).GetPath("a"); got != nil {
+		t.Errorf("nil Struct GetPath() = %v, want nil", got)
+	}
+}
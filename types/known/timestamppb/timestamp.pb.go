@@ -73,11 +73,37 @@
 //	ts := timestamppb.Now()
 //	... // make use of ts as a *timestamppb.Timestamp
 //
+//
+// Parsing and formatting
+//
+// The Parse function and Format method convert between a Timestamp and its
+// RFC 3339 representation, which is also used by the JSON format:
+//
+//	ts, err := timestamppb.Parse("1972-01-01T10:00:20.021Z")
+//	if err != nil {
+//		... // handle error
+//	}
+//	s := ts.Format() // "1972-01-01T10:00:20.021Z"
+//
+//
+// Arithmetic
+//
+// The Add, Sub, and Compare methods operate exactly on the seconds and nanos
+// fields without converting to a time.Time, and never overflow:
+//
+//	later := ts.Add(durationpb.New(time.Hour))
+//	elapsed := later.Sub(ts) // a *durationpb.Duration of one hour
+//
+// The Normalize method carries nanos outside the range of 0 to 999,999,999
+// into the seconds.
+//
 package timestamppb
 
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	math "math"
 	reflect "reflect"
 	sync "sync"
 	time "time"
@@ -250,6 +276,92 @@ func (x *Timestamp) check() uint {
 	}
 }
 
+// Parse parses a timestamp in the RFC 3339 format, which is the JSON format of
+// a Timestamp (e.g., "1972-01-01T10:00:20.021Z"). The year must have four
+// digits, the fractional seconds may have at most 9 digits, and the time zone
+// offset may be "Z" or of the form "±hh:mm".
+// It reports an error if the timestamp is invalid.
+func Parse(s string) (*Timestamp, error) {
+	secs, nanos, ok := protoimpl.X.ParseTimestamp(s)
+	if !ok {
+		return nil, protoimpl.X.NewError("invalid timestamp %q", s)
+	}
+	x := &Timestamp{Seconds: secs, Nanos: nanos}
+	if err := x.CheckValid(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Format formats x in the RFC 3339 format in UTC, which is the JSON format of
+// a Timestamp (e.g., "1972-01-01T10:00:20.021Z"). The fractional seconds have
+// 0, 3, 6, or 9 digits depending on the required precision.
+// The timestamp is normalized before formatting. The result is only a valid
+// RFC 3339 timestamp if x is within the range reported by CheckValid.
+func (x *Timestamp) Format() string {
+	t := x.normalized()
+	return protoimpl.X.FormatTimestamp(t.Seconds, t.Nanos)
+}
+
+// Add returns x advanced by the exact duration d as a new normalized
+// Timestamp. A nil Timestamp or Duration is treated as zero.
+// The result may be outside the range of a valid timestamp,
+// but it saturates instead of overflowing the seconds.
+func (x *Timestamp) Add(d *durationpb.Duration) *Timestamp {
+	return newTimestamp(x.asDuration().Add(d))
+}
+
+// AddDate returns x advanced by the given number of years, months, and days
+// in UTC, normalizing dates in the same way as time.Time.AddDate
+// (e.g., adding one month to October 31 yields December 1).
+func (x *Timestamp) AddDate(years, months, days int) *Timestamp {
+	return New(x.AsTime().AddDate(years, months, days))
+}
+
+// Sub returns the exact duration x-y as a new normalized Duration.
+// A nil Timestamp is treated as zero. The result may be outside the range of
+// a valid duration, but it saturates instead of overflowing the seconds.
+func (x *Timestamp) Sub(y *Timestamp) *durationpb.Duration {
+	return x.asDuration().Sub(y.asDuration())
+}
+
+// Compare returns -1, 0, or +1 depending on whether x is before,
+// equal to, or after y. A nil Timestamp is treated as zero.
+// Denormalized timestamps are compared by the instants they represent.
+func (x *Timestamp) Compare(y *Timestamp) int {
+	return x.asDuration().Compare(y.asDuration())
+}
+
+// Normalize converts x to its canonical form where the nanos are within the
+// range of 0 to 999,999,999 inclusive. Excess nanos are carried into the
+// seconds.
+func (x *Timestamp) Normalize() {
+	t := x.normalized()
+	x.Seconds, x.Nanos = t.Seconds, t.Nanos
+}
+
+func (x *Timestamp) normalized() *Timestamp {
+	return newTimestamp(x.asDuration().Add(nil))
+}
+
+// asDuration returns x as a duration since the Unix epoch.
+func (x *Timestamp) asDuration() *durationpb.Duration {
+	return &durationpb.Duration{Seconds: x.GetSeconds(), Nanos: x.GetNanos()}
+}
+
+// newTimestamp constructs a normalized Timestamp from a normalized duration
+// since the Unix epoch.
+func newTimestamp(d *durationpb.Duration) *Timestamp {
+	secs, nanos := d.GetSeconds(), d.GetNanos()
+	if nanos < 0 {
+		if secs == math.MinInt64 {
+			return &Timestamp{Seconds: secs}
+		}
+		secs, nanos = secs-1, nanos+1e9
+	}
+	return &Timestamp{Seconds: secs, Nanos: nanos}
+}
+
 func (x *Timestamp) Reset() {
 	*x = Timestamp{}
 	if protoimpl.UnsafeEnabled {
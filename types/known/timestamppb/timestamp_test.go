@@ -15,6 +15,7 @@ import (
 	"google.golang.org/protobuf/internal/detrand"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	durpb "google.golang.org/protobuf/types/known/durationpb"
 	tspb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -102,3 +103,106 @@ type textError string
 
 func (e textError) Error() string     { return string(e) }
 func (e textError) Is(err error) bool { return err != nil && strings.Contains(err.Error(), e.Error()) }
+
+func TestArithmetic(t *testing.T) {
+	ts := func(secs int64, nanos int32) *tspb.Timestamp {
+		return &tspb.Timestamp{Seconds: secs, Nanos: nanos}
+	}
+	dur := func(secs int64, nanos int32) *durpb.Duration {
+		return &durpb.Duration{Seconds: secs, Nanos: nanos}
+	}
+	tests := []struct {
+		x       *tspb.Timestamp
+		d       *durpb.Duration
+		wantAdd *tspb.Timestamp
+	}{
+		{x: nil, d: nil, wantAdd: ts(0, 0)},
+		{x: ts(10, 5e8), d: dur(1, 5e8), wantAdd: ts(12, 0)},
+		{x: ts(10, 0), d: dur(0, -1), wantAdd: ts(9, 999999999)},
+		{x: ts(0, 0), d: dur(-1, -5e8), wantAdd: ts(-2, 5e8)},
+		{x: ts(minTimestamp, 0), d: dur(-1, 0), wantAdd: ts(minTimestamp-1, 0)},
+		{x: ts(maxTimestamp, 999999999), d: dur(0, 1), wantAdd: ts(maxTimestamp+1, 0)},
+		{x: ts(math.MaxInt64, 0), d: dur(1, 0), wantAdd: ts(math.MaxInt64, 999999999)},
+		{x: ts(math.MinInt64, 0), d: dur(0, -1), wantAdd: ts(math.MinInt64, 0)},
+	}
+	for _, tt := range tests {
+		got := tt.x.Add(tt.d)
+		if diff := cmp.Diff(tt.wantAdd, got, protocmp.Transform()); diff != "" {
+			t.Errorf("Add(%v, %v) mismatch (-want +got):\n%s", tt.x, tt.d, diff)
+		}
+		if tt.x.GetSeconds() == math.MaxInt64 || tt.x.GetSeconds() == math.MinInt64 {
+			continue // saturated
+		}
+		if diff := cmp.Diff(tt.d.Add(nil), got.Sub(tt.x), protocmp.Transform()); diff != "" {
+			t.Errorf("Sub(%v, %v) mismatch (-want +got):\n%s", got, tt.x, diff)
+		}
+		if got, want := got.Compare(tt.x), tt.d.Compare(nil); got != want {
+			t.Errorf("Compare() = %v, want %v", got, want)
+		}
+	}
+
+	if got := ts(1, 2e9).Compare(ts(3, 0)); got != 0 {
+		t.Errorf("Compare(denormalized) = %v, want 0", got)
+	}
+	got := ts(0, -1)
+	got.Normalize()
+	if diff := cmp.Diff(ts(-1, 999999999), got, protocmp.Transform()); diff != "" {
+		t.Errorf("Normalize() mismatch (-want +got):\n%s", diff)
+	}
+
+	jan31 := tspb.New(time.Date(2020, time.January, 31, 12, 0, 0, 5, time.UTC))
+	want := tspb.New(time.Date(2021, time.March, 2, 12, 0, 0, 5, time.UTC))
+	if diff := cmp.Diff(want, jan31.AddDate(1, 1, -1), protocmp.Transform()); diff != "" {
+		t.Errorf("AddDate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in         *tspb.Timestamp
+		wantFormat string
+		parse      []string
+	}{{
+		in:         &tspb.Timestamp{},
+		wantFormat: "1970-01-01T00:00:00Z",
+		parse:      []string{"1970-01-01T01:00:00+01:00"},
+	}, {
+		in:         &tspb.Timestamp{Seconds: 63108020, Nanos: 21e6},
+		wantFormat: "1972-01-01T10:00:20.021Z",
+		parse:      []string{"1972-01-01T10:00:20.021000Z", "1972-01-01T05:00:20.021-05:00"},
+	}, {
+		in:         &tspb.Timestamp{Seconds: minTimestamp},
+		wantFormat: "0001-01-01T00:00:00Z",
+	}, {
+		in:         &tspb.Timestamp{Seconds: maxTimestamp, Nanos: 999999999},
+		wantFormat: "9999-12-31T23:59:59.999999999Z",
+	}}
+	for _, tt := range tests {
+		if got := tt.in.Format(); got != tt.wantFormat {
+			t.Errorf("Format(%v) = %q, want %q", tt.in, got, tt.wantFormat)
+		}
+		for _, s := range append([]string{tt.wantFormat}, tt.parse...) {
+			got, err := tspb.Parse(s)
+			if err != nil {
+				t.Errorf("Parse(%q) error: %v", s, err)
+			} else if diff := cmp.Diff(tt.in, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Parse(%q) mismatch (-want +got):\n%s", s, diff)
+			}
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"1970-01-01",
+		"1970-01-01T00:00:00",
+		"1970-01-01T00:00:00.Z",
+		"1970-01-01T00:00:00.0000000001Z",
+		"1970-01-01T00:00:00,5Z",
+		"0000-12-31T23:59:59Z",
+		"0001-01-01T00:00:00+00:01",
+	} {
+		if got, err := tspb.Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", s, got)
+		}
+	}
+}